@@ -0,0 +1,214 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/skip2/go-qrcode"
+
+	"github.com/MowlCoder/go-url-shortener/pkg/client"
+)
+
+var errNoArguments = errors.New("not enough arguments")
+
+// commandEnv is everything command needs to run.
+type commandEnv struct {
+	client  client.Client
+	printer *printer
+	stdin   io.Reader
+	stdout  io.Writer
+	profile profile
+}
+
+type command struct {
+	run         func(ctx context.Context, env *commandEnv, args []string) error
+	usage       string
+	description string
+}
+
+var commands = map[string]command{
+	"shorten": {
+		run:         runShorten,
+		usage:       "shorten <url>...",
+		description: "short urls, urls are read from stdin if no arguments given",
+	},
+	"batch": {
+		run:         runBatch,
+		usage:       "batch <file>",
+		description: "short all urls from file in one request, one url per line, '-' reads stdin",
+	},
+	"list": {
+		run:         runList,
+		usage:       "list",
+		description: "list urls of current user",
+	},
+	"delete": {
+		run:         runDelete,
+		usage:       "delete <id|short url>...",
+		description: "delete urls of current user",
+	},
+	"stats": {
+		run:         runStats,
+		usage:       "stats",
+		description: "show internal stats, client must be in trusted subnet",
+	},
+	"qr": {
+		run:         runQR,
+		usage:       "qr [-o file.png] [-size 256] <id|short url>",
+		description: "print QR code of short url to terminal or save it as PNG",
+	},
+}
+
+func runShorten(ctx context.Context, env *commandEnv, args []string) error {
+	originalURLs := args
+
+	if len(originalURLs) == 0 {
+		var err error
+
+		originalURLs, err = readURLs(env.stdin)
+		if err != nil {
+			return err
+		}
+	}
+
+	if len(originalURLs) == 0 {
+		return fmt.Errorf("%w: url is required", errNoArguments)
+	}
+
+	urls := make([]client.ShortenedURL, 0, len(originalURLs))
+
+	for _, originalURL := range originalURLs {
+		shortURL, err := env.client.ShortURL(ctx, originalURL)
+		if err != nil {
+			return fmt.Errorf("short %s: %w", originalURL, err)
+		}
+
+		urls = append(urls, client.ShortenedURL{ShortURL: shortURL, OriginalURL: originalURL})
+	}
+
+	return env.printer.printURLs(urls)
+}
+
+func runBatch(ctx context.Context, env *commandEnv, args []string) error {
+	if len(args) != 1 {
+		return fmt.Errorf("%w: file is required", errNoArguments)
+	}
+
+	input := env.stdin
+
+	if args[0] != "-" {
+		file, err := os.Open(args[0])
+		if err != nil {
+			return err
+		}
+		defer file.Close()
+
+		input = file
+	}
+
+	originalURLs, err := readURLs(input)
+	if err != nil {
+		return err
+	}
+
+	if len(originalURLs) == 0 {
+		return fmt.Errorf("%w: file has no urls", errNoArguments)
+	}
+
+	urls, err := env.client.ShortBatch(ctx, originalURLs)
+	if err != nil {
+		return err
+	}
+
+	return env.printer.printURLs(urls)
+}
+
+func runList(ctx context.Context, env *commandEnv, args []string) error {
+	urls, err := env.client.ListMine(ctx)
+	if err != nil {
+		return err
+	}
+
+	return env.printer.printURLs(urls)
+}
+
+func runDelete(ctx context.Context, env *commandEnv, args []string) error {
+	if len(args) == 0 {
+		return fmt.Errorf("%w: at least one url is required", errNoArguments)
+	}
+
+	ids := make([]string, 0, len(args))
+	for _, arg := range args {
+		ids = append(ids, shortURLID(arg))
+	}
+
+	taskID, err := env.client.Delete(ctx, ids)
+	if err != nil {
+		return err
+	}
+
+	return env.printer.printDeleteTask(taskID, ids)
+}
+
+func runStats(ctx context.Context, env *commandEnv, args []string) error {
+	s, err := env.client.Stats(ctx)
+	if err != nil {
+		return err
+	}
+
+	return env.printer.printStats(s)
+}
+
+func runQR(ctx context.Context, env *commandEnv, args []string) error {
+	flags := flag.NewFlagSet("qr", flag.ContinueOnError)
+	outputPath := flags.String("o", "", "Path to PNG file, QR code is printed to terminal if empty")
+	size := flags.Int("size", 256, "Size of PNG image in pixels")
+
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	if flags.NArg() != 1 {
+		return fmt.Errorf("%w: short url is required", errNoArguments)
+	}
+
+	shortURL := flags.Arg(0)
+	if !strings.Contains(shortURL, "://") {
+		shortURL = client.NormalizeBaseURL(env.profile.HTTPAddr) + "/" + shortURLID(shortURL)
+	}
+
+	code, err := qrcode.New(shortURL, qrcode.Medium)
+	if err != nil {
+		return err
+	}
+
+	if *outputPath != "" {
+		return code.WriteFile(*size, *outputPath)
+	}
+
+	_, err = fmt.Fprint(env.stdout, code.ToSmallString(false))
+	return err
+}
+
+// readURLs reads not empty lines, lines starting with '#' are skipped.
+func readURLs(r io.Reader) ([]string, error) {
+	urls := make([]string, 0)
+	scanner := bufio.NewScanner(r)
+
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		urls = append(urls, line)
+	}
+
+	return urls, scanner.Err()
+}
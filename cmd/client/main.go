@@ -1,48 +1,152 @@
+// Command client is command line client of URL shortener.
+//
+// Usage:
+//
+//	client [flags] <command> [arguments]
+//
+// Commands are shorten, batch, list, delete, stats and qr. Server issues token on the first request,
+// token is saved to profile file, so urls created by one invocation belong to the user in the next ones.
+// Transport, server addresses passed by flags are saved to the profile too.
 package main
 
 import (
-	"bufio"
+	"context"
+	"errors"
+	"flag"
 	"fmt"
 	"io"
-	"net/http"
 	"os"
-	"strings"
-
-	"github.com/MowlCoder/go-url-shortener/internal/config"
+	"os/signal"
+	"sort"
+	"syscall"
+	"time"
 )
 
+var errUnknownCommand = errors.New("unknown command")
+
+// options are global flags of client.
+type options struct {
+	profileName string
+	profilePath string
+	transport   string
+	httpAddr    string
+	grpcAddr    string
+	output      string
+	timeout     time.Duration
+}
+
 func main() {
-	appConfig := &config.AppConfig{}
-	appConfig.ParseFlags()
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	if err := run(ctx, os.Args[1:], os.Stdin, os.Stdout, os.Stderr); err != nil {
+		if !errors.Is(err, flag.ErrHelp) {
+			fmt.Fprintln(os.Stderr, "error:", err)
+		}
+
+		os.Exit(1)
+	}
+}
+
+func run(ctx context.Context, args []string, stdin io.Reader, stdout io.Writer, stderr io.Writer) error {
+	opts := options{}
+	flags := flag.NewFlagSet("client", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	flags.StringVar(&opts.profileName, "profile", defaultProfileName, "Name of profile")
+	flags.StringVar(&opts.profilePath, "profile-file", defaultProfilePath(), "Path to file with profiles")
+	flags.StringVar(&opts.transport, "transport", "", "Transport to talk to the server: http or grpc")
+	flags.StringVar(&opts.httpAddr, "http-addr", "", "Base address of http server")
+	flags.StringVar(&opts.grpcAddr, "grpc-addr", "", "Address of gRPC server")
+	flags.StringVar(&opts.output, "output", outputTable, "Output format: table or json")
+	flags.DurationVar(&opts.timeout, "timeout", time.Second*30, "Timeout of command")
+	flags.Usage = func() {
+		printUsage(flags)
+	}
 
-	endpoint := "http://" + appConfig.BaseHTTPAddr
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
 
-	fmt.Print("Введите длинный URL: ")
-	reader := bufio.NewReader(os.Stdin)
-	long, err := reader.ReadString('\n')
+	if flags.NArg() == 0 {
+		flags.Usage()
+		return flag.ErrHelp
+	}
+
+	cmd, ok := commands[flags.Arg(0)]
+	if !ok {
+		flags.Usage()
+		return fmt.Errorf("%w: %s", errUnknownCommand, flags.Arg(0))
+	}
+
+	profiles, err := loadProfileFile(opts.profilePath)
 	if err != nil {
-		panic(err)
+		return err
 	}
-	long = strings.TrimSuffix(long, "\n")
 
-	client := &http.Client{}
-	request, err := http.NewRequest(http.MethodPost, endpoint, strings.NewReader(long))
+	currentProfile := profiles.get(opts.profileName)
+	flags.Visit(func(f *flag.Flag) {
+		switch f.Name {
+		case "transport":
+			currentProfile.Transport = opts.transport
+		case "http-addr":
+			currentProfile.HTTPAddr = opts.httpAddr
+		case "grpc-addr":
+			currentProfile.GRPCAddr = opts.grpcAddr
+		}
+	})
+
+	resultPrinter, err := newPrinter(stdout, opts.output)
 	if err != nil {
-		panic(err)
+		return err
 	}
 
-	response, err := client.Do(request)
+	shortenerClient, err := newClient(currentProfile)
 	if err != nil {
-		panic(err)
+		return err
 	}
+	defer shortenerClient.Close()
 
-	fmt.Println("Статус-код ", response.Status)
-	defer response.Body.Close()
+	ctx, cancel := context.WithTimeout(ctx, opts.timeout)
+	defer cancel()
 
-	body, err := io.ReadAll(response.Body)
-	if err != nil {
-		panic(err)
+	cmdErr := cmd.run(ctx, &commandEnv{
+		client:  shortenerClient,
+		printer: resultPrinter,
+		stdin:   stdin,
+		stdout:  stdout,
+		profile: currentProfile,
+	}, flags.Args()[1:])
+
+	currentProfile.Token = shortenerClient.Token()
+	if currentProfile != profiles.Profiles[opts.profileName] {
+		profiles.Profiles[opts.profileName] = currentProfile
+
+		if err := profiles.save(); err != nil {
+			return errors.Join(cmdErr, fmt.Errorf("save profile: %w", err))
+		}
+	}
+
+	return cmdErr
+}
+
+func printUsage(flags *flag.FlagSet) {
+	w := flags.Output()
+
+	fmt.Fprintln(w, "Usage: client [flags] <command> [arguments]")
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "Commands:")
+
+	names := make([]string, 0, len(commands))
+	for name := range commands {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		fmt.Fprintf(w, "  %-45s %s\n", commands[name].usage, commands[name].description)
 	}
 
-	fmt.Println(string(body))
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "Flags:")
+	flags.PrintDefaults()
 }
@@ -0,0 +1,98 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/MowlCoder/go-url-shortener/pkg/client"
+)
+
+func TestRun_PersistsToken(t *testing.T) {
+	var receivedTokens []string
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if cookie, err := r.Cookie(client.TokenCookieName); err == nil {
+			receivedTokens = append(receivedTokens, cookie.Value)
+		} else {
+			http.SetCookie(w, &http.Cookie{Name: client.TokenCookieName, Value: "issued-token"})
+		}
+
+		switch r.URL.Path {
+		case "/api/shorten":
+			w.WriteHeader(http.StatusCreated)
+			json.NewEncoder(w).Encode(map[string]string{"result": "http://short/abc"})
+		case "/api/user/urls":
+			json.NewEncoder(w).Encode([]client.ShortenedURL{{ShortURL: "http://short/abc", OriginalURL: "https://example.com"}})
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	profilePath := filepath.Join(t.TempDir(), "client.json")
+
+	var stdout, stderr bytes.Buffer
+	err := run(context.Background(), []string{
+		"-profile-file", profilePath,
+		"-http-addr", server.URL,
+		"shorten", "https://example.com",
+	}, nil, &stdout, &stderr)
+	require.NoError(t, err)
+	assert.Contains(t, stdout.String(), "http://short/abc")
+
+	profiles, err := loadProfileFile(profilePath)
+	require.NoError(t, err)
+	assert.Equal(t, "issued-token", profiles.Profiles[defaultProfileName].Token)
+	assert.Equal(t, server.URL, profiles.Profiles[defaultProfileName].HTTPAddr)
+
+	stdout.Reset()
+	err = run(context.Background(), []string{
+		"-profile-file", profilePath,
+		"-output", outputJSON,
+		"list",
+	}, nil, &stdout, &stderr)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"issued-token"}, receivedTokens)
+
+	var urls []client.ShortenedURL
+	require.NoError(t, json.Unmarshal(stdout.Bytes(), &urls))
+	assert.Equal(t, []client.ShortenedURL{{ShortURL: "http://short/abc", OriginalURL: "https://example.com"}}, urls)
+}
+
+func TestRun_Errors(t *testing.T) {
+	profilePath := filepath.Join(t.TempDir(), "client.json")
+
+	var stdout, stderr bytes.Buffer
+
+	err := run(context.Background(), []string{"-profile-file", profilePath, "unknown"}, nil, &stdout, &stderr)
+	assert.ErrorIs(t, err, errUnknownCommand)
+
+	err = run(context.Background(), []string{"-profile-file", profilePath, "-transport", "ftp", "list"}, nil, &stdout, &stderr)
+	assert.ErrorIs(t, err, errUnknownTransport)
+
+	err = run(context.Background(), []string{"-profile-file", profilePath, "-output", "xml", "list"}, nil, &stdout, &stderr)
+	assert.ErrorIs(t, err, errUnknownOutput)
+
+	err = run(context.Background(), []string{"-profile-file", profilePath, "delete"}, nil, &stdout, &stderr)
+	assert.ErrorIs(t, err, errNoArguments)
+}
+
+func TestShortURLID(t *testing.T) {
+	assert.Equal(t, "abc", shortURLID("abc"))
+	assert.Equal(t, "abc", shortURLID("http://localhost:8080/abc"))
+	assert.Equal(t, "abc", shortURLID("/abc"))
+}
+
+func TestReadURLs(t *testing.T) {
+	urls, err := readURLs(bytes.NewBufferString("https://a.com\n\n# comment\n  https://b.com  \n"))
+	require.NoError(t, err)
+	assert.Equal(t, []string{"https://a.com", "https://b.com"}, urls)
+}
@@ -0,0 +1,99 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"text/tabwriter"
+
+	"github.com/MowlCoder/go-url-shortener/pkg/client"
+)
+
+// Output formats.
+const (
+	outputTable = "table"
+	outputJSON  = "json"
+)
+
+var errUnknownOutput = errors.New("unknown output format")
+
+// printer prints command results as aligned table or as JSON.
+type printer struct {
+	w      io.Writer
+	format string
+}
+
+func newPrinter(w io.Writer, format string) (*printer, error) {
+	if format != outputTable && format != outputJSON {
+		return nil, fmt.Errorf("%w: %s", errUnknownOutput, format)
+	}
+
+	return &printer{
+		w:      w,
+		format: format,
+	}, nil
+}
+
+func (p *printer) printURLs(urls []client.ShortenedURL) error {
+	if p.format == outputJSON {
+		return p.printJSON(urls)
+	}
+
+	rows := make([][]string, 0, len(urls))
+	for _, url := range urls {
+		rows = append(rows, []string{url.ShortURL, url.OriginalURL})
+	}
+
+	return p.printTable([]string{"SHORT URL", "ORIGINAL URL"}, rows)
+}
+
+func (p *printer) printDeleteTask(taskID string, shortURLs []string) error {
+	if p.format == outputJSON {
+		return p.printJSON(struct {
+			TaskID    string   `json:"task_id"`
+			ShortURLs []string `json:"short_urls"`
+		}{
+			TaskID:    taskID,
+			ShortURLs: shortURLs,
+		})
+	}
+
+	_, err := fmt.Fprintf(p.w, "deletion of %d urls is scheduled, task id: %s\n", len(shortURLs), taskID)
+	return err
+}
+
+func (p *printer) printStats(s *client.Stats) error {
+	if p.format == outputJSON {
+		return p.printJSON(s)
+	}
+
+	return p.printTable([]string{"URLS", "USERS"}, [][]string{
+		{fmt.Sprint(s.URLs), fmt.Sprint(s.Users)},
+	})
+}
+
+func (p *printer) printJSON(v interface{}) error {
+	encoder := json.NewEncoder(p.w)
+	encoder.SetIndent("", "  ")
+
+	return encoder.Encode(v)
+}
+
+func (p *printer) printTable(header []string, rows [][]string) error {
+	w := tabwriter.NewWriter(p.w, 0, 0, 2, ' ', 0)
+
+	for _, row := range append([][]string{header}, rows...) {
+		for i, cell := range row {
+			if i > 0 {
+				fmt.Fprint(w, "\t")
+			}
+
+			fmt.Fprint(w, cell)
+		}
+
+		fmt.Fprintln(w)
+	}
+
+	return w.Flush()
+}
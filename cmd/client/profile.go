@@ -0,0 +1,99 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+const (
+	defaultProfileName = "default"
+	defaultHTTPAddr    = "http://localhost:8080"
+	defaultGRPCAddr    = "localhost:3200"
+)
+
+// profile contains server addresses and token of one user, so ownership of urls is kept between invocations.
+type profile struct {
+	Transport string `json:"transport,omitempty"`
+	HTTPAddr  string `json:"http_addr,omitempty"`
+	GRPCAddr  string `json:"grpc_addr,omitempty"`
+	Token     string `json:"token,omitempty"`
+}
+
+// profileFile is file with named profiles.
+type profileFile struct {
+	Profiles map[string]profile `json:"profiles"`
+	path     string
+}
+
+// defaultProfilePath returns path to profile file in user config directory.
+func defaultProfilePath() string {
+	configDir, err := os.UserConfigDir()
+	if err != nil {
+		return ".shortener-client.json"
+	}
+
+	return filepath.Join(configDir, "go-url-shortener", "client.json")
+}
+
+// loadProfileFile reads profile file. Not existing file is treated as empty.
+func loadProfileFile(path string) (*profileFile, error) {
+	file := &profileFile{
+		Profiles: make(map[string]profile),
+		path:     path,
+	}
+
+	rawFile, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return file, nil
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	if err := json.Unmarshal(rawFile, file); err != nil {
+		return nil, fmt.Errorf("parse profile file %s: %w", path, err)
+	}
+
+	if file.Profiles == nil {
+		file.Profiles = make(map[string]profile)
+	}
+
+	return file, nil
+}
+
+// get returns profile by name with defaults for empty addresses.
+func (f *profileFile) get(name string) profile {
+	p := f.Profiles[name]
+
+	if p.Transport == "" {
+		p.Transport = transportHTTP
+	}
+
+	if p.HTTPAddr == "" {
+		p.HTTPAddr = defaultHTTPAddr
+	}
+
+	if p.GRPCAddr == "" {
+		p.GRPCAddr = defaultGRPCAddr
+	}
+
+	return p
+}
+
+// save writes profile file. File contains tokens, so it is readable only by owner.
+func (f *profileFile) save() error {
+	if err := os.MkdirAll(filepath.Dir(f.path), 0700); err != nil {
+		return err
+	}
+
+	rawFile, err := json.MarshalIndent(f, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(f.path, append(rawFile, '\n'), 0600)
+}
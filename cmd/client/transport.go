@@ -0,0 +1,40 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"path"
+	"strings"
+
+	"github.com/MowlCoder/go-url-shortener/pkg/client"
+)
+
+// Transports to talk to the server.
+const (
+	transportHTTP = "http"
+	transportGRPC = "grpc"
+)
+
+var errUnknownTransport = errors.New("unknown transport")
+
+func newClient(p profile) (client.Client, error) {
+	switch p.Transport {
+	case transportHTTP:
+		return client.NewHTTPClient(p.HTTPAddr, client.WithToken(p.Token))
+	case transportGRPC:
+		return client.NewGRPCClient(p.GRPCAddr, client.WithToken(p.Token))
+	default:
+		return nil, fmt.Errorf("%w: %s", errUnknownTransport, p.Transport)
+	}
+}
+
+// shortURLID returns id of short url. Both id and full short url are accepted.
+func shortURLID(shortURL string) string {
+	parsedURL, err := url.Parse(shortURL)
+	if err != nil || parsedURL.Host == "" {
+		return strings.Trim(shortURL, "/")
+	}
+
+	return path.Base(parsedURL.Path)
+}
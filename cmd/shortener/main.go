@@ -1,8 +1,11 @@
 package main
 
 import (
-	"compress/gzip"
 	"context"
+	"crypto/tls"
+	"encoding/json"
+	"errors"
+	"flag"
 	"fmt"
 	"log"
 	"math/rand"
@@ -13,22 +16,19 @@ import (
 	"syscall"
 	"time"
 
-	"github.com/go-chi/chi/v5"
-	"github.com/go-chi/chi/v5/middleware"
 	"github.com/joho/godotenv"
+	"go.uber.org/zap"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
 
 	_ "github.com/jackc/pgx/v5/stdlib"
 
 	"github.com/MowlCoder/go-url-shortener/internal/config"
-	grpcHandlers "github.com/MowlCoder/go-url-shortener/internal/handlers/grpc"
-	httpHandlers "github.com/MowlCoder/go-url-shortener/internal/handlers/http"
-	"github.com/MowlCoder/go-url-shortener/internal/interceptors"
+	"github.com/MowlCoder/go-url-shortener/internal/geoip"
+	"github.com/MowlCoder/go-url-shortener/internal/lifecycle"
 	"github.com/MowlCoder/go-url-shortener/internal/logger"
-	customMiddlewares "github.com/MowlCoder/go-url-shortener/internal/middlewares"
-	"github.com/MowlCoder/go-url-shortener/internal/services"
+	"github.com/MowlCoder/go-url-shortener/internal/server"
 	"github.com/MowlCoder/go-url-shortener/internal/storage"
-	"github.com/MowlCoder/go-url-shortener/proto"
 )
 
 var (
@@ -37,6 +37,12 @@ var (
 	buildCommit  string
 )
 
+// @title URL shortener
+// @version 1.0
+// @description URL shortener helps to work with long urls, allow to save your long url and give you a small url, that point to your long url
+// @description Errors are sent as JSON with human readable message (error), machine readable code and invalid fields (details).
+// @description Client, that sends Accept: application/problem+json, gets RFC 7807 problem with the same code and details instead.
+// @BasePath /
 func main() {
 	rand.Seed(time.Now().UnixNano())
 
@@ -45,74 +51,87 @@ func main() {
 		log.Println("No .env provided")
 	}
 
-	appConfig := &config.AppConfig{}
-	appConfig.ParseFlags()
+	appConfig, commandLine, err := config.Load(os.Args[1:], nil)
+	if errors.Is(err, flag.ErrHelp) {
+		return
+	}
+	if err != nil {
+		log.Fatalf("invalid config:\n%v", err)
+	}
+
+	if commandLine.PrintConfig {
+		if err := printConfig(appConfig); err != nil {
+			log.Fatal(err)
+		}
+		return
+	}
 
 	customLogger, err := logger.NewLogger(logger.Options{
-		Level:        logger.LogInfo,
+		Level:        appConfig.LogLevel,
+		Format:       appConfig.LogFormat,
 		IsProduction: appConfig.AppEnvironment == config.AppProductionEnv,
 	})
 	if err != nil {
 		panic(err)
 	}
 
-	gzipWriter, err := gzip.NewWriterLevel(nil, gzip.BestSpeed)
+	urlStorage, err := storage.New(appConfig)
 	if err != nil {
 		panic(err)
 	}
 
-	urlStorage, err := storage.New(appConfig)
+	countryReader, err := geoip.Open(appConfig.GeoIPDatabasePath)
 	if err != nil {
-		panic(err)
+		log.Fatal(err)
+	}
+
+	lifecycleManager := lifecycle.NewManager(customLogger)
+
+	var httpTLSConfig *tls.Config
+	grpcServerOptions := make([]grpc.ServerOption, 0)
+
+	if appConfig.EnableHTTPS {
+		var grpcTLSConfig *tls.Config
+
+		httpTLSConfig, grpcTLSConfig, err = newTLSConfigs(appConfig, customLogger, lifecycleManager)
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		grpcServerOptions = append(grpcServerOptions, grpc.Creds(credentials.NewTLS(grpcTLSConfig)))
 	}
 
-	stringGeneratorService := services.NewStringGenerator()
-	userService := services.NewUserService()
-	deleteURLQueue := services.NewDeleteURLQueue(urlStorage, customLogger, 3)
-	shortenerService := services.NewShortenerService(
-		urlStorage,
-		stringGeneratorService,
-		deleteURLQueue,
-	)
-
-	httpShortenerHandler := httpHandlers.NewShortenerHandler(
-		appConfig,
-		shortenerService,
-	)
-	grpcShortenerHandler := grpcHandlers.NewShortenerHandler(
-		appConfig,
-		shortenerService,
-	)
-
-	httpRouter := makeRouter(
-		httpShortenerHandler,
-		userService,
-		customLogger,
-		gzipWriter,
-		appConfig,
-	)
-	grpcServer := makeGRPCServer(
-		grpcShortenerHandler,
-		userService,
-	)
-
-	workersCtx, workersStopCtx := context.WithCancel(context.Background())
-	go deleteURLQueue.Start(workersCtx)
+	app, err := server.NewApp(appConfig, server.AppOptions{
+		Storage:   urlStorage,
+		Countries: countryReader,
+		Logger:    customLogger,
+		Lifecycle: lifecycleManager,
+		LoadConfig: func() (*config.AppConfig, error) {
+			reloadedConfig, _, err := config.Load(os.Args[1:], nil)
+			return reloadedConfig, err
+		},
+		GRPCServerOptions: grpcServerOptions,
+	})
+	if err != nil {
+		log.Fatal(err)
+	}
 
 	displayBuildInfo()
-	log.Println("URL Shortener server is running on", appConfig.BaseHTTPAddr)
-	log.Println("Config:", appConfig)
+	customLogger.Info("URL Shortener server is running", zap.String("addr", appConfig.BaseHTTPAddr))
+	customLogger.Debug("config", zap.Any("config", appConfig.Redacted()))
 
 	httpServer := http.Server{
-		Addr:    appConfig.BaseHTTPAddr,
-		Handler: httpRouter,
+		Addr:      appConfig.BaseHTTPAddr,
+		Handler:   app.HTTPHandler,
+		TLSConfig: httpTLSConfig,
 	}
 
 	go func() {
 		var err error
 
 		if appConfig.EnableHTTPS {
-			err = httpServer.ListenAndServeTLS(appConfig.SSLPemPath, appConfig.SSLKeyPath)
+			// certificate is taken from TLSConfig
+			err = httpServer.ListenAndServeTLS("", "")
 		} else {
 			err = httpServer.ListenAndServe()
 		}
@@ -128,87 +147,60 @@ func main() {
 			log.Fatal(err)
 		}
 
-		log.Println("gRPC server started on", appConfig.BaseGRPCAddr)
-		if err := grpcServer.Serve(listen); err != nil {
+		customLogger.Info("gRPC server started", zap.String("addr", appConfig.BaseGRPCAddr))
+		if err := app.GRPCServer.Serve(listen); err != nil {
 			log.Fatal(err)
 		}
 	}()
 
+	reloadSignals := make(chan os.Signal, 1)
+	signal.Notify(reloadSignals, syscall.SIGHUP)
+
+	app.Start(reloadSignals, httpServer.Shutdown, func(ctx context.Context) error {
+		return gracefulStopGRPCServer(ctx, app.GRPCServer)
+	})
+	// storage and geoip database are closed only when everything, that uses them, is stopped
+	lifecycleManager.AppendAfterStopped("storage", func(ctx context.Context) error {
+		return urlStorage.Close()
+	})
+	lifecycleManager.AppendAfterStopped("geoip database", func(ctx context.Context) error {
+		return countryReader.Close()
+	})
+	lifecycleManager.SetReady()
+
 	sigs := make(chan os.Signal, 1)
 	signal.Notify(sigs, syscall.SIGINT, syscall.SIGTERM, syscall.SIGINT)
 	<-sigs
 
-	log.Println("start graceful shutdown...")
+	customLogger.Info("start graceful shutdown")
 
-	shutdownCtx, shutdownCtxCancel := context.WithTimeout(context.Background(), 60*time.Second)
+	shutdownCtx, shutdownCtxCancel := context.WithTimeout(context.Background(), appConfig.ShutdownTimeout)
 	defer shutdownCtxCancel()
 
-	go func() {
-		<-shutdownCtx.Done()
-		if shutdownCtx.Err() == context.DeadlineExceeded {
-			log.Fatal("graceful shutdown timed out... forcing exit")
-		}
-	}()
-
-	if err := httpServer.Shutdown(shutdownCtx); err != nil {
+	if err := lifecycleManager.Shutdown(shutdownCtx); err != nil {
 		log.Fatal(err)
 	}
 
-	grpcServer.Stop()
-	workersStopCtx()
-
-	log.Println("graceful shutdown server successfully")
+	customLogger.Info("graceful shutdown server successfully")
 }
 
-// @title URL shortener
-// @version 1.0
-// @description URL shortener helps to work with long urls, allow to save your long url and give you a small url, that point to your long url
-// @BasePath /
-func makeRouter(
-	shortenerHandler *httpHandlers.ShortenerHandler,
-	userService *services.UserService,
-	customLogger *logger.Logger,
-	gzipWriter *gzip.Writer,
-	appConfig *config.AppConfig,
-) http.Handler {
-	mux := chi.NewRouter()
-
-	mux.Use(middleware.RealIP)
-	mux.Use(middleware.Recoverer)
-	mux.Use(customMiddlewares.NewCompressMiddleware(gzipWriter).Handler)
-	mux.Use(func(handler http.Handler) http.Handler {
-		return customMiddlewares.WithLogging(handler, customLogger)
-	})
-	mux.Use(func(handler http.Handler) http.Handler {
-		return customMiddlewares.AuthMiddleware(handler, userService)
-	})
-
-	mux.Group(func(privateRouter chi.Router) {
-		privateRouter.Use(customMiddlewares.TrustedSubnetsMiddleware(appConfig.TrustedSubnet))
-		privateRouter.Get("/api/internal/stats", shortenerHandler.GetStats)
-	})
-
-	mux.Post("/api/shorten/batch", shortenerHandler.ShortBatchURL)
-	mux.Post("/api/shorten", shortenerHandler.ShortURLJSON)
-	mux.Post("/", shortenerHandler.ShortURL)
-	mux.Delete("/api/user/urls", shortenerHandler.DeleteURLs)
-	mux.Get("/api/user/urls", shortenerHandler.GetMyURLs)
-	mux.Get("/ping", shortenerHandler.Ping)
-	mux.Get("/{id}", shortenerHandler.RedirectToURLByID)
-
-	return mux
-}
+// gracefulStopGRPCServer stops gRPC server waiting for in-flight calls.
+// If ctx is done earlier, server is stopped forcibly.
+func gracefulStopGRPCServer(ctx context.Context, server *grpc.Server) error {
+	stopped := make(chan struct{})
 
-func makeGRPCServer(
-	shortenerHandler *grpcHandlers.ShortenerHandler,
-	userService *services.UserService,
-) *grpc.Server {
-	grpcServer := grpc.NewServer(
-		grpc.UnaryInterceptor(interceptors.CreateAuthInterceptor(userService)),
-	)
-	proto.RegisterShortenerServer(grpcServer, shortenerHandler)
+	go func() {
+		server.GracefulStop()
+		close(stopped)
+	}()
 
-	return grpcServer
+	select {
+	case <-stopped:
+		return nil
+	case <-ctx.Done():
+		server.Stop()
+		return ctx.Err()
+	}
 }
 
 func displayBuildInfo() {
@@ -230,3 +222,11 @@ func displayBuildInfo() {
 	fmt.Printf("Build commit: %s\n", buildCommit)
 	fmt.Println("==========================================================")
 }
+
+// printConfig prints configuration as json with hidden secrets.
+func printConfig(appConfig *config.AppConfig) error {
+	encoder := json.NewEncoder(os.Stdout)
+	encoder.SetIndent("", "  ")
+
+	return encoder.Encode(appConfig.Redacted())
+}
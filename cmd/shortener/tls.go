@@ -0,0 +1,80 @@
+package main
+
+import (
+	"context"
+	"crypto/tls"
+	"log"
+	"net/http"
+
+	"golang.org/x/crypto/acme"
+
+	"github.com/MowlCoder/go-url-shortener/internal/certs"
+	"github.com/MowlCoder/go-url-shortener/internal/config"
+	"github.com/MowlCoder/go-url-shortener/internal/lifecycle"
+	"github.com/MowlCoder/go-url-shortener/internal/logger"
+)
+
+// newTLSConfigs creates TLS configs of http and gRPC servers. Certificates are issued by ACME server,
+// when ACME domains are configured, otherwise they are loaded from files and reloaded on change.
+// Background jobs, that keep certificates up to date, are stopped by lifecycle manager.
+func newTLSConfigs(
+	appConfig *config.AppConfig,
+	customLogger *logger.Logger,
+	lifecycleManager *lifecycle.Manager,
+) (*tls.Config, *tls.Config, error) {
+	var httpTLSConfig *tls.Config
+	var getCertificate func(*tls.ClientHelloInfo) (*tls.Certificate, error)
+
+	if appConfig.ACMEDomains != "" {
+		manager, err := certs.NewACMEManager(certs.ACMEOptions{
+			Domains:      appConfig.ACMEDomains,
+			DirectoryURL: appConfig.ACMEDirectoryURL,
+			Email:        appConfig.ACMEEmail,
+			CacheDir:     appConfig.ACMECacheDir,
+			RootCAPath:   appConfig.ACMERootCAPath,
+		})
+		if err != nil {
+			return nil, nil, err
+		}
+
+		getCertificate = manager.GetCertificate
+		httpTLSConfig = certs.NewServerTLSConfig(getCertificate, "h2", "http/1.1", acme.ALPNProto)
+
+		if appConfig.ACMEHTTPAddr != "" {
+			challengeServer := &http.Server{
+				Addr:    appConfig.ACMEHTTPAddr,
+				Handler: manager.HTTPHandler(nil),
+			}
+
+			go func() {
+				if err := challengeServer.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+					log.Fatal(err)
+				}
+			}()
+
+			lifecycleManager.Append("acme challenge server", challengeServer.Shutdown)
+		}
+	} else {
+		reloader, err := certs.NewReloader(appConfig.SSLPemPath, appConfig.SSLKeyPath, appConfig.CertReloadInterval, customLogger)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		reloaderCtx, stopReloader := context.WithCancel(context.Background())
+		go reloader.Start(reloaderCtx)
+
+		lifecycleManager.Append("certificate reloader", lifecycle.WaitDone(stopReloader, reloader.Done()))
+
+		getCertificate = reloader.GetCertificate
+		httpTLSConfig = certs.NewServerTLSConfig(getCertificate, "h2", "http/1.1")
+	}
+
+	grpcTLSConfig := certs.NewServerTLSConfig(getCertificate, "h2")
+	if appConfig.GRPCClientCAPath != "" {
+		if err := certs.RequireClientCertificate(grpcTLSConfig, appConfig.GRPCClientCAPath); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	return httpTLSConfig, grpcTLSConfig, nil
+}
@@ -0,0 +1,162 @@
+// Package apierror is error model of http api. Every error has http status, machine readable code,
+// human readable message and optional details about invalid fields of request.
+// Errors are sent as JSON or as RFC 7807 problem, when client accepts application/problem+json.
+package apierror
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/MowlCoder/go-url-shortener/internal/domain"
+)
+
+// Machine readable codes of errors.
+const (
+	CodeInvalidRequest       = "invalid_request"
+	CodeValidationFailed     = "validation_failed"
+	CodeUnauthorized         = "unauthorized"
+	CodeForbidden            = "forbidden"
+	CodeNotFound             = "not_found"
+	CodeGone                 = "gone"
+	CodeUnsupportedMediaType = "unsupported_media_type"
+	CodeRequestTooLarge      = "request_too_large"
+	CodeInternal             = "internal_error"
+
+	CodeURLNotFound        = "url_not_found"
+	CodeShortURLConflict   = "short_url_conflict"
+	CodeDeleteTaskNotFound = "delete_task_not_found"
+	CodeWebhookNotFound    = "webhook_not_found"
+	CodeInvalidWebhook     = "invalid_webhook"
+	CodeImportJobNotFound  = "import_job_not_found"
+	CodeUnsupportedFormat  = "unsupported_format"
+	CodeInvalidImportFile  = "invalid_import_file"
+	CodeTooManyImportRows  = "too_many_import_rows"
+	CodeInvalidConfig      = "invalid_config"
+	CodeMigrationsPending  = "migrations_pending"
+)
+
+// Errors, that are not caused by domain errors.
+var (
+	ErrUnauthorized = New(http.StatusUnauthorized, CodeUnauthorized, "user is not authenticated")
+	ErrForbidden    = New(http.StatusForbidden, CodeForbidden, "access is denied")
+	ErrInternal     = New(http.StatusInternalServerError, CodeInternal, "internal server error")
+)
+
+// FieldError describes invalid field of request.
+type FieldError struct {
+	Field   string `json:"field"`
+	Message string `json:"message"`
+}
+
+// Error is error of http api.
+type Error struct {
+	// cause is original error, it is logged and never sent to client.
+	cause   error
+	Code    string
+	Message string
+	Details []FieldError
+	Status  int
+}
+
+// New is constructor function to create Error.
+func New(status int, code string, message string, details ...FieldError) *Error {
+	return &Error{
+		Status:  status,
+		Code:    code,
+		Message: message,
+		Details: details,
+	}
+}
+
+// InvalidRequest creates error about request body, that can't be read or parsed.
+func InvalidRequest(err error) *Error {
+	return New(http.StatusBadRequest, CodeInvalidRequest, fmt.Sprintf("invalid request body: %s", err))
+}
+
+// Validation creates error about invalid fields of request.
+func Validation(details ...FieldError) *Error {
+	return New(http.StatusBadRequest, CodeValidationFailed, "request validation failed", details...)
+}
+
+// Error returns message of error.
+func (e *Error) Error() string {
+	return e.Message
+}
+
+// Unwrap returns original error, if error was created from it.
+func (e *Error) Unwrap() error {
+	return e.cause
+}
+
+// WithStatus returns copy of error with other http status. It is used, when endpoint keeps
+// status of previous versions of api.
+func (e *Error) WithStatus(status int) *Error {
+	copied := *e
+	copied.Status = status
+
+	return &copied
+}
+
+type domainError struct {
+	err     error
+	code    string
+	field   string
+	status  int
+	exposed bool
+}
+
+// domainErrors maps domain errors to http errors. Message of exposed error is sent to client,
+// field is set for errors caused by invalid field of request.
+var domainErrors = []domainError{
+	{err: domain.ErrURLNotFound, status: http.StatusNotFound, code: CodeURLNotFound, exposed: true},
+	{err: domain.ErrURLDeleted, status: http.StatusGone, code: CodeGone, exposed: true},
+	{err: domain.ErrShortURLConflict, status: http.StatusConflict, code: CodeShortURLConflict, exposed: true},
+	{err: domain.ErrDeleteTaskNotFound, status: http.StatusNotFound, code: CodeDeleteTaskNotFound, exposed: true},
+	{err: domain.ErrWebhookNotFound, status: http.StatusNotFound, code: CodeWebhookNotFound, exposed: true},
+	{err: domain.ErrInvalidWebhookURL, status: http.StatusBadRequest, code: CodeValidationFailed, field: "url", exposed: true},
+	{err: domain.ErrInvalidWebhookEvent, status: http.StatusBadRequest, code: CodeValidationFailed, field: "events", exposed: true},
+	{err: domain.ErrImportJobNotFound, status: http.StatusNotFound, code: CodeImportJobNotFound, exposed: true},
+	{err: domain.ErrUnsupportedFormat, status: http.StatusBadRequest, code: CodeUnsupportedFormat, exposed: true},
+	{err: domain.ErrInvalidImportFile, status: http.StatusBadRequest, code: CodeInvalidImportFile, exposed: true},
+	{err: domain.ErrTooManyImportRows, status: http.StatusBadRequest, code: CodeTooManyImportRows, exposed: true},
+	{err: domain.ErrInvalidRedirectType, status: http.StatusBadRequest, code: CodeValidationFailed, field: "redirect_type", exposed: true},
+	{err: domain.ErrInvalidRedirectRules, status: http.StatusBadRequest, code: CodeValidationFailed, field: "rules", exposed: true},
+	{err: domain.ErrInvalidConfig, status: http.StatusBadRequest, code: CodeInvalidConfig, exposed: true},
+	{err: domain.ErrMigrationsPending, status: http.StatusServiceUnavailable, code: CodeMigrationsPending},
+}
+
+// FromError converts err to Error. Known domain errors get their status and code, any other error
+// is internal error, message of which is hidden from client.
+func FromError(err error) *Error {
+	var apiErr *Error
+	if errors.As(err, &apiErr) {
+		return apiErr
+	}
+
+	for _, mapping := range domainErrors {
+		if !errors.Is(err, mapping.err) {
+			continue
+		}
+
+		message := http.StatusText(mapping.status)
+		if mapping.exposed {
+			message = err.Error()
+		}
+
+		result := New(mapping.status, mapping.code, message)
+		result.cause = err
+
+		if mapping.field != "" {
+			result.Message = "request validation failed"
+			result.Details = []FieldError{{Field: mapping.field, Message: message}}
+		}
+
+		return result
+	}
+
+	result := *ErrInternal
+	result.cause = err
+
+	return &result
+}
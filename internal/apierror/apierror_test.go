@@ -0,0 +1,136 @@
+package apierror
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/MowlCoder/go-url-shortener/internal/domain"
+)
+
+func TestFromError(t *testing.T) {
+	testCases := []struct {
+		err             error
+		name            string
+		expectedCode    string
+		expectedMessage string
+		expectedDetails []FieldError
+		expectedStatus  int
+	}{
+		{
+			name:            "api error",
+			err:             ErrUnauthorized,
+			expectedStatus:  http.StatusUnauthorized,
+			expectedCode:    CodeUnauthorized,
+			expectedMessage: "user is not authenticated",
+		},
+		{
+			name:            "wrapped domain error",
+			err:             fmt.Errorf("get job: %w", domain.ErrImportJobNotFound),
+			expectedStatus:  http.StatusNotFound,
+			expectedCode:    CodeImportJobNotFound,
+			expectedMessage: "get job: import job not found",
+		},
+		{
+			name:            "domain error of field",
+			err:             domain.ErrInvalidWebhookURL,
+			expectedStatus:  http.StatusBadRequest,
+			expectedCode:    CodeValidationFailed,
+			expectedMessage: "request validation failed",
+			expectedDetails: []FieldError{{Field: "url", Message: domain.ErrInvalidWebhookURL.Error()}},
+		},
+		{
+			name:            "hidden domain error",
+			err:             fmt.Errorf("%w: 3 pending", domain.ErrMigrationsPending),
+			expectedStatus:  http.StatusServiceUnavailable,
+			expectedCode:    CodeMigrationsPending,
+			expectedMessage: "Service Unavailable",
+		},
+		{
+			name:            "unknown error",
+			err:             errors.New("connection refused"),
+			expectedStatus:  http.StatusInternalServerError,
+			expectedCode:    CodeInternal,
+			expectedMessage: "internal server error",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			apiErr := FromError(tc.err)
+
+			assert.Equal(t, tc.expectedStatus, apiErr.Status)
+			assert.Equal(t, tc.expectedCode, apiErr.Code)
+			assert.Equal(t, tc.expectedMessage, apiErr.Message)
+			assert.Equal(t, tc.expectedDetails, apiErr.Details)
+			assert.ErrorIs(t, apiErr, tc.err)
+		})
+	}
+
+	t.Run("internal error is not shared", func(t *testing.T) {
+		FromError(errors.New("first"))
+
+		assert.Nil(t, ErrInternal.Unwrap())
+	})
+}
+
+func TestWrite(t *testing.T) {
+	err := Validation(FieldError{Field: "url", Message: "must not be empty"})
+
+	t.Run("json", func(t *testing.T) {
+		r := httptest.NewRequest(http.MethodPost, "/api/shorten", nil)
+		w := httptest.NewRecorder()
+
+		Write(w, r, err)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Equal(t, "application/json", w.Header().Get("content-type"))
+
+		var body Response
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &body))
+		assert.Equal(t, Response{
+			Error:   "request validation failed",
+			Code:    CodeValidationFailed,
+			Details: []FieldError{{Field: "url", Message: "must not be empty"}},
+		}, body)
+	})
+
+	t.Run("problem", func(t *testing.T) {
+		r := httptest.NewRequest(http.MethodPost, "/api/shorten", nil)
+		r.Header.Set("Accept", "application/json, application/problem+json;q=0.9")
+		w := httptest.NewRecorder()
+
+		Write(w, r, err)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Equal(t, ProblemContentType, w.Header().Get("content-type"))
+
+		var body Problem
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &body))
+		assert.Equal(t, Problem{
+			Type:     "about:blank",
+			Title:    "Bad Request",
+			Status:   http.StatusBadRequest,
+			Detail:   "request validation failed",
+			Instance: "/api/shorten",
+			Code:     CodeValidationFailed,
+			Details:  []FieldError{{Field: "url", Message: "must not be empty"}},
+		}, body)
+	})
+
+	t.Run("status is kept", func(t *testing.T) {
+		r := httptest.NewRequest(http.MethodGet, "/abc", nil)
+		w := httptest.NewRecorder()
+
+		Write(w, r, FromError(domain.ErrURLNotFound).WithStatus(http.StatusBadRequest))
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Contains(t, w.Body.String(), CodeURLNotFound)
+	})
+}
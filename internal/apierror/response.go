@@ -0,0 +1,88 @@
+package apierror
+
+import (
+	"encoding/json"
+	"mime"
+	"net/http"
+	"strings"
+
+	"go.uber.org/zap"
+
+	"github.com/MowlCoder/go-url-shortener/internal/logger"
+)
+
+// ProblemContentType is content type of RFC 7807 problem details.
+const ProblemContentType = "application/problem+json"
+
+// Response is JSON body of error. Error field is kept for clients of previous versions of api.
+type Response struct {
+	Error   string       `json:"error" example:"request validation failed"`
+	Code    string       `json:"code" example:"validation_failed"`
+	Details []FieldError `json:"details,omitempty"`
+}
+
+// Problem is RFC 7807 problem details of error, code and details are extension members.
+type Problem struct {
+	Type     string       `json:"type" example:"about:blank"`
+	Title    string       `json:"title" example:"Bad Request"`
+	Detail   string       `json:"detail" example:"request validation failed"`
+	Instance string       `json:"instance" example:"/api/shorten"`
+	Code     string       `json:"code" example:"validation_failed"`
+	Details  []FieldError `json:"details,omitempty"`
+	Status   int          `json:"status" example:"400"`
+}
+
+// Write sends err to the client. Error is converted by FromError, internal errors are logged
+// with logger of request. Problem is sent, when client accepts application/problem+json, otherwise Response.
+func Write(w http.ResponseWriter, r *http.Request, err error) {
+	apiErr := FromError(err)
+
+	if apiErr.Status >= http.StatusInternalServerError {
+		logger.FromContext(r.Context()).Error(apiErr.Message, zap.String("code", apiErr.Code), zap.Error(apiErr.cause))
+	}
+
+	var body interface{}
+	contentType := "application/json"
+
+	if acceptsProblem(r) {
+		contentType = ProblemContentType
+		body = Problem{
+			Type:     "about:blank",
+			Title:    http.StatusText(apiErr.Status),
+			Status:   apiErr.Status,
+			Detail:   apiErr.Message,
+			Instance: r.URL.Path,
+			Code:     apiErr.Code,
+			Details:  apiErr.Details,
+		}
+	} else {
+		body = Response{
+			Error:   apiErr.Message,
+			Code:    apiErr.Code,
+			Details: apiErr.Details,
+		}
+	}
+
+	jsonData, marshalErr := json.Marshal(body)
+	if marshalErr != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("content-type", contentType)
+	w.WriteHeader(apiErr.Status)
+	_, _ = w.Write(jsonData)
+}
+
+func acceptsProblem(r *http.Request) bool {
+	for _, value := range r.Header.Values("Accept") {
+		for _, item := range strings.Split(value, ",") {
+			mediaType, _, err := mime.ParseMediaType(strings.TrimSpace(item))
+			if err == nil && mediaType == ProblemContentType {
+				return true
+			}
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,212 @@
+package certs
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"errors"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+type testLogger struct{}
+
+func (testLogger) Info(string, ...zap.Field) {}
+
+func (testLogger) Error(string, ...zap.Field) {}
+
+type testCertificate struct {
+	cert *x509.Certificate
+	key  *ecdsa.PrivateKey
+}
+
+// newTestCertificate creates certificate signed by parent or self-signed certificate, when parent is nil.
+func newTestCertificate(t *testing.T, commonName string, parent *testCertificate) *testCertificate {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(time.Now().UnixNano()),
+		Subject:      pkix.Name{CommonName: commonName},
+		DNSNames:     []string{commonName},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+	}
+
+	signerCert, signerKey := template, key
+	if parent == nil {
+		template.IsCA = true
+		template.BasicConstraintsValid = true
+	} else {
+		signerCert, signerKey = parent.cert, parent.key
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, signerCert, &key.PublicKey, signerKey)
+	require.NoError(t, err)
+
+	cert, err := x509.ParseCertificate(der)
+	require.NoError(t, err)
+
+	return &testCertificate{cert: cert, key: key}
+}
+
+func (c *testCertificate) write(t *testing.T, certPath string, keyPath string) {
+	t.Helper()
+
+	require.NoError(t, os.WriteFile(certPath, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: c.cert.Raw}), 0600))
+
+	if keyPath != "" {
+		rawKey, err := x509.MarshalECPrivateKey(c.key)
+		require.NoError(t, err)
+		require.NoError(t, os.WriteFile(keyPath, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: rawKey}), 0600))
+	}
+}
+
+func (c *testCertificate) tlsCertificate() tls.Certificate {
+	return tls.Certificate{Certificate: [][]byte{c.cert.Raw}, PrivateKey: c.key}
+}
+
+func commonName(t *testing.T, cert *tls.Certificate) string {
+	leaf, err := x509.ParseCertificate(cert.Certificate[0])
+	require.NoError(t, err)
+
+	return leaf.Subject.CommonName
+}
+
+func TestReloader(t *testing.T) {
+	dir := t.TempDir()
+	certPath := filepath.Join(dir, "server.pem")
+	keyPath := filepath.Join(dir, "server.key")
+
+	newTestCertificate(t, "old.example.com", nil).write(t, certPath, keyPath)
+
+	reloader, err := NewReloader(certPath, keyPath, time.Millisecond*10, testLogger{})
+	require.NoError(t, err)
+
+	cert, err := reloader.GetCertificate(nil)
+	require.NoError(t, err)
+	assert.Equal(t, "old.example.com", commonName(t, cert))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	go reloader.Start(ctx)
+
+	defer func() {
+		cancel()
+		<-reloader.Done()
+	}()
+
+	newTestCertificate(t, "new.example.com", nil).write(t, certPath, keyPath)
+	future := time.Now().Add(time.Minute)
+	require.NoError(t, os.Chtimes(certPath, future, future))
+	require.NoError(t, os.Chtimes(keyPath, future, future))
+
+	assert.Eventually(t, func() bool {
+		cert, _ := reloader.GetCertificate(nil)
+		return commonName(t, cert) == "new.example.com"
+	}, time.Second, time.Millisecond*10)
+
+	// invalid files do not replace working certificate
+	require.NoError(t, os.WriteFile(keyPath, []byte("invalid"), 0600))
+	assert.Error(t, reloader.Reload())
+
+	cert, err = reloader.GetCertificate(nil)
+	require.NoError(t, err)
+	assert.Equal(t, "new.example.com", commonName(t, cert))
+
+	_, err = NewReloader(filepath.Join(dir, "missing.pem"), keyPath, time.Second, testLogger{})
+	assert.Error(t, err)
+
+	_, err = NewReloader(certPath, keyPath, 0, testLogger{})
+	assert.Error(t, err)
+}
+
+func TestRequireClientCertificate(t *testing.T) {
+	dir := t.TempDir()
+	caPath := filepath.Join(dir, "ca.pem")
+
+	ca := newTestCertificate(t, "ca", nil)
+	ca.write(t, caPath, "")
+
+	serverCert := newTestCertificate(t, "localhost", ca).tlsCertificate()
+	serverConfig := NewServerTLSConfig(func(*tls.ClientHelloInfo) (*tls.Certificate, error) {
+		return &serverCert, nil
+	})
+	require.NoError(t, RequireClientCertificate(serverConfig, caPath))
+
+	rootCAs := x509.NewCertPool()
+	rootCAs.AddCert(ca.cert)
+
+	listener, err := tls.Listen("tcp", "127.0.0.1:0", serverConfig)
+	require.NoError(t, err)
+	defer listener.Close()
+
+	handshake := func(clientCerts []tls.Certificate) error {
+		serverErr := make(chan error, 1)
+		go func() {
+			conn, err := listener.Accept()
+			if err != nil {
+				serverErr <- err
+				return
+			}
+			defer conn.Close()
+
+			serverErr <- conn.(*tls.Conn).Handshake()
+		}()
+
+		client, err := tls.Dial("tcp", listener.Addr().String(), &tls.Config{
+			ServerName:   "localhost",
+			RootCAs:      rootCAs,
+			Certificates: clientCerts,
+			MinVersion:   tls.VersionTLS12,
+		})
+		if err == nil {
+			client.Close()
+		}
+
+		return errors.Join(err, <-serverErr)
+	}
+
+	assert.NoError(t, handshake([]tls.Certificate{newTestCertificate(t, "client", ca).tlsCertificate()}))
+	assert.Error(t, handshake(nil))
+	assert.Error(t, handshake([]tls.Certificate{newTestCertificate(t, "stranger", nil).tlsCertificate()}))
+}
+
+func TestNewACMEManager(t *testing.T) {
+	dir := t.TempDir()
+	rootCAPath := filepath.Join(dir, "pebble.minica.pem")
+	newTestCertificate(t, "pebble", nil).write(t, rootCAPath, "")
+
+	manager, err := NewACMEManager(ACMEOptions{
+		Domains:      "short.example.com, www.short.example.com",
+		DirectoryURL: "https://localhost:14000/dir",
+		CacheDir:     dir,
+		RootCAPath:   rootCAPath,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "https://localhost:14000/dir", manager.Client.DirectoryURL)
+	assert.NotNil(t, manager.Client.HTTPClient)
+	assert.NoError(t, manager.HostPolicy(context.Background(), "www.short.example.com"))
+	assert.Error(t, manager.HostPolicy(context.Background(), "evil.example.com"))
+
+	_, err = NewACMEManager(ACMEOptions{})
+	assert.Error(t, err)
+
+	_, err = NewACMEManager(ACMEOptions{Domains: "short.example.com", RootCAPath: filepath.Join(dir, "missing.pem")})
+	assert.Error(t, err)
+}
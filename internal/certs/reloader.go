@@ -0,0 +1,141 @@
+// Package certs provides TLS certificates for http and gRPC servers: certificates from files,
+// that are reloaded when files change, and certificates issued by ACME server.
+package certs
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+type logger interface {
+	Info(msg string, fields ...zap.Field)
+	Error(msg string, fields ...zap.Field)
+}
+
+// Reloader keeps certificate loaded from pem and key files and reloads it, when files are modified.
+// Servers get current certificate by GetCertificate on every handshake, so new certificate is used without restart.
+type Reloader struct {
+	logger   logger
+	cert     *tls.Certificate
+	done     chan struct{}
+	modTime  time.Time
+	certPath string
+	keyPath  string
+	interval time.Duration
+	mu       sync.RWMutex
+}
+
+// NewReloader is constructor function to create Reloader. Certificate is loaded immediately,
+// so invalid files are reported at start of server. Interval must be positive.
+func NewReloader(certPath string, keyPath string, interval time.Duration, logger logger) (*Reloader, error) {
+	if interval <= 0 {
+		return nil, fmt.Errorf("certificate reload interval must be positive, got %s", interval)
+	}
+
+	r := &Reloader{
+		logger:   logger,
+		done:     make(chan struct{}),
+		certPath: certPath,
+		keyPath:  keyPath,
+		interval: interval,
+	}
+
+	if err := r.Reload(); err != nil {
+		return nil, err
+	}
+
+	return r, nil
+}
+
+// GetCertificate returns current certificate, it is used as tls.Config.GetCertificate.
+func (r *Reloader) GetCertificate(*tls.ClientHelloInfo) (*tls.Certificate, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return r.cert, nil
+}
+
+// Reload loads certificate from files. Current certificate is kept, if files are invalid.
+func (r *Reloader) Reload() error {
+	modTime, err := r.lastModTime()
+	if err != nil {
+		return err
+	}
+
+	cert, err := tls.LoadX509KeyPair(r.certPath, r.keyPath)
+	if err != nil {
+		return fmt.Errorf("load certificate: %w", err)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.cert = &cert
+	r.modTime = modTime
+
+	return nil
+}
+
+// Start checks files every interval and reloads certificate, when they are modified. Stops when ctx is done.
+func (r *Reloader) Start(ctx context.Context) {
+	defer close(r.done)
+
+	ticker := time.NewTicker(r.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			modTime, err := r.lastModTime()
+			if err != nil {
+				r.logger.Error("check certificate files", zap.Error(err))
+				continue
+			}
+
+			r.mu.RLock()
+			isModified := modTime.After(r.modTime)
+			r.mu.RUnlock()
+
+			if !isModified {
+				continue
+			}
+
+			if err := r.Reload(); err != nil {
+				r.logger.Error("reload certificate", zap.Error(err))
+				continue
+			}
+
+			r.logger.Info("certificate is reloaded", zap.String("path", r.certPath))
+		}
+	}
+}
+
+// Done returns channel that is closed when watching of files is stopped.
+func (r *Reloader) Done() <-chan struct{} {
+	return r.done
+}
+
+func (r *Reloader) lastModTime() (time.Time, error) {
+	var modTime time.Time
+
+	for _, path := range []string{r.certPath, r.keyPath} {
+		info, err := os.Stat(path)
+		if err != nil {
+			return time.Time{}, fmt.Errorf("stat certificate file: %w", err)
+		}
+
+		if info.ModTime().After(modTime) {
+			modTime = info.ModTime()
+		}
+	}
+
+	return modTime, nil
+}
@@ -0,0 +1,109 @@
+package certs
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+
+	"golang.org/x/crypto/acme"
+	"golang.org/x/crypto/acme/autocert"
+)
+
+// ErrNoCertificates is returned when file has no pem encoded certificates.
+var ErrNoCertificates = errors.New("no certificates in file")
+
+// ACMEOptions configure issuing of certificates by ACME server.
+type ACMEOptions struct {
+	// Domains is comma separated list of domains, certificates are issued only for them.
+	Domains string
+	// DirectoryURL is url of ACME directory, Let's Encrypt is used when it is empty.
+	DirectoryURL string
+	// Email is contact of account in ACME server.
+	Email string
+	// CacheDir is directory, where account key and issued certificates are kept between restarts.
+	CacheDir string
+	// RootCAPath is path to pem file with CA certificate of ACME server itself,
+	// for example of local test server like pebble. System roots are used when it is empty.
+	RootCAPath string
+}
+
+// NewACMEManager creates manager, that issues and renews certificates of given domains.
+// Challenges are solved with TLS-ALPN-01 on https listener, HTTP-01 needs manager.HTTPHandler served on port 80.
+func NewACMEManager(options ACMEOptions) (*autocert.Manager, error) {
+	domains := make([]string, 0)
+	for _, domain := range strings.Split(options.Domains, ",") {
+		if domain = strings.TrimSpace(domain); domain != "" {
+			domains = append(domains, domain)
+		}
+	}
+
+	if len(domains) == 0 {
+		return nil, errors.New("acme: no domains")
+	}
+
+	client := &acme.Client{DirectoryURL: options.DirectoryURL}
+
+	if options.RootCAPath != "" {
+		pool, err := LoadCertPool(options.RootCAPath)
+		if err != nil {
+			return nil, err
+		}
+
+		client.HTTPClient = &http.Client{
+			Transport: &http.Transport{
+				Proxy:           http.ProxyFromEnvironment,
+				TLSClientConfig: &tls.Config{RootCAs: pool, MinVersion: tls.VersionTLS12},
+			},
+		}
+	}
+
+	return &autocert.Manager{
+		Prompt:     autocert.AcceptTOS,
+		Cache:      autocert.DirCache(options.CacheDir),
+		HostPolicy: autocert.HostWhitelist(domains...),
+		Client:     client,
+		Email:      options.Email,
+	}, nil
+}
+
+// NewServerTLSConfig creates config of TLS server, that gets certificate on every handshake,
+// so certificates can be changed without restart.
+func NewServerTLSConfig(getCertificate func(*tls.ClientHelloInfo) (*tls.Certificate, error), nextProtos ...string) *tls.Config {
+	return &tls.Config{
+		MinVersion:     tls.VersionTLS12,
+		GetCertificate: getCertificate,
+		NextProtos:     nextProtos,
+	}
+}
+
+// RequireClientCertificate turns on mutual TLS: clients must present certificate signed by CA from caPath.
+func RequireClientCertificate(config *tls.Config, caPath string) error {
+	pool, err := LoadCertPool(caPath)
+	if err != nil {
+		return err
+	}
+
+	config.ClientCAs = pool
+	config.ClientAuth = tls.RequireAndVerifyClientCert
+
+	return nil
+}
+
+// LoadCertPool loads pem encoded certificates from file to pool.
+func LoadCertPool(path string) (*x509.CertPool, error) {
+	rawContent, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(rawContent) {
+		return nil, fmt.Errorf("%w: %s", ErrNoCertificates, path)
+	}
+
+	return pool, nil
+}
@@ -0,0 +1,109 @@
+// Package clientip resolves ip address of client, that may be behind trusted reverse proxies.
+// The same resolution is used by http and gRPC servers.
+package clientip
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"strings"
+
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+)
+
+// Headers set by reverse proxies. gRPC metadata keys are lowercase names of the same headers.
+const (
+	ForwardedForHeader = "X-Forwarded-For"
+	RealIPHeader       = "X-Real-IP"
+)
+
+type trustedProxies interface {
+	Contains(ip net.IP) bool
+}
+
+// Resolver resolves client ip address. Headers with client address are honoured only when request
+// comes from trusted proxy, otherwise address of connection peer is client address.
+type Resolver struct {
+	proxies trustedProxies
+}
+
+// NewResolver is constructor function to create Resolver with trusted proxies.
+func NewResolver(proxies trustedProxies) *Resolver {
+	return &Resolver{
+		proxies: proxies,
+	}
+}
+
+// FromRequest returns ip address of client of http request.
+func (r *Resolver) FromRequest(request *http.Request) net.IP {
+	return r.resolve(
+		parseHostPort(request.RemoteAddr),
+		request.Header.Values(ForwardedForHeader),
+		request.Header.Get(RealIPHeader),
+	)
+}
+
+// FromGRPC returns ip address of client of gRPC call.
+func (r *Resolver) FromGRPC(ctx context.Context) net.IP {
+	var remoteIP net.IP
+	if p, ok := peer.FromContext(ctx); ok && p.Addr != nil {
+		remoteIP = parseHostPort(p.Addr.String())
+	}
+
+	md, _ := metadata.FromIncomingContext(ctx)
+	realIP := ""
+	if values := md.Get(RealIPHeader); len(values) > 0 {
+		realIP = values[0]
+	}
+
+	return r.resolve(remoteIP, md.Get(ForwardedForHeader), realIP)
+}
+
+// resolve walks X-Forwarded-For from the nearest hop and returns first address, that is not trusted proxy.
+// X-Real-IP is used, when trusted proxy does not send X-Forwarded-For.
+func (r *Resolver) resolve(remoteIP net.IP, forwardedFor []string, realIP string) net.IP {
+	if !r.proxies.Contains(remoteIP) {
+		return remoteIP
+	}
+
+	hops := make([]string, 0)
+	for _, value := range forwardedFor {
+		hops = append(hops, strings.Split(value, ",")...)
+	}
+
+	if len(hops) == 0 {
+		if ip := net.ParseIP(strings.TrimSpace(realIP)); ip != nil {
+			return ip
+		}
+
+		return remoteIP
+	}
+
+	clientIP := remoteIP
+
+	for i := len(hops) - 1; i >= 0; i-- {
+		ip := net.ParseIP(strings.TrimSpace(hops[i]))
+		if ip == nil {
+			// address before invalid hop can't be trusted, the nearest valid one is used
+			break
+		}
+
+		clientIP = ip
+
+		if !r.proxies.Contains(ip) {
+			break
+		}
+	}
+
+	return clientIP
+}
+
+func parseHostPort(address string) net.IP {
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		host = address
+	}
+
+	return net.ParseIP(host)
+}
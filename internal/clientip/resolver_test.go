@@ -0,0 +1,82 @@
+package clientip
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+
+	"github.com/MowlCoder/go-url-shortener/internal/subnets"
+)
+
+func TestResolver(t *testing.T) {
+	trustedProxies, err := subnets.NewTrusted("10.0.0.0/8, fd00::/8")
+	require.NoError(t, err)
+
+	resolver := NewResolver(trustedProxies)
+
+	testCases := []struct {
+		name         string
+		remoteAddr   string
+		forwardedFor []string
+		realIP       string
+		expected     string
+	}{
+		{name: "direct client", remoteAddr: "203.0.113.5:5000", expected: "203.0.113.5"},
+		{name: "headers of not trusted peer are ignored", remoteAddr: "203.0.113.5:5000", forwardedFor: []string{"192.168.1.1"}, realIP: "192.168.1.1", expected: "203.0.113.5"},
+		{name: "real ip from trusted proxy", remoteAddr: "10.0.0.2:5000", realIP: "203.0.113.5", expected: "203.0.113.5"},
+		{name: "forwarded for from trusted proxy", remoteAddr: "10.0.0.2:5000", forwardedFor: []string{"203.0.113.5"}, realIP: "198.51.100.1", expected: "203.0.113.5"},
+		{name: "spoofed hops before client are ignored", remoteAddr: "10.0.0.2:5000", forwardedFor: []string{"192.168.1.1, 203.0.113.5, 10.0.0.3"}, expected: "203.0.113.5"},
+		{name: "several headers", remoteAddr: "10.0.0.2:5000", forwardedFor: []string{"192.168.1.1", "203.0.113.5, 10.0.0.3"}, expected: "203.0.113.5"},
+		{name: "all hops are trusted", remoteAddr: "10.0.0.2:5000", forwardedFor: []string{"10.0.0.4, 10.0.0.3"}, expected: "10.0.0.4"},
+		{name: "invalid hop", remoteAddr: "10.0.0.2:5000", forwardedFor: []string{"203.0.113.5, unknown, 10.0.0.3"}, expected: "10.0.0.3"},
+		{name: "invalid real ip", remoteAddr: "10.0.0.2:5000", realIP: "unknown", expected: "10.0.0.2"},
+		{name: "ipv6 proxy", remoteAddr: "[fd00::2]:5000", forwardedFor: []string{"2001:db8::1"}, expected: "2001:db8::1"},
+		{name: "ipv6 client", remoteAddr: "[2001:db8::1]:5000", expected: "2001:db8::1"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Run("http", func(t *testing.T) {
+				r := httptest.NewRequest(http.MethodGet, "/", nil)
+				r.RemoteAddr = tc.remoteAddr
+				for _, value := range tc.forwardedFor {
+					r.Header.Add(ForwardedForHeader, value)
+				}
+				if tc.realIP != "" {
+					r.Header.Set(RealIPHeader, tc.realIP)
+				}
+
+				assert.Equal(t, net.ParseIP(tc.expected), resolver.FromRequest(r))
+			})
+
+			t.Run("grpc", func(t *testing.T) {
+				addr, err := net.ResolveTCPAddr("tcp", tc.remoteAddr)
+				require.NoError(t, err)
+
+				md := metadata.MD{}
+				for _, value := range tc.forwardedFor {
+					md.Append(ForwardedForHeader, value)
+				}
+				if tc.realIP != "" {
+					md.Set(RealIPHeader, tc.realIP)
+				}
+
+				ctx := peer.NewContext(context.Background(), &peer.Peer{Addr: addr})
+				ctx = metadata.NewIncomingContext(ctx, md)
+
+				assert.Equal(t, net.ParseIP(tc.expected), resolver.FromGRPC(ctx))
+			})
+		})
+	}
+
+	t.Run("grpc without peer", func(t *testing.T) {
+		assert.Nil(t, resolver.FromGRPC(context.Background()))
+	})
+}
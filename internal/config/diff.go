@@ -0,0 +1,47 @@
+package config
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/MowlCoder/go-url-shortener/internal/domain"
+)
+
+// reloadableKeys are json names of settings, that are applied to running server without restart.
+var reloadableKeys = map[string]struct{}{
+	"trusted_subnet":  {},
+	"trusted_proxies": {},
+	"log_level":       {},
+}
+
+// IsReloadable reports whether setting with json name key is applied without restart.
+func IsReloadable(key string) bool {
+	_, ok := reloadableKeys[key]
+	return ok
+}
+
+// Diff returns settings, that have different values in configurations. Keys are json names of settings,
+// secrets are redacted.
+func Diff(oldConfig *AppConfig, newConfig *AppConfig) []domain.ConfigChange {
+	oldValue := reflect.ValueOf(*oldConfig)
+	newValue := reflect.ValueOf(*newConfig)
+	// values are compared before redaction, so change of password is reported too
+	oldRedacted := reflect.ValueOf(oldConfig.Redacted())
+	newRedacted := reflect.ValueOf(newConfig.Redacted())
+	changes := make([]domain.ConfigChange, 0)
+
+	for i := 0; i < oldValue.NumField(); i++ {
+		if oldValue.Field(i).Interface() == newValue.Field(i).Interface() {
+			continue
+		}
+
+		changes = append(changes, domain.ConfigChange{
+			Key: strings.Split(oldValue.Type().Field(i).Tag.Get("json"), ",")[0],
+			Old: fmt.Sprint(oldRedacted.Field(i).Interface()),
+			New: fmt.Sprint(newRedacted.Field(i).Interface()),
+		})
+	}
+
+	return changes
+}
@@ -0,0 +1,259 @@
+package config
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/BurntSushi/toml"
+	"github.com/caarlos0/env/v9"
+	"gopkg.in/yaml.v3"
+)
+
+// ConfigPathEnv is environment variable with path to config file, -c flag takes precedence over it.
+const ConfigPathEnv = "CONFIG"
+
+// ErrUnknownFormat is returned when extension of config file is not .json, .yaml, .yml or .toml.
+var ErrUnknownFormat = errors.New("unknown config file format")
+
+// CommandLine is options of command line, that are not part of configuration.
+type CommandLine struct {
+	// ConfigPath is path to config file.
+	ConfigPath string
+	// PrintConfig asks to print loaded configuration and exit.
+	PrintConfig bool
+}
+
+// Load loads configuration from sources in order of precedence: defaults < config file < environment variables < flags.
+// Only flags, that are explicitly set in args, override other sources. When environment is nil, environment of
+// process is used. Loaded configuration is validated, all found problems are returned at once.
+func Load(args []string, environment map[string]string) (*AppConfig, CommandLine, error) {
+	if environment == nil {
+		environment = processEnvironment()
+	}
+
+	// first pass only finds config file and handles -h, so usage shows defaults
+	commandLine := CommandLine{}
+	if err := newFlagSet(Default(), &commandLine, io.Discard).Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			newFlagSet(Default(), &commandLine, os.Stderr).Usage()
+		}
+
+		return nil, commandLine, err
+	}
+
+	if commandLine.ConfigPath == "" {
+		commandLine.ConfigPath = environment[ConfigPathEnv]
+	}
+
+	appConfig := Default()
+
+	if commandLine.ConfigPath != "" {
+		if err := loadFile(appConfig, commandLine.ConfigPath); err != nil {
+			return nil, commandLine, err
+		}
+	}
+
+	if err := env.ParseWithOptions(appConfig, env.Options{Environment: environment}); err != nil {
+		return nil, commandLine, fmt.Errorf("parse environment: %w", err)
+	}
+
+	// flags are bound to already loaded values, so flags missing in args keep them
+	if err := newFlagSet(appConfig, &commandLine, io.Discard).Parse(args); err != nil {
+		return nil, commandLine, err
+	}
+
+	if err := appConfig.Validate(); err != nil {
+		return nil, commandLine, err
+	}
+
+	return appConfig, commandLine, nil
+}
+
+func newFlagSet(appConfig *AppConfig, commandLine *CommandLine, output io.Writer) *flag.FlagSet {
+	flagSet := flag.NewFlagSet("shortener", flag.ContinueOnError)
+	flagSet.SetOutput(output)
+
+	flagSet.StringVar(&commandLine.ConfigPath, "c", commandLine.ConfigPath, "Path to config file (.json, .yaml, .yml or .toml)")
+	flagSet.BoolVar(&commandLine.PrintConfig, "print-config", commandLine.PrintConfig, "Print loaded configuration with hidden secrets and exit")
+
+	flagSet.StringVar(&appConfig.BaseHTTPAddr, "a", appConfig.BaseHTTPAddr, "Base http address that server running on")
+	flagSet.StringVar(&appConfig.BaseGRPCAddr, "ga", appConfig.BaseGRPCAddr, "Base grpc address that server running on")
+	flagSet.StringVar(&appConfig.BaseShortURLAddr, "b", appConfig.BaseShortURLAddr, "Base short url address")
+	flagSet.StringVar(&appConfig.LogLevel, "log-level", appConfig.LogLevel, "Minimal level of logged messages: debug, info, warn or error")
+	flagSet.StringVar(&appConfig.LogFormat, "log-format", appConfig.LogFormat, "Format of log entries: json or console")
+	flagSet.StringVar(&appConfig.FileStoragePath, "f", appConfig.FileStoragePath, "Storage file path")
+	flagSet.StringVar(&appConfig.DatabaseDSN, "d", appConfig.DatabaseDSN, "Database DSN")
+	flagSet.BoolVar(&appConfig.EnableHTTPS, "s", appConfig.EnableHTTPS, "Enable HTTPS")
+	flagSet.StringVar(&appConfig.SSLKeyPath, "sslk", appConfig.SSLKeyPath, "Path to ssl key file")
+	flagSet.StringVar(&appConfig.SSLPemPath, "sslp", appConfig.SSLPemPath, "Path to ssl pem file")
+	flagSet.StringVar(&appConfig.TrustedSubnet, "t", appConfig.TrustedSubnet, "Comma separated trusted subnets in CIDR format")
+	flagSet.StringVar(&appConfig.TrustedProxies, "trusted-proxies", appConfig.TrustedProxies, "Comma separated subnets or addresses of proxies, whose X-Forwarded-For and X-Real-IP headers are trusted")
+	flagSet.DurationVar(&appConfig.CertReloadInterval, "cert-reload", appConfig.CertReloadInterval, "How often certificate files are checked for changes")
+	flagSet.StringVar(&appConfig.ACMEDomains, "acme-domains", appConfig.ACMEDomains, "Comma separated domains to get certificates from ACME server for")
+	flagSet.StringVar(&appConfig.ACMEDirectoryURL, "acme-directory", appConfig.ACMEDirectoryURL, "ACME directory url, Let's Encrypt by default")
+	flagSet.StringVar(&appConfig.ACMEEmail, "acme-email", appConfig.ACMEEmail, "Contact email of ACME account")
+	flagSet.StringVar(&appConfig.ACMECacheDir, "acme-cache", appConfig.ACMECacheDir, "Directory to keep ACME account and certificates in")
+	flagSet.StringVar(&appConfig.ACMERootCAPath, "acme-root-ca", appConfig.ACMERootCAPath, "Path to CA certificate of ACME server")
+	flagSet.StringVar(&appConfig.ACMEHTTPAddr, "acme-http-addr", appConfig.ACMEHTTPAddr, "Address of server solving ACME HTTP-01 challenges, for example :80")
+	flagSet.StringVar(&appConfig.GRPCClientCAPath, "grpc-client-ca", appConfig.GRPCClientCAPath, "Path to CA certificate of gRPC clients, enables mutual TLS")
+	flagSet.StringVar(&appConfig.CORSAllowedOrigins, "cors", appConfig.CORSAllowedOrigins, "Comma separated origins allowed to call api from browser, * allows any origin without credentials")
+	flagSet.StringVar(&appConfig.CORSAllowedMethods, "cors-methods", appConfig.CORSAllowedMethods, "Comma separated methods allowed in cross-origin requests")
+	flagSet.BoolVar(&appConfig.CORSAllowCredentials, "cors-credentials", appConfig.CORSAllowCredentials, "Allow browsers to send token cookie in cross-origin requests")
+	flagSet.DurationVar(&appConfig.CORSMaxAge, "cors-max-age", appConfig.CORSMaxAge, "How long browsers cache result of preflight request")
+	flagSet.StringVar(&appConfig.CookieDomain, "cookie-domain", appConfig.CookieDomain, "Domain of token cookie")
+	flagSet.StringVar(&appConfig.CookieSameSite, "cookie-same-site", appConfig.CookieSameSite, "SameSite mode of token cookie: lax, strict or none")
+	flagSet.DurationVar(&appConfig.TokenRefreshBefore, "token-refresh", appConfig.TokenRefreshBefore, "Time before token expiry, when new token cookie is issued")
+	flagSet.StringVar(&appConfig.ContentSecurityPolicy, "csp", appConfig.ContentSecurityPolicy, "Content-Security-Policy header, empty value disables it")
+	flagSet.DurationVar(&appConfig.HSTSMaxAge, "hsts-max-age", appConfig.HSTSMaxAge, "Max age of Strict-Transport-Security header sent when HTTPS is enabled")
+	flagSet.StringVar(&appConfig.GeoIPDatabasePath, "geoip-db", appConfig.GeoIPDatabasePath, "Path to country database in MaxMind DB format used by country redirect rules")
+	flagSet.BoolVar(&appConfig.PreviewOpenGraph, "preview-og", appConfig.PreviewOpenGraph, "Add OpenGraph metadata to link preview page")
+	flagSet.DurationVar(&appConfig.DeleteFlushInterval, "dfi", appConfig.DeleteFlushInterval, "Interval of doing pending url deletions")
+	flagSet.IntVar(&appConfig.DeleteBatchSize, "dbs", appConfig.DeleteBatchSize, "Max count of url deletion tasks done at once")
+	flagSet.IntVar(&appConfig.MaxDeleteBacklog, "mdb", appConfig.MaxDeleteBacklog, "Max count of pending url deletion tasks, after which server is not ready")
+	flagSet.DurationVar(&appConfig.ShutdownTimeout, "st", appConfig.ShutdownTimeout, "Max duration of graceful shutdown")
+
+	return flagSet
+}
+
+// loadFile sets values present in config file, format is chosen by file extension.
+func loadFile(appConfig *AppConfig, path string) error {
+	rawContent, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("read config file: %w", err)
+	}
+
+	values := make(map[string]interface{})
+
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".json":
+		decoder := json.NewDecoder(bytes.NewReader(rawContent))
+		decoder.UseNumber()
+		err = decoder.Decode(&values)
+	case ".yaml", ".yml":
+		err = yaml.Unmarshal(rawContent, &values)
+	case ".toml":
+		err = toml.Unmarshal(rawContent, &values)
+	default:
+		return fmt.Errorf("%w: %s", ErrUnknownFormat, path)
+	}
+
+	if err != nil {
+		return fmt.Errorf("parse config file %s: %w", path, err)
+	}
+
+	if err := setValues(appConfig, values); err != nil {
+		return fmt.Errorf("config file %s: %w", path, err)
+	}
+
+	return nil
+}
+
+// setValues sets fields of config by their json names. Durations are accepted as strings like "10s"
+// or as nanoseconds for compatibility with older json files.
+func setValues(appConfig *AppConfig, values map[string]interface{}) error {
+	fields := make(map[string]reflect.Value)
+
+	configValue := reflect.ValueOf(appConfig).Elem()
+	for i := 0; i < configValue.NumField(); i++ {
+		name := strings.Split(configValue.Type().Field(i).Tag.Get("json"), ",")[0]
+		fields[name] = configValue.Field(i)
+	}
+
+	errs := make([]error, 0)
+
+	for name, value := range values {
+		field, ok := fields[name]
+		if !ok {
+			errs = append(errs, fmt.Errorf("%s: unknown key", name))
+			continue
+		}
+
+		if err := setValue(field, value); err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", name, err))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+func setValue(field reflect.Value, value interface{}) error {
+	if field.Type() == reflect.TypeOf(time.Duration(0)) {
+		if text, ok := value.(string); ok {
+			duration, err := time.ParseDuration(text)
+			if err != nil {
+				return err
+			}
+
+			field.SetInt(int64(duration))
+			return nil
+		}
+	}
+
+	switch field.Kind() {
+	case reflect.String:
+		text, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("expected string, got %T", value)
+		}
+
+		field.SetString(text)
+	case reflect.Bool:
+		enabled, ok := value.(bool)
+		if !ok {
+			return fmt.Errorf("expected bool, got %T", value)
+		}
+
+		field.SetBool(enabled)
+	case reflect.Int, reflect.Int64:
+		number, err := toInt(value)
+		if err != nil {
+			return err
+		}
+
+		field.SetInt(number)
+	default:
+		return fmt.Errorf("unsupported field type %s", field.Type())
+	}
+
+	return nil
+}
+
+func toInt(value interface{}) (int64, error) {
+	switch number := value.(type) {
+	case json.Number:
+		return number.Int64()
+	case int:
+		return int64(number), nil
+	case int64:
+		return number, nil
+	case float64:
+		if number != float64(int64(number)) {
+			return 0, fmt.Errorf("expected integer, got %v", number)
+		}
+
+		return int64(number), nil
+	default:
+		return 0, fmt.Errorf("expected integer, got %T", value)
+	}
+}
+
+func processEnvironment() map[string]string {
+	environment := make(map[string]string)
+
+	for _, pair := range os.Environ() {
+		if key, value, ok := strings.Cut(pair, "="); ok {
+			environment[key] = value
+		}
+	}
+
+	return environment
+}
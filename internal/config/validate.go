@@ -0,0 +1,211 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"go.uber.org/zap/zapcore"
+
+	"github.com/MowlCoder/go-url-shortener/internal/logger"
+	"github.com/MowlCoder/go-url-shortener/internal/subnets"
+)
+
+// Validate checks configuration and returns all found problems joined into one error.
+func (appConfig *AppConfig) Validate() error {
+	errs := make([]error, 0)
+	check := func(name string, err error) {
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", name, err))
+		}
+	}
+
+	check("base_http_addr", validateAddress(appConfig.BaseHTTPAddr))
+	check("base_grpc_addr", validateAddress(appConfig.BaseGRPCAddr))
+	check("base_url", validateBaseURL(appConfig.BaseShortURLAddr))
+	check("app_env", validateEnvironment(appConfig.AppEnvironment))
+	check("log_level", validateLogLevel(appConfig.LogLevel))
+	check("log_format", validateLogFormat(appConfig.LogFormat))
+	check("trusted_subnet", validateSubnets(appConfig.TrustedSubnet))
+	check("trusted_proxies", validateSubnets(appConfig.TrustedProxies))
+	check("database_dsn", validateDSN(appConfig.DatabaseDSN))
+	check("cookie_same_site", validateSameSite(appConfig.CookieSameSite))
+	check("cors_allowed_origins", validateCORSOrigins(appConfig.CORSAllowedOrigins, appConfig.CORSAllowCredentials))
+
+	if appConfig.EnableHTTPS {
+		if appConfig.ACMEDomains == "" {
+			check("ssl_pem_path", validateFile(appConfig.SSLPemPath))
+			check("ssl_key_path", validateFile(appConfig.SSLKeyPath))
+		} else {
+			check("acme_root_ca_path", validateOptionalFile(appConfig.ACMERootCAPath))
+		}
+
+		check("grpc_client_ca_path", validateOptionalFile(appConfig.GRPCClientCAPath))
+	}
+
+	check("geoip_database_path", validateOptionalFile(appConfig.GeoIPDatabasePath))
+
+	if appConfig.ACMEHTTPAddr != "" {
+		check("acme_http_addr", validateAddress(appConfig.ACMEHTTPAddr))
+	}
+
+	check("delete_flush_interval", validatePositiveDuration(appConfig.DeleteFlushInterval))
+	check("shutdown_timeout", validatePositiveDuration(appConfig.ShutdownTimeout))
+	check("cert_reload_interval", validatePositiveDuration(appConfig.CertReloadInterval))
+	check("cors_max_age", validateNotNegativeDuration(appConfig.CORSMaxAge))
+	check("token_refresh_before", validateNotNegativeDuration(appConfig.TokenRefreshBefore))
+	check("hsts_max_age", validateNotNegativeDuration(appConfig.HSTSMaxAge))
+	check("delete_batch_size", validatePositiveInt(appConfig.DeleteBatchSize))
+	check("max_delete_backlog", validatePositiveInt(appConfig.MaxDeleteBacklog))
+
+	return errors.Join(errs...)
+}
+
+func validateAddress(address string) error {
+	_, port, err := net.SplitHostPort(address)
+	if err != nil {
+		return err
+	}
+
+	if _, err := strconv.ParseUint(port, 10, 16); err != nil {
+		return fmt.Errorf("invalid port %q", port)
+	}
+
+	return nil
+}
+
+func validateBaseURL(baseURL string) error {
+	parsedURL, err := url.Parse(baseURL)
+	if err != nil {
+		return err
+	}
+
+	if parsedURL.Scheme != "http" && parsedURL.Scheme != "https" {
+		return fmt.Errorf("scheme must be http or https, got %q", parsedURL.Scheme)
+	}
+
+	if parsedURL.Host == "" {
+		return errors.New("host is empty")
+	}
+
+	return nil
+}
+
+func validateEnvironment(environment string) error {
+	switch environment {
+	case "", AppProductionEnv, AppDevEnv:
+		return nil
+	default:
+		return fmt.Errorf("must be %s or %s, got %q", AppProductionEnv, AppDevEnv, environment)
+	}
+}
+
+func validateLogLevel(level string) error {
+	_, err := zapcore.ParseLevel(level)
+	return err
+}
+
+func validateLogFormat(format string) error {
+	switch format {
+	case "", logger.FormatJSON, logger.FormatConsole:
+		return nil
+	default:
+		return fmt.Errorf("must be %s or %s, got %q", logger.FormatJSON, logger.FormatConsole, format)
+	}
+}
+
+func validateSubnets(value string) error {
+	_, err := subnets.Parse(value)
+	return err
+}
+
+func validateDSN(dsn string) error {
+	if dsn == "" {
+		return nil
+	}
+
+	// error of pgx contains DSN itself, so it is not returned to keep password out of logs
+	if _, err := pgx.ParseConfig(dsn); err != nil {
+		return errors.New("cannot parse DSN")
+	}
+
+	return nil
+}
+
+func validateSameSite(mode string) error {
+	switch strings.ToLower(mode) {
+	case "", "lax", "strict", "none":
+		return nil
+	default:
+		return fmt.Errorf("must be lax, strict or none, got %q", mode)
+	}
+}
+
+func validateCORSOrigins(origins string, allowCredentials bool) error {
+	if !allowCredentials {
+		return nil
+	}
+
+	for _, origin := range strings.Split(origins, ",") {
+		if strings.TrimSpace(origin) == "*" {
+			return errors.New("* can not be used with cors_allow_credentials, list origins explicitly")
+		}
+	}
+
+	return nil
+}
+
+func validateFile(path string) error {
+	if path == "" {
+		return errors.New("path is empty")
+	}
+
+	info, err := os.Stat(path)
+	if err != nil {
+		return err
+	}
+
+	if info.IsDir() {
+		return fmt.Errorf("%s is directory", path)
+	}
+
+	return nil
+}
+
+func validateOptionalFile(path string) error {
+	if path == "" {
+		return nil
+	}
+
+	return validateFile(path)
+}
+
+func validatePositiveDuration(duration time.Duration) error {
+	if duration <= 0 {
+		return fmt.Errorf("must be positive, got %s", duration)
+	}
+
+	return nil
+}
+
+func validateNotNegativeDuration(duration time.Duration) error {
+	if duration < 0 {
+		return fmt.Errorf("must not be negative, got %s", duration)
+	}
+
+	return nil
+}
+
+func validatePositiveInt(value int) error {
+	if value <= 0 {
+		return fmt.Errorf("must be positive, got %d", value)
+	}
+
+	return nil
+}
@@ -3,6 +3,7 @@ package context
 import (
 	"context"
 	"errors"
+	"net"
 )
 
 type contextKey string
@@ -10,6 +11,12 @@ type contextKey string
 // UserIDKey represent key in context to store user id. Need for avoiding magic string.
 const UserIDKey = contextKey("user_id")
 
+// ClientIPKey represent key in context to store resolved ip address of client.
+const ClientIPKey = contextKey("client_ip")
+
+// RequestIDKey represent key in context to store id of request.
+const RequestIDKey = contextKey("request_id")
+
 // Possible errors when working with package.
 var (
 	ErrUserIDKeyNotFound = errors.New("user id key not found in context")
@@ -37,3 +44,25 @@ func GetUserIDFromContext(ctx context.Context) (string, error) {
 
 	return id, nil
 }
+
+// SetClientIPToContext save ip address of client in given context.
+func SetClientIPToContext(ctx context.Context, ip net.IP) context.Context {
+	return context.WithValue(ctx, ClientIPKey, ip)
+}
+
+// GetClientIPFromContext returns ip address of client saved in given context, or nil if it is not saved.
+func GetClientIPFromContext(ctx context.Context) net.IP {
+	ip, _ := ctx.Value(ClientIPKey).(net.IP)
+	return ip
+}
+
+// SetRequestIDToContext save id of request in given context.
+func SetRequestIDToContext(ctx context.Context, requestID string) context.Context {
+	return context.WithValue(ctx, RequestIDKey, requestID)
+}
+
+// GetRequestIDFromContext returns id of request saved in given context, or empty string if it is not saved.
+func GetRequestIDFromContext(ctx context.Context) string {
+	requestID, _ := ctx.Value(RequestIDKey).(string)
+	return requestID
+}
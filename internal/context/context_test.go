@@ -2,6 +2,7 @@ package context
 
 import (
 	"context"
+	"net"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -49,3 +50,17 @@ func TestSetUserIDToContext(t *testing.T) {
 		assert.Equal(t, "30", id)
 	})
 }
+
+func TestGetClientIPFromContext(t *testing.T) {
+	ctx := SetClientIPToContext(context.Background(), net.ParseIP("10.0.0.1"))
+	assert.Equal(t, net.ParseIP("10.0.0.1"), GetClientIPFromContext(ctx))
+
+	assert.Nil(t, GetClientIPFromContext(context.Background()))
+}
+
+func TestGetRequestIDFromContext(t *testing.T) {
+	ctx := SetRequestIDToContext(context.Background(), "abc")
+	assert.Equal(t, "abc", GetRequestIDFromContext(ctx))
+
+	assert.Empty(t, GetRequestIDFromContext(context.Background()))
+}
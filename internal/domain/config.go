@@ -0,0 +1,16 @@
+package domain
+
+// ConfigChange is setting, that has different value in reloaded configuration.
+type ConfigChange struct {
+	Key string
+	Old string
+	New string
+}
+
+// ConfigReload is result of configuration reload.
+type ConfigReload struct {
+	// Applied are changes of settings, that are applied without restart.
+	Applied []ConfigChange
+	// RestartRequired are changes, that are ignored until server is restarted.
+	RestartRequired []ConfigChange
+}
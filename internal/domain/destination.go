@@ -0,0 +1,102 @@
+package domain
+
+import (
+	"net/url"
+	"strings"
+)
+
+// UTMParams are utm parameters of short url. Empty parameters are not added to original url.
+type UTMParams struct {
+	Source   string `json:"utm_source,omitempty"`
+	Medium   string `json:"utm_medium,omitempty"`
+	Campaign string `json:"utm_campaign,omitempty"`
+	Term     string `json:"utm_term,omitempty"`
+	Content  string `json:"utm_content,omitempty"`
+}
+
+// Values returns not empty parameters by their query names.
+func (params *UTMParams) Values() url.Values {
+	values := url.Values{}
+	if params == nil {
+		return values
+	}
+
+	for name, value := range map[string]string{
+		"utm_source":   params.Source,
+		"utm_medium":   params.Medium,
+		"utm_campaign": params.Campaign,
+		"utm_term":     params.Term,
+		"utm_content":  params.Content,
+	} {
+		if value != "" {
+			values.Set(name, value)
+		}
+	}
+
+	return values
+}
+
+// IsEmpty reports if params have no parameter set.
+func (params *UTMParams) IsEmpty() bool {
+	return params == nil || *params == UTMParams{}
+}
+
+// Destination returns url, visitor of short url is redirected to. It is destination of the first redirect rule,
+// that visitor matches, or original url. Stored UTM parameters are added to it, and query parameters of visitor too,
+// when url has QueryPassthrough. Parameter is never overridden: parameters of destination win over UTM parameters,
+// which win over parameters of visitor. Added parameters are appended after query of destination, which is kept as is.
+func Destination(shortenedURL *ShortenedURL, visitor Visitor) string {
+	base := shortenedURL.OriginalURL
+
+	for _, rule := range shortenedURL.RedirectRules {
+		if rule.Matches(visitor) {
+			base = rule.Destination
+			break
+		}
+	}
+
+	extra := shortenedURL.UTM.Values()
+
+	if shortenedURL.QueryPassthrough {
+		for name, values := range visitor.Query {
+			if _, ok := extra[name]; !ok {
+				extra[name] = values
+			}
+		}
+	}
+
+	if len(extra) == 0 {
+		return base
+	}
+
+	destination, err := url.Parse(base)
+	if err != nil {
+		return base
+	}
+
+	for name := range destination.Query() {
+		delete(extra, name)
+	}
+
+	if len(extra) == 0 {
+		return base
+	}
+
+	// destination is not re-encoded, parameters are inserted before fragment
+	base, fragment, hasFragment := strings.Cut(base, "#")
+
+	switch {
+	case !strings.Contains(base, "?"):
+		base += "?"
+	case !strings.HasSuffix(base, "?") && !strings.HasSuffix(base, "&"):
+		base += "&"
+	}
+
+	base += extra.Encode()
+
+	if hasFragment {
+		return base + "#" + fragment
+	}
+
+	return base
+}
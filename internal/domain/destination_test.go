@@ -0,0 +1,103 @@
+package domain
+
+import (
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDestination(t *testing.T) {
+	testCases := []struct {
+		Visitor  Visitor
+		URL      ShortenedURL
+		Name     string
+		Expected string
+	}{
+		{
+			Name:     "without options",
+			URL:      ShortenedURL{OriginalURL: "https://example.com/page?a=1"},
+			Expected: "https://example.com/page?a=1",
+		},
+		{
+			Name:     "visitor query is dropped without passthrough",
+			URL:      ShortenedURL{OriginalURL: "https://example.com/page"},
+			Visitor:  Visitor{Query: url.Values{"ref": {"mail"}}},
+			Expected: "https://example.com/page",
+		},
+		{
+			Name: "utm params",
+			URL: ShortenedURL{
+				OriginalURL: "https://example.com/page",
+				UTM:         &UTMParams{Source: "news letter", Medium: "email", Campaign: "spring&sale"},
+			},
+			Expected: "https://example.com/page?utm_campaign=spring%26sale&utm_medium=email&utm_source=news+letter",
+		},
+		{
+			Name: "utm params do not override original query",
+			URL: ShortenedURL{
+				OriginalURL: "https://example.com/page?utm_source=site&b=%2F#top",
+				UTM:         &UTMParams{Source: "mail", Medium: "email"},
+			},
+			Expected: "https://example.com/page?utm_source=site&b=%2F&utm_medium=email#top",
+		},
+		{
+			Name: "passthrough",
+			URL: ShortenedURL{
+				OriginalURL:      "https://example.com/page?a=1",
+				UTM:              &UTMParams{Source: "mail"},
+				QueryPassthrough: true,
+			},
+			Visitor:  Visitor{Query: url.Values{"a": {"2"}, "utm_source": {"visitor"}, "tag": {"x", "y"}}},
+			Expected: "https://example.com/page?a=1&tag=x&tag=y&utm_source=mail",
+		},
+		{
+			Name:     "passthrough to url ending with question mark",
+			URL:      ShortenedURL{OriginalURL: "https://example.com/?", QueryPassthrough: true},
+			Visitor:  Visitor{Query: url.Values{"q": {"a b"}}},
+			Expected: "https://example.com/?q=a+b",
+		},
+		{
+			Name: "first matching rule wins",
+			URL: ShortenedURL{
+				OriginalURL: "https://example.com/page",
+				RedirectRules: []RedirectRule{
+					{Destination: "https://apps.apple.com/app", Devices: []string{DeviceIOS}},
+					{Destination: "https://example.de/page", Countries: []string{"DE"}},
+					{Destination: "https://example.com/de", Languages: []string{"de"}},
+				},
+			},
+			Visitor:  Visitor{Device: DeviceDesktop, Language: "de-AT", Country: "DE"},
+			Expected: "https://example.de/page",
+		},
+		{
+			Name: "original url without matching rule",
+			URL: ShortenedURL{
+				OriginalURL: "https://example.com/page",
+				RedirectRules: []RedirectRule{
+					{Destination: "https://example.de/page", Countries: []string{"DE"}, Devices: []string{DeviceAndroid}},
+				},
+			},
+			Visitor:  Visitor{Device: DeviceDesktop, Country: "DE"},
+			Expected: "https://example.com/page",
+		},
+		{
+			Name: "utm params are added to rule destination",
+			URL: ShortenedURL{
+				OriginalURL: "https://example.com/page",
+				UTM:         &UTMParams{Source: "mail"},
+				RedirectRules: []RedirectRule{
+					{Destination: "https://play.google.com/store?id=app", Devices: []string{DeviceAndroid}},
+				},
+			},
+			Visitor:  Visitor{Device: DeviceAndroid},
+			Expected: "https://play.google.com/store?id=app&utm_source=mail",
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			assert.Equal(t, testCase.Expected, Destination(&testCase.URL, testCase.Visitor))
+		})
+	}
+}
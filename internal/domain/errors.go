@@ -4,7 +4,20 @@ import "errors"
 
 // All available domain errors. They can occur during service working.
 var (
-	ErrURLConflict      = errors.New("url conflict")
-	ErrURLNotFound      = errors.New("url not found")
-	ErrShortURLConflict = errors.New("provided short url already exists")
+	ErrURLConflict          = errors.New("url conflict")
+	ErrURLNotFound          = errors.New("url not found")
+	ErrURLDeleted           = errors.New("url is deleted")
+	ErrShortURLConflict     = errors.New("provided short url already exists")
+	ErrDeleteTaskNotFound   = errors.New("delete task not found")
+	ErrWebhookNotFound      = errors.New("webhook not found")
+	ErrInvalidWebhookURL    = errors.New("webhook url must be absolute http or https url")
+	ErrInvalidWebhookEvent  = errors.New("unknown webhook event")
+	ErrMigrationsPending    = errors.New("database migrations are not applied")
+	ErrImportJobNotFound    = errors.New("import job not found")
+	ErrUnsupportedFormat    = errors.New("unsupported format, use csv or jsonl")
+	ErrInvalidImportFile    = errors.New("invalid import file")
+	ErrTooManyImportRows    = errors.New("too many rows in import file")
+	ErrInvalidConfig        = errors.New("invalid config")
+	ErrInvalidRedirectType  = errors.New("redirect type must be 301, 302, 307, 308 or preview")
+	ErrInvalidRedirectRules = errors.New("invalid redirect rules")
 )
@@ -0,0 +1,25 @@
+package domain
+
+// Health statuses of application and its components.
+const (
+	HealthStatusUp   = "up"
+	HealthStatusDown = "down"
+)
+
+// HealthComponent is result of health check of one application component.
+// Reason is fixed description of failure, details of failure are not exposed.
+type HealthComponent struct {
+	Status string
+	Reason string
+}
+
+// HealthReport is result of application health check with breakdown by components.
+type HealthReport struct {
+	Components map[string]HealthComponent
+	Status     string
+}
+
+// IsUp reports if application is healthy.
+func (r HealthReport) IsUp() bool {
+	return r.Status == HealthStatusUp
+}
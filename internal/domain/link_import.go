@@ -0,0 +1,31 @@
+package domain
+
+import "time"
+
+// Statuses of link import job.
+const (
+	ImportJobPending   = "pending"
+	ImportJobRunning   = "running"
+	ImportJobCompleted = "completed"
+)
+
+// ImportRowResult is result of importing one row. ShortURL is set when row is imported, Error otherwise.
+type ImportRowResult struct {
+	OriginalURL string
+	ShortURL    string
+	Error       string
+	Row         int
+}
+
+// ImportJob is background job of importing user links from file.
+type ImportJob struct {
+	CreatedAt  time.Time
+	FinishedAt *time.Time
+	ID         string
+	UserID     string
+	Status     string
+	Rows       []ImportRowResult
+	Total      int
+	Succeeded  int
+	Failed     int
+}
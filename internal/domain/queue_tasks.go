@@ -1,7 +1,26 @@
 package domain
 
-// DeleteURLsTask is containing short urls to delete and id of user who request deletion
+import "time"
+
+// Statuses of delete urls task.
+const (
+	DeleteTaskPending   = "pending"
+	DeleteTaskCompleted = "completed"
+	DeleteTaskFailed    = "failed"
+)
+
+// DeleteTaskMaxAttempts is count of failed attempts, after which delete task gets failed status and is not retried.
+const DeleteTaskMaxAttempts = 20
+
+// DeleteURLsTask is containing short urls to delete and id of user who request deletion.
+// Task is persisted in storage until it is completed, so it survives restarts.
 type DeleteURLsTask struct {
-	UserID    string
-	ShortURLs []string
+	CreatedAt   time.Time  `json:"created_at"`
+	CompletedAt *time.Time `json:"completed_at"`
+	ID          string     `json:"id"`
+	UserID      string     `json:"user_id"`
+	Status      string     `json:"status"`
+	LastError   string     `json:"last_error"`
+	ShortURLs   []string   `json:"short_urls"`
+	Attempts    int        `json:"attempts"`
 }
@@ -0,0 +1,139 @@
+package domain
+
+import (
+	"fmt"
+	"net/url"
+	"regexp"
+	"strings"
+)
+
+// Devices of visitors, that redirect rules match on.
+const (
+	DeviceIOS     = "ios"
+	DeviceAndroid = "android"
+	// DeviceMobile is mobile device with other operating system.
+	DeviceMobile  = "mobile"
+	DeviceDesktop = "desktop"
+)
+
+// MaxRedirectRules is max count of redirect rules of one url.
+const MaxRedirectRules = 20
+
+var (
+	countryRegexp  = regexp.MustCompile(`^[A-Za-z]{2}$`)
+	languageRegexp = regexp.MustCompile(`^[A-Za-z]{2,8}(-[A-Za-z0-9]{1,8})*$`)
+)
+
+// RedirectRule sends visitor, that matches all not empty conditions of rule, to Destination instead of original url.
+// Condition matches, when visitor has any of its values.
+type RedirectRule struct {
+	Destination string   `json:"destination"`
+	Devices     []string `json:"devices,omitempty"`
+	// Languages are language tags, like "en" or "pt-BR". Tag without region matches every region of language.
+	Languages []string `json:"languages,omitempty"`
+	// Countries are ISO 3166-1 alpha-2 codes.
+	Countries []string `json:"countries,omitempty"`
+}
+
+// Visitor describes request to short url, it is used to choose destination.
+type Visitor struct {
+	Query  url.Values
+	Device string
+	// Language is the most preferred language of visitor.
+	Language string
+	// Country is empty, when country of visitor is unknown.
+	Country string
+}
+
+// Matches reports if visitor matches all conditions of rule.
+func (rule *RedirectRule) Matches(visitor Visitor) bool {
+	if len(rule.Devices) > 0 && !containsFold(rule.Devices, visitor.Device) {
+		return false
+	}
+
+	if len(rule.Countries) > 0 && !containsFold(rule.Countries, visitor.Country) {
+		return false
+	}
+
+	if len(rule.Languages) > 0 && !matchesLanguage(rule.Languages, visitor.Language) {
+		return false
+	}
+
+	return true
+}
+
+// ValidateRedirectRules checks rules, error wraps ErrInvalidRedirectRules and describes the first invalid rule.
+func ValidateRedirectRules(rules []RedirectRule) error {
+	if len(rules) > MaxRedirectRules {
+		return fmt.Errorf("%w: url can have up to %d rules", ErrInvalidRedirectRules, MaxRedirectRules)
+	}
+
+	for i, rule := range rules {
+		if err := validateRedirectRule(rule); err != nil {
+			return fmt.Errorf("%w: rule %d: %s", ErrInvalidRedirectRules, i+1, err)
+		}
+	}
+
+	return nil
+}
+
+func validateRedirectRule(rule RedirectRule) error {
+	destination, err := url.ParseRequestURI(rule.Destination)
+	if err != nil || (destination.Scheme != "http" && destination.Scheme != "https") || destination.Host == "" {
+		return fmt.Errorf("destination must be absolute http or https url")
+	}
+
+	if len(rule.Devices) == 0 && len(rule.Languages) == 0 && len(rule.Countries) == 0 {
+		return fmt.Errorf("at least one of devices, languages or countries is required")
+	}
+
+	for _, device := range rule.Devices {
+		if !containsFold([]string{DeviceIOS, DeviceAndroid, DeviceMobile, DeviceDesktop}, device) {
+			return fmt.Errorf("device must be one of ios, android, mobile or desktop")
+		}
+	}
+
+	for _, language := range rule.Languages {
+		if !languageRegexp.MatchString(language) {
+			return fmt.Errorf("language must be language tag, like en or pt-BR")
+		}
+	}
+
+	for _, country := range rule.Countries {
+		if !countryRegexp.MatchString(country) {
+			return fmt.Errorf("country must be ISO 3166-1 alpha-2 code, like US")
+		}
+	}
+
+	return nil
+}
+
+func containsFold(values []string, value string) bool {
+	for _, item := range values {
+		if strings.EqualFold(item, value) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// matchesLanguage reports if language is one of languages, or region of one of languages without region.
+func matchesLanguage(languages []string, language string) bool {
+	if language == "" {
+		return false
+	}
+
+	for _, item := range languages {
+		if strings.EqualFold(item, language) {
+			return true
+		}
+
+		if !strings.Contains(item, "-") && len(language) > len(item) &&
+			language[len(item)] == '-' && strings.EqualFold(item, language[:len(item)]) {
+			return true
+		}
+	}
+
+	return false
+}
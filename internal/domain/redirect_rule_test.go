@@ -0,0 +1,136 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRedirectRule_Matches(t *testing.T) {
+	testCases := []struct {
+		Visitor  Visitor
+		Name     string
+		Rule     RedirectRule
+		Expected bool
+	}{
+		{
+			Name:     "device",
+			Rule:     RedirectRule{Devices: []string{DeviceIOS, DeviceAndroid}},
+			Visitor:  Visitor{Device: DeviceAndroid},
+			Expected: true,
+		},
+		{
+			Name:     "other device",
+			Rule:     RedirectRule{Devices: []string{DeviceIOS}},
+			Visitor:  Visitor{Device: DeviceDesktop},
+			Expected: false,
+		},
+		{
+			Name:     "country ignores case",
+			Rule:     RedirectRule{Countries: []string{"de"}},
+			Visitor:  Visitor{Country: "DE"},
+			Expected: true,
+		},
+		{
+			Name:     "unknown country",
+			Rule:     RedirectRule{Countries: []string{"DE"}},
+			Visitor:  Visitor{},
+			Expected: false,
+		},
+		{
+			Name:     "language matches every region",
+			Rule:     RedirectRule{Languages: []string{"pt"}},
+			Visitor:  Visitor{Language: "pt-BR"},
+			Expected: true,
+		},
+		{
+			Name:     "language with region matches only region",
+			Rule:     RedirectRule{Languages: []string{"pt-BR"}},
+			Visitor:  Visitor{Language: "pt-PT"},
+			Expected: false,
+		},
+		{
+			Name:     "language prefix is not language",
+			Rule:     RedirectRule{Languages: []string{"e"}},
+			Visitor:  Visitor{Language: "en"},
+			Expected: false,
+		},
+		{
+			Name:     "all conditions must match",
+			Rule:     RedirectRule{Devices: []string{DeviceIOS}, Countries: []string{"US"}},
+			Visitor:  Visitor{Device: DeviceIOS, Country: "CA"},
+			Expected: false,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			assert.Equal(t, testCase.Expected, testCase.Rule.Matches(testCase.Visitor))
+		})
+	}
+}
+
+func TestValidateRedirectRules(t *testing.T) {
+	tooManyRules := make([]RedirectRule, MaxRedirectRules+1)
+	for i := range tooManyRules {
+		tooManyRules[i] = RedirectRule{Destination: "https://example.com", Countries: []string{"US"}}
+	}
+
+	testCases := []struct {
+		Name    string
+		Rules   []RedirectRule
+		IsValid bool
+	}{
+		{
+			Name:    "no rules",
+			IsValid: true,
+		},
+		{
+			Name: "valid rules",
+			Rules: []RedirectRule{
+				{Destination: "https://example.com/ios", Devices: []string{DeviceIOS}},
+				{Destination: "http://example.de", Countries: []string{"DE", "at"}, Languages: []string{"de", "de-CH"}},
+			},
+			IsValid: true,
+		},
+		{
+			Name:  "relative destination",
+			Rules: []RedirectRule{{Destination: "/page", Devices: []string{DeviceIOS}}},
+		},
+		{
+			Name:  "not http destination",
+			Rules: []RedirectRule{{Destination: "ftp://example.com", Devices: []string{DeviceIOS}}},
+		},
+		{
+			Name:  "without conditions",
+			Rules: []RedirectRule{{Destination: "https://example.com"}},
+		},
+		{
+			Name:  "unknown device",
+			Rules: []RedirectRule{{Destination: "https://example.com", Devices: []string{"tv"}}},
+		},
+		{
+			Name:  "invalid language",
+			Rules: []RedirectRule{{Destination: "https://example.com", Languages: []string{"en_US"}}},
+		},
+		{
+			Name:  "invalid country",
+			Rules: []RedirectRule{{Destination: "https://example.com", Countries: []string{"USA"}}},
+		},
+		{
+			Name:  "too many rules",
+			Rules: tooManyRules,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			err := ValidateRedirectRules(testCase.Rules)
+			if testCase.IsValid {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorIs(t, err, ErrInvalidRedirectRules)
+			}
+		})
+	}
+}
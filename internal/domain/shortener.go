@@ -1,19 +1,73 @@
 package domain
 
+import "time"
+
+// Redirect types of short url. Empty redirect type is RedirectTemporary, it is used by links created before redirect types.
+const (
+	RedirectMovedPermanently = "301"
+	RedirectFound            = "302"
+	RedirectTemporary        = "307"
+	RedirectPermanent        = "308"
+	// RedirectPreview shows page with original url, user leaves to original url by link on the page.
+	RedirectPreview = "preview"
+)
+
 // ShortenedURL is model of shortened url. Use model to store data in storages.
 type ShortenedURL struct {
-	ShortURL    string `json:"short_url"`
-	OriginalURL string `json:"original_url"`
-	UserID      string `json:"user_id"`
-	ID          int    `json:"id"`
-	IsDeleted   bool   `json:"is_deleted"`
+	ExpiresAt *time.Time `json:"expires_at,omitempty"`
+	// CreatedAt is nil for urls created before creation time was stored.
+	CreatedAt *time.Time `json:"created_at,omitempty"`
+	// UTM are parameters appended to original url on redirect.
+	UTM         *UTMParams `json:"utm,omitempty"`
+	ShortURL    string     `json:"short_url"`
+	OriginalURL string     `json:"original_url"`
+	UserID      string     `json:"user_id"`
+	// RedirectType is one of Redirect* constants.
+	RedirectType string   `json:"redirect_type,omitempty"`
+	Tags         []string `json:"tags,omitempty"`
+	// RedirectRules choose destination by visitor, original url is used, when no rule matches.
+	RedirectRules []RedirectRule `json:"redirect_rules,omitempty"`
+	ID            int            `json:"id"`
+	Clicks        int            `json:"clicks"`
+	IsDeleted     bool           `json:"is_deleted"`
+	// QueryPassthrough adds query parameters of visitor request to original url on redirect.
+	QueryPassthrough bool `json:"query_passthrough,omitempty"`
+}
+
+// IsExpired reports if url has expiration time and it is passed.
+func (url *ShortenedURL) IsExpired(now time.Time) bool {
+	return url.ExpiresAt != nil && !now.Before(*url.ExpiresAt)
+}
+
+// IsValidRedirectType reports if redirectType is one of Redirect* constants or empty.
+func IsValidRedirectType(redirectType string) bool {
+	switch redirectType {
+	case "", RedirectMovedPermanently, RedirectFound, RedirectTemporary, RedirectPermanent, RedirectPreview:
+		return true
+	default:
+		return false
+	}
 }
 
 // SaveShortURLDto contains info about short url saving to pass around layers.
 type SaveShortURLDto struct {
-	OriginalURL string `json:"original_url"`
-	ShortURL    string `json:"short_url"`
-	UserID      string `json:"user_id"`
+	ExpiresAt    *time.Time `json:"expires_at,omitempty"`
+	UTM          *UTMParams `json:"utm,omitempty"`
+	OriginalURL  string     `json:"original_url"`
+	ShortURL     string     `json:"short_url"`
+	UserID       string     `json:"user_id"`
+	RedirectType string     `json:"redirect_type,omitempty"`
+	Tags         []string   `json:"tags,omitempty"`
+	// RedirectRules are saved only by import of links.
+	RedirectRules    []RedirectRule `json:"redirect_rules,omitempty"`
+	QueryPassthrough bool           `json:"query_passthrough,omitempty"`
+}
+
+// ShortURLOptions are options of url chosen at shortening.
+type ShortURLOptions struct {
+	UTM              *UTMParams `json:"utm,omitempty"`
+	RedirectType     string     `json:"redirect_type,omitempty"`
+	QueryPassthrough bool       `json:"query_passthrough,omitempty"`
 }
 
 // InternalStats contains internal stats about system state
@@ -22,8 +76,18 @@ type InternalStats struct {
 	Users int `json:"users"`
 }
 
+// ShortBatchURL is url in batch shortening. ShortURL can be set in request to use it as alias instead of generated one.
 type ShortBatchURL struct {
-	CorrelationID string `json:"correlation_id"`
-	OriginalURL   string `json:"original_url"`
-	ShortURL      string `json:"short_url"`
+	ExpiresAt     *time.Time `json:"expires_at,omitempty"`
+	UTM           *UTMParams `json:"utm,omitempty"`
+	CorrelationID string     `json:"correlation_id"`
+	OriginalURL   string     `json:"original_url"`
+	ShortURL      string     `json:"short_url"`
+	RedirectType  string     `json:"redirect_type,omitempty"`
+	Tags          []string   `json:"tags,omitempty"`
+	// RedirectRules are set only by import of links.
+	RedirectRules    []RedirectRule `json:"redirect_rules,omitempty"`
+	QueryPassthrough bool           `json:"query_passthrough,omitempty"`
+	// IsExisting is set in result for url, that was shortened before, maybe by another user.
+	IsExisting bool `json:"-"`
 }
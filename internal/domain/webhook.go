@@ -0,0 +1,89 @@
+package domain
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// Link lifecycle events that user can subscribe to with webhook.
+const (
+	EventLinkCreated      = "link.created"
+	EventLinkDeleted      = "link.deleted"
+	EventLinkFirstClicked = "link.first_clicked"
+)
+
+// LinkEvents contains all available link lifecycle events.
+var LinkEvents = []string{
+	EventLinkCreated,
+	EventLinkDeleted,
+	EventLinkFirstClicked,
+}
+
+// Statuses of webhook event in outbox.
+const (
+	WebhookEventPending   = "pending"
+	WebhookEventDelivered = "delivered"
+	WebhookEventFailed    = "failed"
+)
+
+// Webhook is user subscription to link lifecycle events.
+// Secret is used to sign payloads with HMAC-SHA256.
+type Webhook struct {
+	CreatedAt time.Time `json:"created_at"`
+	ID        string    `json:"id"`
+	UserID    string    `json:"user_id"`
+	URL       string    `json:"url"`
+	Secret    string    `json:"secret"`
+	Events    []string  `json:"events"`
+}
+
+// IsSubscribedTo check if webhook wants to receive given event type.
+func (w *Webhook) IsSubscribedTo(eventType string) bool {
+	for _, event := range w.Events {
+		if event == eventType {
+			return true
+		}
+	}
+
+	return false
+}
+
+// WebhookEvent is outbox record of event that must be delivered to webhook.
+type WebhookEvent struct {
+	CreatedAt     time.Time       `json:"created_at"`
+	NextAttemptAt time.Time       `json:"next_attempt_at"`
+	ID            string          `json:"id"`
+	WebhookID     string          `json:"webhook_id"`
+	EventType     string          `json:"event_type"`
+	Status        string          `json:"status"`
+	Payload       json.RawMessage `json:"payload"`
+	Attempts      int             `json:"attempts"`
+}
+
+// WebhookDelivery is log record of one attempt to deliver webhook event.
+type WebhookDelivery struct {
+	AttemptedAt time.Time `json:"attempted_at"`
+	ID          string    `json:"id"`
+	WebhookID   string    `json:"webhook_id"`
+	EventID     string    `json:"event_id"`
+	EventType   string    `json:"event_type"`
+	Error       string    `json:"error"`
+	StatusCode  int       `json:"status_code"`
+	Attempt     int       `json:"attempt"`
+	DurationMs  int64     `json:"duration_ms"`
+	Success     bool      `json:"success"`
+}
+
+// LinkEventData describes link that event is about.
+type LinkEventData struct {
+	ShortURL    string `json:"short_url"`
+	OriginalURL string `json:"original_url,omitempty"`
+}
+
+// WebhookPayload is body that sends to webhook url.
+type WebhookPayload struct {
+	OccurredAt time.Time     `json:"occurred_at"`
+	ID         string        `json:"id"`
+	Type       string        `json:"type"`
+	Data       LinkEventData `json:"data"`
+}
@@ -0,0 +1,58 @@
+// Package geoip resolves country of ip address by local database in MaxMind DB format,
+// like GeoLite2 Country, GeoIP2 Country or DB-IP Country Lite.
+package geoip
+
+import (
+	"net"
+
+	"github.com/oschwald/maxminddb-golang"
+)
+
+type countryRecord struct {
+	Country struct {
+		ISOCode string `maxminddb:"iso_code"`
+	} `maxminddb:"country"`
+}
+
+// Reader resolves country of ip address. Reader without database resolves no countries.
+type Reader struct {
+	db *maxminddb.Reader
+}
+
+// Open opens database file. Empty path gives reader without database.
+func Open(path string) (*Reader, error) {
+	if path == "" {
+		return &Reader{}, nil
+	}
+
+	db, err := maxminddb.Open(path)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Reader{db: db}, nil
+}
+
+// Country returns ISO 3166-1 alpha-2 code of country of ip, or empty string, when country is unknown.
+func (r *Reader) Country(ip net.IP) string {
+	if r.db == nil || ip == nil {
+		return ""
+	}
+
+	var record countryRecord
+
+	if err := r.db.Lookup(ip, &record); err != nil {
+		return ""
+	}
+
+	return record.Country.ISOCode
+}
+
+// Close closes database file.
+func (r *Reader) Close() error {
+	if r.db == nil {
+		return nil
+	}
+
+	return r.db.Close()
+}
@@ -0,0 +1,103 @@
+package geoip
+
+import (
+	"bytes"
+	"encoding/binary"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReader_Country(t *testing.T) {
+	_, network, err := net.ParseCIDR("81.0.0.0/8")
+	require.NoError(t, err)
+
+	reader, err := Open(writeTestDatabase(t, network, "DE"))
+	require.NoError(t, err)
+	defer reader.Close()
+
+	assert.Equal(t, "DE", reader.Country(net.ParseIP("81.2.69.160")))
+	assert.Empty(t, reader.Country(net.ParseIP("82.2.69.160")))
+	assert.Empty(t, reader.Country(net.ParseIP("2001:db8::1")))
+	assert.Empty(t, reader.Country(nil))
+}
+
+func TestReader_WithoutDatabase(t *testing.T) {
+	reader, err := Open("")
+	require.NoError(t, err)
+
+	assert.Empty(t, reader.Country(net.ParseIP("81.2.69.160")))
+	assert.NoError(t, reader.Close())
+}
+
+func TestOpen_MissingFile(t *testing.T) {
+	_, err := Open(filepath.Join(t.TempDir(), "missing.mmdb"))
+	assert.Error(t, err)
+}
+
+// writeTestDatabase writes IPv4 database in MaxMind DB format, where only network has country.
+func writeTestDatabase(t *testing.T, network *net.IPNet, country string) string {
+	t.Helper()
+
+	prefixLength, _ := network.Mask.Size()
+	ip := network.IP.To4()
+	nodeCount := uint32(prefixLength)
+	// value of record pointing to the first record of data section
+	dataRecord := nodeCount + 16
+
+	var file bytes.Buffer
+
+	for node := uint32(0); node < nodeCount; node++ {
+		next := node + 1
+		if next == nodeCount {
+			next = dataRecord
+		}
+
+		records := [2]uint32{nodeCount, nodeCount}
+		records[ip[node/8]>>(7-node%8)&1] = next
+
+		for _, record := range records {
+			file.Write([]byte{byte(record >> 16), byte(record >> 8), byte(record)})
+		}
+	}
+
+	file.Write(make([]byte, 16))
+	file.Write(encodeMap(1, encodeString("country"), encodeMap(1, encodeString("iso_code"), encodeString(country))))
+	file.WriteString("\xab\xcd\xefMaxMind.com")
+	file.Write(encodeMap(
+		9,
+		encodeString("node_count"), encodeUint(6, uint64(nodeCount), 4),
+		encodeString("record_size"), encodeUint(5, 24, 2),
+		encodeString("ip_version"), encodeUint(5, 4, 2),
+		encodeString("database_type"), encodeString("Test-Country"),
+		encodeString("languages"), []byte{0x00, 0x04},
+		encodeString("binary_format_major_version"), encodeUint(5, 2, 2),
+		encodeString("binary_format_minor_version"), encodeUint(5, 0, 2),
+		encodeString("build_epoch"), append([]byte{0x08, 0x02}, binary.BigEndian.AppendUint64(nil, 1)...),
+		encodeString("description"), encodeMap(0),
+	))
+
+	path := filepath.Join(t.TempDir(), "country.mmdb")
+	require.NoError(t, os.WriteFile(path, file.Bytes(), 0o600))
+
+	return path
+}
+
+// encodeMap encodes map with size pairs, fields are encoded keys and values.
+func encodeMap(size int, fields ...[]byte) []byte {
+	return append([]byte{7<<5 | byte(size)}, bytes.Join(fields, nil)...)
+}
+
+func encodeString(value string) []byte {
+	return append([]byte{2<<5 | byte(len(value))}, value...)
+}
+
+func encodeUint(dataType byte, value uint64, size int) []byte {
+	encoded := binary.BigEndian.AppendUint64(nil, value)
+
+	return append([]byte{dataType<<5 | byte(size)}, encoded[8-size:]...)
+}
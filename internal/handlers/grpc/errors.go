@@ -0,0 +1,111 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+
+	"go.uber.org/zap"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/protoadapt"
+
+	"github.com/MowlCoder/go-url-shortener/internal/domain"
+	"github.com/MowlCoder/go-url-shortener/internal/logger"
+)
+
+// ErrorDomain is domain of ErrorInfo details of errors returned by shortener.
+const ErrorDomain = "shortener"
+
+// ResourceTypeShortURL is resource type of ResourceInfo details with existing short url.
+const ResourceTypeShortURL = "short_url"
+
+// Reasons of ErrorInfo details.
+const (
+	ReasonURLConflict       = "URL_CONFLICT"
+	ReasonShortURLConflict  = "SHORT_URL_CONFLICT"
+	ReasonURLNotFound       = "URL_NOT_FOUND"
+	ReasonURLDeleted        = "URL_DELETED"
+	ReasonNotFound          = "NOT_FOUND"
+	ReasonInvalidArgument   = "INVALID_ARGUMENT"
+	ReasonMigrationsPending = "MIGRATIONS_PENDING"
+)
+
+type statusMapping struct {
+	err    error
+	reason string
+	field  string
+	code   codes.Code
+}
+
+// statusMappings maps domain errors to gRPC codes. Field is set for errors caused by invalid field of request.
+var statusMappings = []statusMapping{
+	{err: domain.ErrURLConflict, code: codes.AlreadyExists, reason: ReasonURLConflict},
+	{err: domain.ErrShortURLConflict, code: codes.AlreadyExists, reason: ReasonShortURLConflict},
+	{err: domain.ErrURLNotFound, code: codes.NotFound, reason: ReasonURLNotFound},
+	{err: domain.ErrURLDeleted, code: codes.FailedPrecondition, reason: ReasonURLDeleted},
+	{err: domain.ErrDeleteTaskNotFound, code: codes.NotFound, reason: ReasonNotFound},
+	{err: domain.ErrWebhookNotFound, code: codes.NotFound, reason: ReasonNotFound},
+	{err: domain.ErrImportJobNotFound, code: codes.NotFound, reason: ReasonNotFound},
+	{err: domain.ErrInvalidWebhookURL, code: codes.InvalidArgument, reason: ReasonInvalidArgument, field: "url"},
+	{err: domain.ErrInvalidWebhookEvent, code: codes.InvalidArgument, reason: ReasonInvalidArgument, field: "events"},
+	{err: domain.ErrUnsupportedFormat, code: codes.InvalidArgument, reason: ReasonInvalidArgument, field: "format"},
+	{err: domain.ErrInvalidImportFile, code: codes.InvalidArgument, reason: ReasonInvalidArgument},
+	{err: domain.ErrTooManyImportRows, code: codes.InvalidArgument, reason: ReasonInvalidArgument},
+	{err: domain.ErrInvalidRedirectType, code: codes.InvalidArgument, reason: ReasonInvalidArgument, field: "redirect_type"},
+	{err: domain.ErrInvalidRedirectRules, code: codes.InvalidArgument, reason: ReasonInvalidArgument, field: "rules"},
+	{err: domain.ErrInvalidConfig, code: codes.InvalidArgument, reason: ReasonInvalidArgument},
+	{err: domain.ErrMigrationsPending, code: codes.Unavailable, reason: ReasonMigrationsPending},
+}
+
+// statusFromError converts err to gRPC status error. Known domain errors get their code and ErrorInfo details,
+// errors of invalid fields get BadRequest details too. Other errors are logged and returned as Internal
+// without message of cause, so details of storage are not leaked to clients.
+func statusFromError(ctx context.Context, err error, details ...protoadapt.MessageV1) error {
+	if _, ok := status.FromError(err); ok {
+		return err
+	}
+
+	for _, mapping := range statusMappings {
+		if !errors.Is(err, mapping.err) {
+			continue
+		}
+
+		details = append(details, &errdetails.ErrorInfo{Reason: mapping.reason, Domain: ErrorDomain})
+
+		if mapping.field != "" {
+			details = append(details, &errdetails.BadRequest{
+				FieldViolations: []*errdetails.BadRequest_FieldViolation{{Field: mapping.field, Description: err.Error()}},
+			})
+		}
+
+		return newStatus(mapping.code, err.Error(), details...)
+	}
+
+	logger.FromContext(ctx).Error("grpc call failed", zap.Error(err))
+
+	return status.Error(codes.Internal, "internal error")
+}
+
+// invalidArgument returns InvalidArgument status error with BadRequest details about invalid field.
+func invalidArgument(message string, field string, description string) error {
+	return newStatus(
+		codes.InvalidArgument,
+		message,
+		&errdetails.ErrorInfo{Reason: ReasonInvalidArgument, Domain: ErrorDomain},
+		&errdetails.BadRequest{
+			FieldViolations: []*errdetails.BadRequest_FieldViolation{{Field: field, Description: description}},
+		},
+	)
+}
+
+func newStatus(code codes.Code, message string, details ...protoadapt.MessageV1) error {
+	st := status.New(code, message)
+
+	withDetails, err := st.WithDetails(details...)
+	if err != nil {
+		return st.Err()
+	}
+
+	return withDetails.Err()
+}
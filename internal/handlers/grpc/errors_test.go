@@ -0,0 +1,103 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/MowlCoder/go-url-shortener/internal/domain"
+)
+
+func TestStatusFromError(t *testing.T) {
+	testCases := []struct {
+		err             error
+		name            string
+		expectedMessage string
+		expectedReason  string
+		expectedField   string
+		expectedCode    codes.Code
+	}{
+		{
+			name:            "conflict",
+			err:             domain.ErrURLConflict,
+			expectedCode:    codes.AlreadyExists,
+			expectedMessage: "url conflict",
+			expectedReason:  ReasonURLConflict,
+		},
+		{
+			name:            "wrapped not found",
+			err:             fmt.Errorf("get url: %w", domain.ErrURLNotFound),
+			expectedCode:    codes.NotFound,
+			expectedMessage: "get url: url not found",
+			expectedReason:  ReasonURLNotFound,
+		},
+		{
+			name:            "deleted url",
+			err:             domain.ErrURLDeleted,
+			expectedCode:    codes.FailedPrecondition,
+			expectedMessage: "url is deleted",
+			expectedReason:  ReasonURLDeleted,
+		},
+		{
+			name:            "invalid field",
+			err:             domain.ErrInvalidWebhookURL,
+			expectedCode:    codes.InvalidArgument,
+			expectedMessage: domain.ErrInvalidWebhookURL.Error(),
+			expectedReason:  ReasonInvalidArgument,
+			expectedField:   "url",
+		},
+		{
+			name:            "storage error is hidden",
+			err:             errors.New(`pq: relation "urls" does not exist`),
+			expectedCode:    codes.Internal,
+			expectedMessage: "internal error",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			st := status.Convert(statusFromError(context.Background(), tc.err))
+
+			assert.Equal(t, tc.expectedCode, st.Code())
+			assert.Equal(t, tc.expectedMessage, st.Message())
+
+			reason, field := "", ""
+			for _, detail := range st.Details() {
+				switch typed := detail.(type) {
+				case *errdetails.ErrorInfo:
+					assert.Equal(t, ErrorDomain, typed.Domain)
+					reason = typed.Reason
+				case *errdetails.BadRequest:
+					require.Len(t, typed.FieldViolations, 1)
+					field = typed.FieldViolations[0].Field
+				}
+			}
+
+			assert.Equal(t, tc.expectedReason, reason)
+			assert.Equal(t, tc.expectedField, field)
+		})
+	}
+
+	t.Run("status error is kept", func(t *testing.T) {
+		err := status.Error(codes.Unauthenticated, "missing user id")
+
+		assert.Equal(t, err, statusFromError(context.Background(), err))
+	})
+
+	t.Run("extra details", func(t *testing.T) {
+		st := status.Convert(statusFromError(context.Background(), domain.ErrURLConflict, &errdetails.ResourceInfo{
+			ResourceType: ResourceTypeShortURL,
+			ResourceName: "http://localhost:8080/abc",
+		}))
+
+		require.Len(t, st.Details(), 2)
+		assert.Equal(t, "http://localhost:8080/abc", st.Details()[0].(*errdetails.ResourceInfo).ResourceName)
+	})
+}
@@ -0,0 +1,115 @@
+package grpc
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/status"
+
+	"github.com/MowlCoder/go-url-shortener/internal/domain"
+	"github.com/MowlCoder/go-url-shortener/proto"
+)
+
+const healthWatchInterval = time.Second * 5
+
+type healthService interface {
+	Readiness(ctx context.Context) domain.HealthReport
+}
+
+// HealthHandler implements standard grpc.health.v1.Health service.
+// Overall server health ("") and shortener service health reflect readiness checks.
+type HealthHandler struct {
+	healthpb.UnimplementedHealthServer
+
+	service       healthService
+	shutdown      chan struct{}
+	shutdownOnce  sync.Once
+	watchInterval time.Duration
+}
+
+// NewHealthHandler is constructor function for HealthHandler.
+func NewHealthHandler(service healthService) *HealthHandler {
+	return &HealthHandler{
+		service:       service,
+		shutdown:      make(chan struct{}),
+		watchInterval: healthWatchInterval,
+	}
+}
+
+// Shutdown sends NOT_SERVING to all Watch streams and ends them. It must be called before graceful stop
+// of gRPC server, which waits for open streams and does not cancel their context.
+func (h *HealthHandler) Shutdown() {
+	h.shutdownOnce.Do(func() {
+		close(h.shutdown)
+	})
+}
+
+// Check returns current serving status of requested service.
+func (h *HealthHandler) Check(ctx context.Context, in *healthpb.HealthCheckRequest) (*healthpb.HealthCheckResponse, error) {
+	if !isKnownService(in.Service) {
+		return nil, status.Error(codes.NotFound, "unknown service")
+	}
+
+	return &healthpb.HealthCheckResponse{
+		Status: h.servingStatus(ctx),
+	}, nil
+}
+
+// Watch sends serving status of requested service every time it changes. Stream for unknown service
+// gets SERVICE_UNKNOWN and stays open, as grpc.health.v1 requires. Streams are ended by Shutdown.
+func (h *HealthHandler) Watch(in *healthpb.HealthCheckRequest, stream healthpb.Health_WatchServer) error {
+	if !isKnownService(in.Service) {
+		if err := stream.Send(&healthpb.HealthCheckResponse{
+			Status: healthpb.HealthCheckResponse_SERVICE_UNKNOWN,
+		}); err != nil {
+			return err
+		}
+
+		select {
+		case <-stream.Context().Done():
+			return status.FromContextError(stream.Context().Err()).Err()
+		case <-h.shutdown:
+			return nil
+		}
+	}
+
+	ticker := time.NewTicker(h.watchInterval)
+	defer ticker.Stop()
+
+	lastStatus := healthpb.HealthCheckResponse_UNKNOWN
+
+	for {
+		currentStatus := h.servingStatus(stream.Context())
+
+		if currentStatus != lastStatus {
+			if err := stream.Send(&healthpb.HealthCheckResponse{Status: currentStatus}); err != nil {
+				return err
+			}
+
+			lastStatus = currentStatus
+		}
+
+		select {
+		case <-stream.Context().Done():
+			return status.FromContextError(stream.Context().Err()).Err()
+		case <-h.shutdown:
+			return stream.Send(&healthpb.HealthCheckResponse{Status: healthpb.HealthCheckResponse_NOT_SERVING})
+		case <-ticker.C:
+		}
+	}
+}
+
+func (h *HealthHandler) servingStatus(ctx context.Context) healthpb.HealthCheckResponse_ServingStatus {
+	if h.service.Readiness(ctx).IsUp() {
+		return healthpb.HealthCheckResponse_SERVING
+	}
+
+	return healthpb.HealthCheckResponse_NOT_SERVING
+}
+
+func isKnownService(service string) bool {
+	return service == "" || service == proto.Shortener_ServiceDesc.ServiceName
+}
@@ -0,0 +1,90 @@
+package grpc
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+
+	"github.com/MowlCoder/go-url-shortener/internal/domain"
+)
+
+type upHealthService struct{}
+
+func (upHealthService) Readiness(ctx context.Context) domain.HealthReport {
+	return domain.HealthReport{Status: domain.HealthStatusUp}
+}
+
+type watchStream struct {
+	healthpb.Health_WatchServer
+
+	ctx  context.Context
+	sent chan healthpb.HealthCheckResponse_ServingStatus
+}
+
+func (s *watchStream) Context() context.Context {
+	return s.ctx
+}
+
+func (s *watchStream) Send(response *healthpb.HealthCheckResponse) error {
+	s.sent <- response.Status
+	return nil
+}
+
+func TestHealthHandler_Watch(t *testing.T) {
+	testCases := []struct {
+		name     string
+		service  string
+		expected []healthpb.HealthCheckResponse_ServingStatus
+	}{
+		{
+			name:     "known service is not serving after shutdown",
+			service:  "",
+			expected: []healthpb.HealthCheckResponse_ServingStatus{healthpb.HealthCheckResponse_SERVING, healthpb.HealthCheckResponse_NOT_SERVING},
+		},
+		{
+			name:     "unknown service stays open until shutdown",
+			service:  "unknown",
+			expected: []healthpb.HealthCheckResponse_ServingStatus{healthpb.HealthCheckResponse_SERVICE_UNKNOWN},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			handler := NewHealthHandler(upHealthService{})
+			stream := &watchStream{
+				ctx:  context.Background(),
+				sent: make(chan healthpb.HealthCheckResponse_ServingStatus, len(tc.expected)),
+			}
+
+			done := make(chan error)
+			go func() {
+				done <- handler.Watch(&healthpb.HealthCheckRequest{Service: tc.service}, stream)
+			}()
+
+			assert.Equal(t, tc.expected[0], <-stream.sent)
+
+			select {
+			case <-done:
+				t.Fatal("watch is ended before shutdown")
+			case <-time.After(time.Millisecond * 50):
+			}
+
+			handler.Shutdown()
+
+			select {
+			case err := <-done:
+				require.NoError(t, err)
+			case <-time.After(time.Second):
+				t.Fatal("watch is not ended by shutdown")
+			}
+
+			for _, expected := range tc.expected[1:] {
+				assert.Equal(t, expected, <-stream.sent)
+			}
+		})
+	}
+}
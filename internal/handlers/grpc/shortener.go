@@ -4,7 +4,9 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"io"
 
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 
@@ -15,14 +17,20 @@ import (
 )
 
 type shortenerService interface {
-	ShortURL(ctx context.Context, url string, userID string) (*domain.ShortenedURL, error)
+	ShortURL(ctx context.Context, url string, userID string, options domain.ShortURLOptions) (*domain.ShortenedURL, error)
 	ShortBatchURL(ctx context.Context, urls []domain.ShortBatchURL, userID string) ([]domain.ShortBatchURL, error)
 	GetUserURLs(ctx context.Context, userID string) ([]domain.ShortenedURL, error)
-	DeleteURLs(ctx context.Context, urls []string, userID string) error
+	GetUserURLsPage(ctx context.Context, userID string, afterShortURL string, limit int) ([]domain.ShortenedURL, error)
+	DeleteURLs(ctx context.Context, urls []string, userID string) (*domain.DeleteURLsTask, error)
+	GetRedirectRules(ctx context.Context, shortURL string, userID string) ([]domain.RedirectRule, error)
+	SetRedirectRules(ctx context.Context, shortURL string, userID string, rules []domain.RedirectRule) error
 	GetInternalStats(ctx context.Context) (*domain.InternalStats, error)
 	Ping(ctx context.Context) error
 }
 
+// streamMyURLsPageSize is count of urls, that are read from storage at once by StreamMyURLs.
+const streamMyURLsPageSize = 100
+
 type ShortenerHandler struct {
 	proto.UnimplementedShortenerServer
 
@@ -47,12 +55,28 @@ func (h *ShortenerHandler) ShortURL(ctx context.Context, in *proto.ShortURLReque
 	}
 
 	if len(in.Url) == 0 {
-		return nil, status.Error(codes.InvalidArgument, "invalid url")
+		return nil, invalidArgument("invalid url", "url", "must not be empty")
 	}
 
-	shortenedURL, err := h.service.ShortURL(ctx, in.Url, userID)
-	if err != nil && !errors.Is(err, domain.ErrURLConflict) {
-		return nil, status.Error(codes.Internal, err.Error())
+	shortenedURL, err := h.service.ShortURL(ctx, in.Url, userID, domain.ShortURLOptions{
+		RedirectType:     in.RedirectType,
+		UTM:              makeUTMParams(in.Utm),
+		QueryPassthrough: in.QueryPassthrough,
+	})
+
+	// url is already shortened, existing short url is sent in details of AlreadyExists status
+	if errors.Is(err, domain.ErrURLConflict) {
+		shortURL := fmt.Sprintf("%s/%s", h.appConfig.BaseShortURLAddr, shortenedURL.ShortURL)
+
+		return nil, statusFromError(ctx, err, &errdetails.ResourceInfo{
+			ResourceType: ResourceTypeShortURL,
+			ResourceName: shortURL,
+			Description:  "url is already shortened",
+		}, &proto.ShortURLResponse{Result: shortURL})
+	}
+
+	if err != nil {
+		return nil, statusFromError(ctx, err)
 	}
 
 	return &proto.ShortURLResponse{
@@ -69,14 +93,17 @@ func (h *ShortenerHandler) ShortBatchURL(ctx context.Context, in *proto.ShortBat
 	urls := make([]domain.ShortBatchURL, 0)
 	for _, dto := range in.Dtos {
 		urls = append(urls, domain.ShortBatchURL{
-			CorrelationID: dto.CorrelationId,
-			OriginalURL:   dto.OriginalUrl,
+			CorrelationID:    dto.CorrelationId,
+			OriginalURL:      dto.OriginalUrl,
+			RedirectType:     dto.RedirectType,
+			UTM:              makeUTMParams(dto.Utm),
+			QueryPassthrough: dto.QueryPassthrough,
 		})
 	}
 
 	shortenedURLs, err := h.service.ShortBatchURL(ctx, urls, userID)
 	if err != nil {
-		return nil, status.Error(codes.Internal, err.Error())
+		return nil, statusFromError(ctx, err)
 	}
 
 	responseURLs := make([]*proto.ResponseBatchURLDto, 0, len(shortenedURLs))
@@ -100,21 +127,98 @@ func (h *ShortenerHandler) GetMyURLs(ctx context.Context, in *proto.GetMyURLsReq
 
 	urls, err := h.service.GetUserURLs(ctx, userID)
 	if err != nil {
-		return nil, status.Error(codes.Internal, err.Error())
+		return nil, statusFromError(ctx, err)
 	}
 
 	userShortenedURLs := make([]*proto.UserShortenedURL, 0, len(urls))
 
 	for _, url := range urls {
 		userShortenedURLs = append(userShortenedURLs, &proto.UserShortenedURL{
-			OriginalUrl: url.OriginalURL,
-			ShortUrl:    url.ShortURL,
+			OriginalUrl:  url.OriginalURL,
+			ShortUrl:     url.ShortURL,
+			RedirectType: url.RedirectType,
 		})
 	}
 
 	return &proto.GetMyURLsResponse{Result: userShortenedURLs}, nil
 }
 
+func (h *ShortenerHandler) StreamMyURLs(in *proto.StreamMyURLsRequest, stream proto.Shortener_StreamMyURLsServer) error {
+	userID, err := contextUtil.GetUserIDFromContext(stream.Context())
+	if err != nil {
+		return status.Error(codes.Unauthenticated, "missing user id")
+	}
+
+	lastShortURL := ""
+
+	for {
+		urls, err := h.service.GetUserURLsPage(stream.Context(), userID, lastShortURL, streamMyURLsPageSize)
+		if err != nil {
+			return statusFromError(stream.Context(), err)
+		}
+
+		for _, url := range urls {
+			if err := stream.Send(&proto.UserShortenedURL{
+				OriginalUrl:  url.OriginalURL,
+				ShortUrl:     url.ShortURL,
+				RedirectType: url.RedirectType,
+			}); err != nil {
+				return err
+			}
+		}
+
+		if len(urls) < streamMyURLsPageSize {
+			return nil
+		}
+
+		lastShortURL = urls[len(urls)-1].ShortURL
+	}
+}
+
+func (h *ShortenerHandler) ShortURLStream(stream proto.Shortener_ShortURLStreamServer) error {
+	userID, err := contextUtil.GetUserIDFromContext(stream.Context())
+	if err != nil {
+		return status.Error(codes.Unauthenticated, "missing user id")
+	}
+
+	for {
+		in, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+
+		if err != nil {
+			return err
+		}
+
+		response := &proto.ResponseBatchURLDto{
+			CorrelationId: in.CorrelationId,
+		}
+
+		if len(in.OriginalUrl) == 0 {
+			response.Error = "invalid url"
+		} else {
+			shortenedURL, err := h.service.ShortURL(stream.Context(), in.OriginalUrl, userID, domain.ShortURLOptions{
+				RedirectType:     in.RedirectType,
+				UTM:              makeUTMParams(in.Utm),
+				QueryPassthrough: in.QueryPassthrough,
+			})
+
+			if errors.Is(err, domain.ErrURLConflict) {
+				response.Error = err.Error()
+			} else if err != nil {
+				return statusFromError(stream.Context(), err)
+			}
+
+			response.ShortUrl = fmt.Sprintf("%s/%s", h.appConfig.BaseShortURLAddr, shortenedURL.ShortURL)
+		}
+
+		if err := stream.Send(response); err != nil {
+			return err
+		}
+	}
+}
+
 func (h *ShortenerHandler) DeleteURLs(ctx context.Context, in *proto.DeleteURLsRequest) (*proto.DeleteURLsResponse, error) {
 	userID, err := contextUtil.GetUserIDFromContext(ctx)
 	if err != nil {
@@ -122,21 +226,65 @@ func (h *ShortenerHandler) DeleteURLs(ctx context.Context, in *proto.DeleteURLsR
 	}
 
 	if len(in.Urls) == 0 {
-		return nil, status.Error(codes.InvalidArgument, "you have to send at least 1 url")
+		return nil, invalidArgument("you have to send at least 1 url", "urls", "must not be empty")
 	}
 
-	if err := h.service.DeleteURLs(ctx, in.Urls, userID); err != nil {
-		return nil, status.Error(codes.Internal, err.Error())
+	task, err := h.service.DeleteURLs(ctx, in.Urls, userID)
+	if err != nil {
+		return nil, statusFromError(ctx, err)
 	}
 
-	return &proto.DeleteURLsResponse{}, nil
+	return &proto.DeleteURLsResponse{
+		TaskId: task.ID,
+	}, nil
+}
+
+func (h *ShortenerHandler) GetRedirectRules(ctx context.Context, in *proto.GetRedirectRulesRequest) (*proto.GetRedirectRulesResponse, error) {
+	userID, err := contextUtil.GetUserIDFromContext(ctx)
+	if err != nil {
+		return nil, status.Error(codes.Unauthenticated, "missing user id")
+	}
+
+	rules, err := h.service.GetRedirectRules(ctx, in.ShortUrl, userID)
+	if err != nil {
+		return nil, statusFromError(ctx, err)
+	}
+
+	return &proto.GetRedirectRulesResponse{
+		Rules: makeProtoRedirectRules(rules),
+	}, nil
+}
+
+func (h *ShortenerHandler) SetRedirectRules(ctx context.Context, in *proto.SetRedirectRulesRequest) (*proto.SetRedirectRulesResponse, error) {
+	userID, err := contextUtil.GetUserIDFromContext(ctx)
+	if err != nil {
+		return nil, status.Error(codes.Unauthenticated, "missing user id")
+	}
+
+	rules := make([]domain.RedirectRule, 0, len(in.Rules))
+	for _, rule := range in.Rules {
+		rules = append(rules, domain.RedirectRule{
+			Destination: rule.Destination,
+			Devices:     rule.Devices,
+			Languages:   rule.Languages,
+			Countries:   rule.Countries,
+		})
+	}
+
+	if err := h.service.SetRedirectRules(ctx, in.ShortUrl, userID, rules); err != nil {
+		return nil, statusFromError(ctx, err)
+	}
+
+	return &proto.SetRedirectRulesResponse{
+		Rules: makeProtoRedirectRules(rules),
+	}, nil
 }
 
 func (h *ShortenerHandler) GetStats(ctx context.Context, in *proto.GetStatsRequest) (*proto.GetStatsResponse, error) {
 	stats, err := h.service.GetInternalStats(ctx)
 
 	if err != nil {
-		return nil, status.Error(codes.Internal, err.Error())
+		return nil, statusFromError(ctx, err)
 	}
 
 	return &proto.GetStatsResponse{
@@ -147,10 +295,39 @@ func (h *ShortenerHandler) GetStats(ctx context.Context, in *proto.GetStatsReque
 
 func (h *ShortenerHandler) Ping(ctx context.Context, in *proto.PingRequest) (*proto.PingResponse, error) {
 	if err := h.service.Ping(ctx); err != nil {
-		return nil, status.Error(codes.Internal, err.Error())
+		return nil, statusFromError(ctx, err)
 	}
 
 	return &proto.PingResponse{
 		Ok: true,
 	}, nil
 }
+
+func makeUTMParams(params *proto.UTMParams) *domain.UTMParams {
+	if params == nil {
+		return nil
+	}
+
+	return &domain.UTMParams{
+		Source:   params.UtmSource,
+		Medium:   params.UtmMedium,
+		Campaign: params.UtmCampaign,
+		Term:     params.UtmTerm,
+		Content:  params.UtmContent,
+	}
+}
+
+func makeProtoRedirectRules(rules []domain.RedirectRule) []*proto.RedirectRule {
+	result := make([]*proto.RedirectRule, 0, len(rules))
+
+	for _, rule := range rules {
+		result = append(result, &proto.RedirectRule{
+			Destination: rule.Destination,
+			Devices:     rule.Devices,
+			Languages:   rule.Languages,
+			Countries:   rule.Countries,
+		})
+	}
+
+	return result
+}
@@ -0,0 +1,35 @@
+package http
+
+import (
+	"net/http"
+
+	"github.com/MowlCoder/go-url-shortener/pkg/httputil"
+)
+
+type cookiePolicy interface {
+	ExpiredCookie() *http.Cookie
+}
+
+// AuthHandler contains handlers of user session.
+type AuthHandler struct {
+	cookiePolicy cookiePolicy
+}
+
+// NewAuthHandler is constructor function for AuthHandler.
+func NewAuthHandler(cookiePolicy cookiePolicy) *AuthHandler {
+	return &AuthHandler{
+		cookiePolicy: cookiePolicy,
+	}
+}
+
+// Logout godoc
+// @Summary Logout
+// @Description Removes token cookie from browser. Next request is made by new user.
+// @Success 204
+// @Router /api/user/logout [post]
+func (h *AuthHandler) Logout(w http.ResponseWriter, r *http.Request) {
+	// auth middleware may have issued new token for this request, it is replaced by expired cookie
+	w.Header().Del("Set-Cookie")
+	http.SetCookie(w, h.cookiePolicy.ExpiredCookie())
+	httputil.SendStatusCode(w, http.StatusNoContent)
+}
@@ -0,0 +1,40 @@
+package http
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	handlersmock "github.com/MowlCoder/go-url-shortener/internal/handlers/http/mocks"
+)
+
+func TestLogout(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	cookiePolicy := handlersmock.NewMockcookiePolicy(ctrl)
+	handler := NewAuthHandler(cookiePolicy)
+
+	r := httptest.NewRequest(http.MethodPost, "/api/user/logout", nil)
+	w := httptest.NewRecorder()
+
+	// cookie issued by auth middleware for this request
+	http.SetCookie(w, &http.Cookie{Name: "token", Value: "new"})
+
+	cookiePolicy.
+		EXPECT().
+		ExpiredCookie().
+		Return(&http.Cookie{Name: "token", MaxAge: -1})
+
+	handler.Logout(w, r)
+
+	res := w.Result()
+	defer res.Body.Close()
+
+	assert.Equal(t, http.StatusNoContent, res.StatusCode)
+	require.Len(t, res.Cookies(), 1)
+	assert.Equal(t, "token", res.Cookies()[0].Name)
+	assert.Equal(t, -1, res.Cookies()[0].MaxAge)
+}
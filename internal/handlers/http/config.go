@@ -0,0 +1,62 @@
+package http
+
+import (
+	"net/http"
+
+	"github.com/MowlCoder/go-url-shortener/internal/apierror"
+	"github.com/MowlCoder/go-url-shortener/internal/domain"
+	"github.com/MowlCoder/go-url-shortener/internal/handlers/http/dtos"
+	"github.com/MowlCoder/go-url-shortener/pkg/httputil"
+)
+
+type configReloader interface {
+	Reload() (domain.ConfigReload, error)
+}
+
+// ConfigHandler contains handlers of runtime configuration.
+type ConfigHandler struct {
+	reloader configReloader
+}
+
+// NewConfigHandler is constructor function for ConfigHandler.
+func NewConfigHandler(reloader configReloader) *ConfigHandler {
+	return &ConfigHandler{
+		reloader: reloader,
+	}
+}
+
+// ReloadConfig godoc
+// @Summary Reload configuration
+// @Description Loads configuration again like SIGHUP does. Reloadable settings (trusted_subnet, trusted_proxies, log_level) are applied at once,
+// @Description other changed settings are applied after restart. Invalid configuration is rejected. Allowed only from trusted subnet.
+// @Produce json
+// @Success 200 {object} dtos.ConfigReloadResponse
+// @Failure 400 {object} apierror.Response
+// @Failure 403 {object} apierror.Response
+// @Router /api/internal/config/reload [post]
+func (h *ConfigHandler) ReloadConfig(w http.ResponseWriter, r *http.Request) {
+	result, err := h.reloader.Reload()
+	if err != nil {
+		apierror.Write(w, r, err)
+		return
+	}
+
+	httputil.SendJSONResponse(w, http.StatusOK, dtos.ConfigReloadResponse{
+		Applied:         toConfigChangeResponses(result.Applied),
+		RestartRequired: toConfigChangeResponses(result.RestartRequired),
+	})
+}
+
+func toConfigChangeResponses(changes []domain.ConfigChange) []dtos.ConfigChangeResponse {
+	responses := make([]dtos.ConfigChangeResponse, 0, len(changes))
+
+	for _, change := range changes {
+		responses = append(responses, dtos.ConfigChangeResponse{
+			Key: change.Key,
+			Old: change.Old,
+			New: change.New,
+		})
+	}
+
+	return responses
+}
@@ -0,0 +1,73 @@
+package http
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/MowlCoder/go-url-shortener/internal/domain"
+	"github.com/MowlCoder/go-url-shortener/internal/handlers/http/dtos"
+	handlersmock "github.com/MowlCoder/go-url-shortener/internal/handlers/http/mocks"
+)
+
+func TestReloadConfig(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	reloader := handlersmock.NewMockconfigReloader(ctrl)
+	handler := NewConfigHandler(reloader)
+
+	type TestCase struct {
+		Name               string
+		Result             domain.ConfigReload
+		Err                error
+		ExpectedStatusCode int
+	}
+
+	testCases := []TestCase{
+		{
+			Name: "reloaded",
+			Result: domain.ConfigReload{
+				Applied: []domain.ConfigChange{{Key: "log_level", Old: "info", New: "debug"}},
+			},
+			ExpectedStatusCode: http.StatusOK,
+		},
+		{
+			Name:               "invalid config",
+			Err:                fmt.Errorf("%w: trusted_subnet: invalid CIDR address", domain.ErrInvalidConfig),
+			ExpectedStatusCode: http.StatusBadRequest,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			r := httptest.NewRequest(http.MethodPost, "/api/internal/config/reload", nil)
+			w := httptest.NewRecorder()
+
+			reloader.
+				EXPECT().
+				Reload().
+				Return(testCase.Result, testCase.Err)
+
+			handler.ReloadConfig(w, r)
+
+			res := w.Result()
+			defer res.Body.Close()
+
+			require.Equal(t, testCase.ExpectedStatusCode, res.StatusCode)
+
+			if testCase.Err != nil {
+				return
+			}
+
+			var response dtos.ConfigReloadResponse
+			require.NoError(t, json.NewDecoder(res.Body).Decode(&response))
+			assert.Equal(t, []dtos.ConfigChangeResponse{{Key: "log_level", Old: "info", New: "debug"}}, response.Applied)
+			assert.Empty(t, response.RestartRequired)
+		})
+	}
+}
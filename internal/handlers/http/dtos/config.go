@@ -0,0 +1,14 @@
+package dtos
+
+// ConfigChangeResponse setting changed by configuration reload
+type ConfigChangeResponse struct {
+	Key string `json:"key"`
+	Old string `json:"old"`
+	New string `json:"new"`
+}
+
+// ConfigReloadResponse response body of configuration reload
+type ConfigReloadResponse struct {
+	Applied         []ConfigChangeResponse `json:"applied"`
+	RestartRequired []ConfigChangeResponse `json:"restart_required"`
+}
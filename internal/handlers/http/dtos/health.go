@@ -0,0 +1,13 @@
+package dtos
+
+// HealthComponentResponse health of one application component
+type HealthComponentResponse struct {
+	Status string `json:"status"`
+	Reason string `json:"reason,omitempty"`
+}
+
+// HealthResponse response body of liveness and readiness checks
+type HealthResponse struct {
+	Components map[string]HealthComponentResponse `json:"components,omitempty"`
+	Status     string                             `json:"status"`
+}
@@ -0,0 +1,23 @@
+package dtos
+
+import "time"
+
+// ImportRowResponse result of importing one row of file
+type ImportRowResponse struct {
+	OriginalURL string `json:"original_url"`
+	ShortURL    string `json:"short_url,omitempty"`
+	Error       string `json:"error,omitempty"`
+	Row         int    `json:"row"`
+}
+
+// ImportJobResponse response body of links import and of getting import job status
+type ImportJobResponse struct {
+	CreatedAt  time.Time           `json:"created_at"`
+	FinishedAt *time.Time          `json:"finished_at,omitempty"`
+	ID         string              `json:"id"`
+	Status     string              `json:"status"`
+	Rows       []ImportRowResponse `json:"rows"`
+	Total      int                 `json:"total"`
+	Succeeded  int                 `json:"succeeded"`
+	Failed     int                 `json:"failed"`
+}
@@ -1,8 +1,24 @@
 package dtos
 
+import "time"
+
+// UTMParams utm parameters appended to original url on redirect
+type UTMParams struct {
+	Source   string `json:"utm_source,omitempty"`
+	Medium   string `json:"utm_medium,omitempty"`
+	Campaign string `json:"utm_campaign,omitempty"`
+	Term     string `json:"utm_term,omitempty"`
+	Content  string `json:"utm_content,omitempty"`
+}
+
 // ShortURLDto request body for url shorting
 type ShortURLDto struct {
-	URL string `json:"url"`
+	UTM *UTMParams `json:"utm,omitempty"`
+	URL string     `json:"url"`
+	// RedirectType is 301, 302, 307, 308 or preview, 307 is used when it is empty
+	RedirectType string `json:"redirect_type,omitempty" enums:"301,302,307,308,preview"`
+	// QueryPassthrough adds query parameters of visitor to original url on redirect
+	QueryPassthrough bool `json:"query_passthrough,omitempty"`
 }
 
 // ShortURLResponse response body of url shorting
@@ -12,8 +28,11 @@ type ShortURLResponse struct {
 
 // ShortBatchURLDto request body for batch url shorting
 type ShortBatchURLDto struct {
-	OriginalURL   string `json:"original_url"`
-	CorrelationID string `json:"correlation_id"`
+	UTM              *UTMParams `json:"utm,omitempty"`
+	OriginalURL      string     `json:"original_url"`
+	CorrelationID    string     `json:"correlation_id"`
+	RedirectType     string     `json:"redirect_type,omitempty" enums:"301,302,307,308,preview"`
+	QueryPassthrough bool       `json:"query_passthrough,omitempty"`
 }
 
 // ShortBatchURLResponse response body of batch url shorting
@@ -24,13 +43,45 @@ type ShortBatchURLResponse struct {
 
 // UserURLsResponse response body of getting user urls
 type UserURLsResponse struct {
-	ShortURL    string `json:"short_url"`
-	OriginalURL string `json:"original_url"`
+	ShortURL     string `json:"short_url"`
+	OriginalURL  string `json:"original_url"`
+	RedirectType string `json:"redirect_type,omitempty"`
+}
+
+// RedirectRuleDto redirect rule of url, visitor matching all not empty conditions is sent to destination
+type RedirectRuleDto struct {
+	Destination string `json:"destination"`
+	// Devices are ios, android, mobile or desktop
+	Devices []string `json:"devices,omitempty" enums:"ios,android,mobile,desktop"`
+	// Languages are language tags, language without region matches every its region
+	Languages []string `json:"languages,omitempty"`
+	// Countries are ISO 3166-1 alpha-2 codes
+	Countries []string `json:"countries,omitempty"`
+}
+
+// RedirectRulesRequest request body for replacing redirect rules of url
+type RedirectRulesRequest struct {
+	Rules []RedirectRuleDto `json:"rules"`
+}
+
+// RedirectRulesResponse response body of getting and replacing redirect rules of url
+type RedirectRulesResponse struct {
+	Rules []RedirectRuleDto `json:"rules"`
 }
 
 // DeleteURLsRequest request body for deleting urls
 type DeleteURLsRequest []string
 
+// DeleteTaskResponse response body of deleting urls and of getting delete task status
+type DeleteTaskResponse struct {
+	CreatedAt   time.Time  `json:"created_at"`
+	CompletedAt *time.Time `json:"completed_at,omitempty"`
+	ID          string     `json:"id"`
+	Status      string     `json:"status"`
+	ShortURLs   []string   `json:"short_urls"`
+	Attempts    int        `json:"attempts"`
+}
+
 // GetStatsResponse response body of getting internal stats
 type GetStatsResponse struct {
 	URLs  int `json:"urls"`
@@ -0,0 +1,31 @@
+package dtos
+
+import "time"
+
+// CreateWebhookRequest request body for webhook creation
+type CreateWebhookRequest struct {
+	URL    string   `json:"url"`
+	Events []string `json:"events"`
+}
+
+// WebhookResponse response body of webhook. Secret is returned only on creation
+type WebhookResponse struct {
+	CreatedAt time.Time `json:"created_at"`
+	ID        string    `json:"id"`
+	URL       string    `json:"url"`
+	Secret    string    `json:"secret,omitempty"`
+	Events    []string  `json:"events"`
+}
+
+// WebhookDeliveryResponse response body of webhook delivery log record
+type WebhookDeliveryResponse struct {
+	AttemptedAt time.Time `json:"attempted_at"`
+	ID          string    `json:"id"`
+	EventID     string    `json:"event_id"`
+	EventType   string    `json:"event_type"`
+	Error       string    `json:"error,omitempty"`
+	StatusCode  int       `json:"status_code"`
+	Attempt     int       `json:"attempt"`
+	DurationMs  int64     `json:"duration_ms"`
+	Success     bool      `json:"success"`
+}
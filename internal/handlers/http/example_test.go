@@ -8,6 +8,7 @@ import (
 	"strings"
 
 	"github.com/MowlCoder/go-url-shortener/internal/config"
+	"github.com/MowlCoder/go-url-shortener/internal/geoip"
 	httpHandlers "github.com/MowlCoder/go-url-shortener/internal/handlers/http"
 	"github.com/MowlCoder/go-url-shortener/internal/logger"
 	"github.com/MowlCoder/go-url-shortener/internal/services"
@@ -27,17 +28,22 @@ func Example() {
 		Level:        logger.LogInfo,
 		IsProduction: appConfig.AppEnvironment == config.AppProductionEnv,
 	})
-	queue := services.NewDeleteURLQueue(urlStorage, customLogger, 3)
+	webhookService := services.NewWebhookService(urlStorage, customLogger)
+	queue := services.NewDeleteURLQueue(urlStorage, customLogger, webhookService, services.DeleteURLQueueOptions{})
+	clickCounter := services.NewClickCounter(urlStorage, customLogger, webhookService, services.ClickCounterOptions{})
 	shortenerService := services.NewShortenerService(
 		urlStorage,
 		strGeneratorService,
 		queue,
+		webhookService,
+		clickCounter,
 	)
 
 	// Create handler
 	handler := httpHandlers.NewShortenerHandler(
 		appConfig,
 		shortenerService,
+		&geoip.Reader{},
 	)
 
 	// Short url
@@ -0,0 +1,73 @@
+package http
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/MowlCoder/go-url-shortener/internal/domain"
+	"github.com/MowlCoder/go-url-shortener/internal/handlers/http/dtos"
+	"github.com/MowlCoder/go-url-shortener/pkg/httputil"
+)
+
+type healthService interface {
+	Liveness(ctx context.Context) domain.HealthReport
+	Readiness(ctx context.Context) domain.HealthReport
+}
+
+// HealthHandler contains handlers for liveness and readiness probes.
+type HealthHandler struct {
+	service healthService
+}
+
+// NewHealthHandler is constructor function for HealthHandler.
+func NewHealthHandler(service healthService) *HealthHandler {
+	return &HealthHandler{
+		service: service,
+	}
+}
+
+// Healthz godoc
+// @Summary Checking if process is alive
+// @Produce json
+// @Success 200 {object} dtos.HealthResponse
+// @Failure 503 {object} dtos.HealthResponse
+// @Router /healthz [get]
+func (h *HealthHandler) Healthz(w http.ResponseWriter, r *http.Request) {
+	sendHealthReport(w, h.service.Liveness(r.Context()))
+}
+
+// Readyz godoc
+// @Summary Checking if server is ready to accept traffic
+// @Description Response contains status of every component: lifecycle, storage, migrations, delete_url_queue.
+// @Description Component, that is down, has short reason, details of failure are only logged.
+// @Produce json
+// @Success 200 {object} dtos.HealthResponse
+// @Failure 503 {object} dtos.HealthResponse
+// @Router /readyz [get]
+func (h *HealthHandler) Readyz(w http.ResponseWriter, r *http.Request) {
+	sendHealthReport(w, h.service.Readiness(r.Context()))
+}
+
+func sendHealthReport(w http.ResponseWriter, report domain.HealthReport) {
+	response := dtos.HealthResponse{
+		Status: report.Status,
+	}
+
+	if len(report.Components) > 0 {
+		response.Components = make(map[string]dtos.HealthComponentResponse, len(report.Components))
+
+		for name, component := range report.Components {
+			response.Components[name] = dtos.HealthComponentResponse{
+				Status: component.Status,
+				Reason: component.Reason,
+			}
+		}
+	}
+
+	statusCode := http.StatusOK
+	if !report.IsUp() {
+		statusCode = http.StatusServiceUnavailable
+	}
+
+	httputil.SendJSONResponse(w, statusCode, response)
+}
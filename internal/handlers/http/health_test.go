@@ -0,0 +1,96 @@
+package http
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/MowlCoder/go-url-shortener/internal/domain"
+	"github.com/MowlCoder/go-url-shortener/internal/handlers/http/dtos"
+	handlersmock "github.com/MowlCoder/go-url-shortener/internal/handlers/http/mocks"
+)
+
+func TestHealthz(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	service := handlersmock.NewMockhealthService(ctrl)
+	handler := NewHealthHandler(service)
+
+	r := httptest.NewRequest(http.MethodGet, "/healthz", nil)
+	w := httptest.NewRecorder()
+
+	service.
+		EXPECT().
+		Liveness(r.Context()).
+		Return(domain.HealthReport{Status: domain.HealthStatusUp})
+
+	handler.Healthz(w, r)
+
+	res := w.Result()
+	defer res.Body.Close()
+
+	assert.Equal(t, http.StatusOK, res.StatusCode)
+}
+
+func TestReadyz(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	service := handlersmock.NewMockhealthService(ctrl)
+	handler := NewHealthHandler(service)
+
+	type TestCase struct {
+		Name               string
+		Report             domain.HealthReport
+		ExpectedStatusCode int
+	}
+
+	testCases := []TestCase{
+		{
+			Name: "ready",
+			Report: domain.HealthReport{
+				Status: domain.HealthStatusUp,
+				Components: map[string]domain.HealthComponent{
+					"storage": {Status: domain.HealthStatusUp},
+				},
+			},
+			ExpectedStatusCode: http.StatusOK,
+		},
+		{
+			Name: "not ready",
+			Report: domain.HealthReport{
+				Status: domain.HealthStatusDown,
+				Components: map[string]domain.HealthComponent{
+					"storage": {Status: domain.HealthStatusDown, Reason: "storage is unreachable"},
+				},
+			},
+			ExpectedStatusCode: http.StatusServiceUnavailable,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			r := httptest.NewRequest(http.MethodGet, "/readyz", nil)
+			w := httptest.NewRecorder()
+
+			service.
+				EXPECT().
+				Readiness(r.Context()).
+				Return(testCase.Report)
+
+			handler.Readyz(w, r)
+
+			res := w.Result()
+			defer res.Body.Close()
+
+			assert.Equal(t, testCase.ExpectedStatusCode, res.StatusCode)
+
+			var responseBody dtos.HealthResponse
+			require.NoError(t, json.NewDecoder(res.Body).Decode(&responseBody))
+			assert.Equal(t, testCase.Report.Status, responseBody.Status)
+			assert.Equal(t, testCase.Report.Components["storage"].Reason, responseBody.Components["storage"].Reason)
+		})
+	}
+}
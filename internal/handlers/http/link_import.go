@@ -0,0 +1,193 @@
+package http
+
+import (
+	"context"
+	"errors"
+	"io"
+	"mime"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/MowlCoder/go-url-shortener/internal/apierror"
+	contextUtil "github.com/MowlCoder/go-url-shortener/internal/context"
+	"github.com/MowlCoder/go-url-shortener/internal/domain"
+	"github.com/MowlCoder/go-url-shortener/internal/handlers/http/dtos"
+	"github.com/MowlCoder/go-url-shortener/internal/services"
+	"github.com/MowlCoder/go-url-shortener/pkg/httputil"
+)
+
+// maxImportBodySize is max size of import file in bytes.
+const maxImportBodySize = 10 << 20
+
+var linksFormatContentTypes = map[string]string{
+	services.LinksFormatCSV:       "text/csv",
+	services.LinksFormatJSONLines: "application/x-ndjson",
+}
+
+var linksFormatByContentType = map[string]string{
+	"text/csv":               services.LinksFormatCSV,
+	"application/x-ndjson":   services.LinksFormatJSONLines,
+	"application/jsonl":      services.LinksFormatJSONLines,
+	"application/json-lines": services.LinksFormatJSONLines,
+}
+
+type linkImportService interface {
+	StartImport(ctx context.Context, userID string, format string, body io.Reader) (*domain.ImportJob, error)
+	GetImportJob(ctx context.Context, id string, userID string) (*domain.ImportJob, error)
+	Export(ctx context.Context, userID string, format string, w io.Writer) error
+}
+
+// LinkImportHandler contains handlers for bulk import and export of user links.
+type LinkImportHandler struct {
+	service linkImportService
+}
+
+// NewLinkImportHandler is constructor function for LinkImportHandler.
+func NewLinkImportHandler(service linkImportService) *LinkImportHandler {
+	return &LinkImportHandler{
+		service: service,
+	}
+}
+
+// ImportURLs godoc
+// @Summary Import user urls from CSV or JSON lines file
+// @Description Format is taken from format query parameter or Content-Type header.
+// @Description CSV file must have header with original_url column, optional columns are alias, tags (separated by ';'), expires_at (RFC 3339),
+// @Description redirect_type, utm_source, utm_medium, utm_campaign, utm_term, utm_content and query_passthrough (true or false).
+// @Description Redirect rules can be imported only from JSON lines file, in redirect_rules field.
+// @Description Import is done in background. Status and per-row results can be polled by url from Location header.
+// @Accept text/csv,application/x-ndjson
+// @Produce json
+// @Param format query string false "File format: csv or jsonl"
+// @Param file body string true "File content"
+// @Success 202 {object} dtos.ImportJobResponse
+// @Failure 400 {object} apierror.Response
+// @Failure 401 {object} apierror.Response
+// @Failure 413 {object} apierror.Response
+// @Failure 415 {object} apierror.Response
+// @Failure 500 {object} apierror.Response
+// @Router /api/user/urls/import [post]
+func (h *LinkImportHandler) ImportURLs(w http.ResponseWriter, r *http.Request) {
+	userID, err := contextUtil.GetUserIDFromContext(r.Context())
+	if err != nil {
+		apierror.Write(w, r, apierror.ErrUnauthorized)
+		return
+	}
+
+	format := r.URL.Query().Get("format")
+	if format == "" {
+		mediaType, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type"))
+		format = linksFormatByContentType[mediaType]
+	}
+
+	if !services.IsSupportedLinksFormat(format) {
+		apierror.Write(w, r, apierror.New(http.StatusUnsupportedMediaType, apierror.CodeUnsupportedMediaType, domain.ErrUnsupportedFormat.Error()))
+		return
+	}
+
+	body := http.MaxBytesReader(w, r.Body, maxImportBodySize)
+	job, err := h.service.StartImport(r.Context(), userID, format, body)
+
+	var maxBytesErr *http.MaxBytesError
+
+	if errors.As(err, &maxBytesErr) {
+		apierror.Write(w, r, apierror.New(http.StatusRequestEntityTooLarge, apierror.CodeRequestTooLarge, "import file is too large"))
+		return
+	}
+
+	if err != nil {
+		apierror.Write(w, r, err)
+		return
+	}
+
+	w.Header().Set("Location", "/api/user/urls/import/"+job.ID)
+	httputil.SendJSONResponse(w, http.StatusAccepted, makeImportJobResponse(job))
+}
+
+// GetImportJob godoc
+// @Summary Get status and results of user urls import
+// @Produce json
+// @Param id path string true "Import job ID"
+// @Success 200 {object} dtos.ImportJobResponse
+// @Failure 401 {object} apierror.Response
+// @Failure 404 {object} apierror.Response
+// @Failure 500 {object} apierror.Response
+// @Router /api/user/urls/import/{id} [get]
+func (h *LinkImportHandler) GetImportJob(w http.ResponseWriter, r *http.Request) {
+	userID, err := contextUtil.GetUserIDFromContext(r.Context())
+	if err != nil {
+		apierror.Write(w, r, apierror.ErrUnauthorized)
+		return
+	}
+
+	job, err := h.service.GetImportJob(r.Context(), chi.URLParam(r, "id"), userID)
+
+	if err != nil {
+		apierror.Write(w, r, err)
+		return
+	}
+
+	httputil.SendJSONResponse(w, http.StatusOK, makeImportJobResponse(job))
+}
+
+// ExportURLs godoc
+// @Summary Export user urls to CSV or JSON lines file
+// @Description CSV file has the same columns as import file plus short_url and clicks. Redirect rules are exported only to JSON lines file.
+// @Produce text/csv,application/x-ndjson
+// @Param format query string false "File format: csv or jsonl (default)"
+// @Success 200 {string} string
+// @Failure 400 {object} apierror.Response
+// @Failure 401 {object} apierror.Response
+// @Failure 500 {object} apierror.Response
+// @Router /api/user/urls/export [get]
+func (h *LinkImportHandler) ExportURLs(w http.ResponseWriter, r *http.Request) {
+	userID, err := contextUtil.GetUserIDFromContext(r.Context())
+	if err != nil {
+		apierror.Write(w, r, apierror.ErrUnauthorized)
+		return
+	}
+
+	format := r.URL.Query().Get("format")
+	if format == "" {
+		format = services.LinksFormatJSONLines
+	}
+
+	contentType, ok := linksFormatContentTypes[format]
+	if !ok {
+		apierror.Write(w, r, domain.ErrUnsupportedFormat)
+		return
+	}
+
+	w.Header().Set("content-type", contentType)
+	w.Header().Set("Content-Disposition", `attachment; filename="urls.`+format+`"`)
+
+	if err := h.service.Export(r.Context(), userID, format, w); err != nil {
+		apierror.Write(w, r, err)
+		return
+	}
+}
+
+func makeImportJobResponse(job *domain.ImportJob) dtos.ImportJobResponse {
+	response := dtos.ImportJobResponse{
+		CreatedAt:  job.CreatedAt,
+		FinishedAt: job.FinishedAt,
+		ID:         job.ID,
+		Status:     job.Status,
+		Rows:       make([]dtos.ImportRowResponse, 0, len(job.Rows)),
+		Total:      job.Total,
+		Succeeded:  job.Succeeded,
+		Failed:     job.Failed,
+	}
+
+	for _, row := range job.Rows {
+		response.Rows = append(response.Rows, dtos.ImportRowResponse{
+			OriginalURL: row.OriginalURL,
+			ShortURL:    row.ShortURL,
+			Error:       row.Error,
+			Row:         row.Row,
+		})
+	}
+
+	return response
+}
@@ -0,0 +1,306 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	contextUtil "github.com/MowlCoder/go-url-shortener/internal/context"
+	"github.com/MowlCoder/go-url-shortener/internal/domain"
+	"github.com/MowlCoder/go-url-shortener/internal/handlers/http/dtos"
+	handlersmock "github.com/MowlCoder/go-url-shortener/internal/handlers/http/mocks"
+	"github.com/MowlCoder/go-url-shortener/internal/services"
+)
+
+func TestImportURLs(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	service := handlersmock.NewMocklinkImportService(ctrl)
+	handler := NewLinkImportHandler(service)
+
+	type TestCase struct {
+		PrepareServiceFunc func(ctx context.Context)
+		Name               string
+		ContentType        string
+		Query              string
+		NotAuth            bool
+		ExpectedStatusCode int
+	}
+
+	testCases := []TestCase{
+		{
+			Name:        "valid csv",
+			ContentType: "text/csv; charset=utf-8",
+			PrepareServiceFunc: func(ctx context.Context) {
+				service.
+					EXPECT().
+					StartImport(ctx, "1", services.LinksFormatCSV, gomock.Any()).
+					Return(&domain.ImportJob{ID: "job", Status: domain.ImportJobPending, Total: 1}, nil)
+			},
+			ExpectedStatusCode: http.StatusAccepted,
+		},
+		{
+			Name:  "valid jsonl from query",
+			Query: "?format=jsonl",
+			PrepareServiceFunc: func(ctx context.Context) {
+				service.
+					EXPECT().
+					StartImport(ctx, "1", services.LinksFormatJSONLines, gomock.Any()).
+					Return(&domain.ImportJob{ID: "job", Status: domain.ImportJobPending, Total: 1}, nil)
+			},
+			ExpectedStatusCode: http.StatusAccepted,
+		},
+		{
+			Name:               "not auth",
+			NotAuth:            true,
+			ExpectedStatusCode: http.StatusUnauthorized,
+		},
+		{
+			Name:               "unsupported format",
+			ContentType:        "application/xml",
+			ExpectedStatusCode: http.StatusUnsupportedMediaType,
+		},
+		{
+			Name:        "invalid file",
+			ContentType: "text/csv",
+			PrepareServiceFunc: func(ctx context.Context) {
+				service.
+					EXPECT().
+					StartImport(ctx, "1", services.LinksFormatCSV, gomock.Any()).
+					Return(nil, domain.ErrInvalidImportFile)
+			},
+			ExpectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			Name:        "internal server error",
+			ContentType: "text/csv",
+			PrepareServiceFunc: func(ctx context.Context) {
+				service.
+					EXPECT().
+					StartImport(ctx, "1", services.LinksFormatCSV, gomock.Any()).
+					Return(nil, errors.New("undefined behavior"))
+			},
+			ExpectedStatusCode: http.StatusInternalServerError,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			r := httptest.NewRequest(
+				http.MethodPost,
+				"/api/user/urls/import"+testCase.Query,
+				strings.NewReader("original_url\nhttps://example.com\n"),
+			)
+			r.Header.Set("Content-Type", testCase.ContentType)
+
+			if !testCase.NotAuth {
+				ctx := contextUtil.SetUserIDToContext(r.Context(), "1")
+				r = r.WithContext(ctx)
+			}
+
+			w := httptest.NewRecorder()
+
+			if testCase.PrepareServiceFunc != nil {
+				testCase.PrepareServiceFunc(r.Context())
+			}
+
+			handler.ImportURLs(w, r)
+
+			res := w.Result()
+			defer res.Body.Close()
+
+			assert.Equal(t, testCase.ExpectedStatusCode, res.StatusCode)
+
+			if res.StatusCode == http.StatusAccepted {
+				assert.Equal(t, "/api/user/urls/import/job", res.Header.Get("Location"))
+			}
+		})
+	}
+}
+
+func TestGetImportJob(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	service := handlersmock.NewMocklinkImportService(ctrl)
+	handler := NewLinkImportHandler(service)
+
+	type TestCase struct {
+		PrepareServiceFunc func(ctx context.Context)
+		Name               string
+		NotAuth            bool
+		ExpectedStatusCode int
+	}
+
+	testCases := []TestCase{
+		{
+			Name: "valid",
+			PrepareServiceFunc: func(ctx context.Context) {
+				service.
+					EXPECT().
+					GetImportJob(ctx, "job", "1").
+					Return(&domain.ImportJob{
+						ID:        "job",
+						Status:    domain.ImportJobCompleted,
+						Total:     1,
+						Succeeded: 1,
+						Rows:      []domain.ImportRowResult{{Row: 2, OriginalURL: "https://example.com", ShortURL: "http://localhost/a"}},
+					}, nil)
+			},
+			ExpectedStatusCode: http.StatusOK,
+		},
+		{
+			Name:               "not auth",
+			NotAuth:            true,
+			ExpectedStatusCode: http.StatusUnauthorized,
+		},
+		{
+			Name: "not found",
+			PrepareServiceFunc: func(ctx context.Context) {
+				service.
+					EXPECT().
+					GetImportJob(ctx, "job", "1").
+					Return(nil, domain.ErrImportJobNotFound)
+			},
+			ExpectedStatusCode: http.StatusNotFound,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			r := httptest.NewRequest(http.MethodGet, "/api/user/urls/import/job", nil)
+
+			routeCtx := chi.NewRouteContext()
+			routeCtx.URLParams.Add("id", "job")
+			ctx := context.WithValue(r.Context(), chi.RouteCtxKey, routeCtx)
+
+			if !testCase.NotAuth {
+				ctx = contextUtil.SetUserIDToContext(ctx, "1")
+			}
+
+			r = r.WithContext(ctx)
+			w := httptest.NewRecorder()
+
+			if testCase.PrepareServiceFunc != nil {
+				testCase.PrepareServiceFunc(r.Context())
+			}
+
+			handler.GetImportJob(w, r)
+
+			res := w.Result()
+			defer res.Body.Close()
+
+			assert.Equal(t, testCase.ExpectedStatusCode, res.StatusCode)
+
+			if res.StatusCode == http.StatusOK {
+				response, err := io.ReadAll(res.Body)
+				require.NoError(t, err)
+
+				var responseBody dtos.ImportJobResponse
+				require.NoError(t, json.Unmarshal(response, &responseBody))
+				assert.Equal(t, 1, responseBody.Succeeded)
+				require.Len(t, responseBody.Rows, 1)
+				assert.Equal(t, "http://localhost/a", responseBody.Rows[0].ShortURL)
+			}
+		})
+	}
+}
+
+func TestExportURLs(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	service := handlersmock.NewMocklinkImportService(ctrl)
+	handler := NewLinkImportHandler(service)
+
+	type TestCase struct {
+		PrepareServiceFunc  func(ctx context.Context)
+		Name                string
+		Query               string
+		ExpectedContentType string
+		NotAuth             bool
+		ExpectedStatusCode  int
+	}
+
+	testCases := []TestCase{
+		{
+			Name:  "csv",
+			Query: "?format=csv",
+			PrepareServiceFunc: func(ctx context.Context) {
+				service.
+					EXPECT().
+					Export(ctx, "1", services.LinksFormatCSV, gomock.Any()).
+					DoAndReturn(func(ctx context.Context, userID string, format string, w io.Writer) error {
+						_, err := io.WriteString(w, "original_url\n")
+						return err
+					})
+			},
+			ExpectedContentType: "text/csv",
+			ExpectedStatusCode:  http.StatusOK,
+		},
+		{
+			Name: "jsonl by default",
+			PrepareServiceFunc: func(ctx context.Context) {
+				service.
+					EXPECT().
+					Export(ctx, "1", services.LinksFormatJSONLines, gomock.Any()).
+					Return(nil)
+			},
+			ExpectedContentType: "application/x-ndjson",
+			ExpectedStatusCode:  http.StatusOK,
+		},
+		{
+			Name:               "not auth",
+			NotAuth:            true,
+			ExpectedStatusCode: http.StatusUnauthorized,
+		},
+		{
+			Name:               "unsupported format",
+			Query:              "?format=xml",
+			ExpectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			Name: "internal server error",
+			PrepareServiceFunc: func(ctx context.Context) {
+				service.
+					EXPECT().
+					Export(ctx, "1", services.LinksFormatJSONLines, gomock.Any()).
+					Return(errors.New("undefined behavior"))
+			},
+			ExpectedStatusCode: http.StatusInternalServerError,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			r := httptest.NewRequest(http.MethodGet, "/api/user/urls/export"+testCase.Query, nil)
+
+			if !testCase.NotAuth {
+				ctx := contextUtil.SetUserIDToContext(r.Context(), "1")
+				r = r.WithContext(ctx)
+			}
+
+			w := httptest.NewRecorder()
+
+			if testCase.PrepareServiceFunc != nil {
+				testCase.PrepareServiceFunc(r.Context())
+			}
+
+			handler.ExportURLs(w, r)
+
+			res := w.Result()
+			defer res.Body.Close()
+
+			assert.Equal(t, testCase.ExpectedStatusCode, res.StatusCode)
+
+			if testCase.ExpectedContentType != "" {
+				assert.Equal(t, testCase.ExpectedContentType, res.Header.Get("Content-Type"))
+			}
+		})
+	}
+}
@@ -0,0 +1,53 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: auth.go
+//
+// Generated by this command:
+//
+//	mockgen -source=auth.go -destination=./mocks/auth.go -package=handlersmock
+//
+// Package handlersmock is a generated GoMock package.
+package handlersmock
+
+import (
+	http "net/http"
+	reflect "reflect"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockcookiePolicy is a mock of cookiePolicy interface.
+type MockcookiePolicy struct {
+	ctrl     *gomock.Controller
+	recorder *MockcookiePolicyMockRecorder
+}
+
+// MockcookiePolicyMockRecorder is the mock recorder for MockcookiePolicy.
+type MockcookiePolicyMockRecorder struct {
+	mock *MockcookiePolicy
+}
+
+// NewMockcookiePolicy creates a new mock instance.
+func NewMockcookiePolicy(ctrl *gomock.Controller) *MockcookiePolicy {
+	mock := &MockcookiePolicy{ctrl: ctrl}
+	mock.recorder = &MockcookiePolicyMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockcookiePolicy) EXPECT() *MockcookiePolicyMockRecorder {
+	return m.recorder
+}
+
+// ExpiredCookie mocks base method.
+func (m *MockcookiePolicy) ExpiredCookie() *http.Cookie {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExpiredCookie")
+	ret0, _ := ret[0].(*http.Cookie)
+	return ret0
+}
+
+// ExpiredCookie indicates an expected call of ExpiredCookie.
+func (mr *MockcookiePolicyMockRecorder) ExpiredCookie() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExpiredCookie", reflect.TypeOf((*MockcookiePolicy)(nil).ExpiredCookie))
+}
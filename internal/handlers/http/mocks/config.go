@@ -0,0 +1,54 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: config.go
+//
+// Generated by this command:
+//
+//	mockgen -source=config.go -destination=./mocks/config.go -package=handlersmock
+//
+// Package handlersmock is a generated GoMock package.
+package handlersmock
+
+import (
+	reflect "reflect"
+
+	domain "github.com/MowlCoder/go-url-shortener/internal/domain"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockconfigReloader is a mock of configReloader interface.
+type MockconfigReloader struct {
+	ctrl     *gomock.Controller
+	recorder *MockconfigReloaderMockRecorder
+}
+
+// MockconfigReloaderMockRecorder is the mock recorder for MockconfigReloader.
+type MockconfigReloaderMockRecorder struct {
+	mock *MockconfigReloader
+}
+
+// NewMockconfigReloader creates a new mock instance.
+func NewMockconfigReloader(ctrl *gomock.Controller) *MockconfigReloader {
+	mock := &MockconfigReloader{ctrl: ctrl}
+	mock.recorder = &MockconfigReloaderMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockconfigReloader) EXPECT() *MockconfigReloaderMockRecorder {
+	return m.recorder
+}
+
+// Reload mocks base method.
+func (m *MockconfigReloader) Reload() (domain.ConfigReload, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Reload")
+	ret0, _ := ret[0].(domain.ConfigReload)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Reload indicates an expected call of Reload.
+func (mr *MockconfigReloaderMockRecorder) Reload() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Reload", reflect.TypeOf((*MockconfigReloader)(nil).Reload))
+}
@@ -0,0 +1,68 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: health.go
+//
+// Generated by this command:
+//
+//	mockgen -source=health.go -destination=./mocks/health.go -package=handlersmock
+//
+// Package handlersmock is a generated GoMock package.
+package handlersmock
+
+import (
+	context "context"
+	reflect "reflect"
+
+	domain "github.com/MowlCoder/go-url-shortener/internal/domain"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockhealthService is a mock of healthService interface.
+type MockhealthService struct {
+	ctrl     *gomock.Controller
+	recorder *MockhealthServiceMockRecorder
+}
+
+// MockhealthServiceMockRecorder is the mock recorder for MockhealthService.
+type MockhealthServiceMockRecorder struct {
+	mock *MockhealthService
+}
+
+// NewMockhealthService creates a new mock instance.
+func NewMockhealthService(ctrl *gomock.Controller) *MockhealthService {
+	mock := &MockhealthService{ctrl: ctrl}
+	mock.recorder = &MockhealthServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockhealthService) EXPECT() *MockhealthServiceMockRecorder {
+	return m.recorder
+}
+
+// Liveness mocks base method.
+func (m *MockhealthService) Liveness(ctx context.Context) domain.HealthReport {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Liveness", ctx)
+	ret0, _ := ret[0].(domain.HealthReport)
+	return ret0
+}
+
+// Liveness indicates an expected call of Liveness.
+func (mr *MockhealthServiceMockRecorder) Liveness(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Liveness", reflect.TypeOf((*MockhealthService)(nil).Liveness), ctx)
+}
+
+// Readiness mocks base method.
+func (m *MockhealthService) Readiness(ctx context.Context) domain.HealthReport {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Readiness", ctx)
+	ret0, _ := ret[0].(domain.HealthReport)
+	return ret0
+}
+
+// Readiness indicates an expected call of Readiness.
+func (mr *MockhealthServiceMockRecorder) Readiness(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Readiness", reflect.TypeOf((*MockhealthService)(nil).Readiness), ctx)
+}
@@ -0,0 +1,85 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: link_import.go
+//
+// Generated by this command:
+//
+//	mockgen -source=link_import.go -destination=./mocks/link_import.go -package=handlersmock
+//
+// Package handlersmock is a generated GoMock package.
+package handlersmock
+
+import (
+	context "context"
+	io "io"
+	reflect "reflect"
+
+	domain "github.com/MowlCoder/go-url-shortener/internal/domain"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MocklinkImportService is a mock of linkImportService interface.
+type MocklinkImportService struct {
+	ctrl     *gomock.Controller
+	recorder *MocklinkImportServiceMockRecorder
+}
+
+// MocklinkImportServiceMockRecorder is the mock recorder for MocklinkImportService.
+type MocklinkImportServiceMockRecorder struct {
+	mock *MocklinkImportService
+}
+
+// NewMocklinkImportService creates a new mock instance.
+func NewMocklinkImportService(ctrl *gomock.Controller) *MocklinkImportService {
+	mock := &MocklinkImportService{ctrl: ctrl}
+	mock.recorder = &MocklinkImportServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MocklinkImportService) EXPECT() *MocklinkImportServiceMockRecorder {
+	return m.recorder
+}
+
+// Export mocks base method.
+func (m *MocklinkImportService) Export(ctx context.Context, userID, format string, w io.Writer) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Export", ctx, userID, format, w)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Export indicates an expected call of Export.
+func (mr *MocklinkImportServiceMockRecorder) Export(ctx, userID, format, w any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Export", reflect.TypeOf((*MocklinkImportService)(nil).Export), ctx, userID, format, w)
+}
+
+// GetImportJob mocks base method.
+func (m *MocklinkImportService) GetImportJob(ctx context.Context, id, userID string) (*domain.ImportJob, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetImportJob", ctx, id, userID)
+	ret0, _ := ret[0].(*domain.ImportJob)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetImportJob indicates an expected call of GetImportJob.
+func (mr *MocklinkImportServiceMockRecorder) GetImportJob(ctx, id, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetImportJob", reflect.TypeOf((*MocklinkImportService)(nil).GetImportJob), ctx, id, userID)
+}
+
+// StartImport mocks base method.
+func (m *MocklinkImportService) StartImport(ctx context.Context, userID, format string, body io.Reader) (*domain.ImportJob, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StartImport", ctx, userID, format, body)
+	ret0, _ := ret[0].(*domain.ImportJob)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// StartImport indicates an expected call of StartImport.
+func (mr *MocklinkImportServiceMockRecorder) StartImport(ctx, userID, format, body any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StartImport", reflect.TypeOf((*MocklinkImportService)(nil).StartImport), ctx, userID, format, body)
+}
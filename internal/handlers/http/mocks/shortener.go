@@ -10,6 +10,7 @@ package handlersmock
 
 import (
 	context "context"
+	net "net"
 	reflect "reflect"
 
 	domain "github.com/MowlCoder/go-url-shortener/internal/domain"
@@ -40,11 +41,12 @@ func (m *MockshortenerService) EXPECT() *MockshortenerServiceMockRecorder {
 }
 
 // DeleteURLs mocks base method.
-func (m *MockshortenerService) DeleteURLs(ctx context.Context, urls []string, userID string) error {
+func (m *MockshortenerService) DeleteURLs(ctx context.Context, urls []string, userID string) (*domain.DeleteURLsTask, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "DeleteURLs", ctx, urls, userID)
-	ret0, _ := ret[0].(error)
-	return ret0
+	ret0, _ := ret[0].(*domain.DeleteURLsTask)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
 // DeleteURLs indicates an expected call of DeleteURLs.
@@ -68,6 +70,21 @@ func (mr *MockshortenerServiceMockRecorder) GetByShortURL(ctx, url any) *gomock.
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByShortURL", reflect.TypeOf((*MockshortenerService)(nil).GetByShortURL), ctx, url)
 }
 
+// GetDeleteTask mocks base method.
+func (m *MockshortenerService) GetDeleteTask(ctx context.Context, id, userID string) (*domain.DeleteURLsTask, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDeleteTask", ctx, id, userID)
+	ret0, _ := ret[0].(*domain.DeleteURLsTask)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDeleteTask indicates an expected call of GetDeleteTask.
+func (mr *MockshortenerServiceMockRecorder) GetDeleteTask(ctx, id, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDeleteTask", reflect.TypeOf((*MockshortenerService)(nil).GetDeleteTask), ctx, id, userID)
+}
+
 // GetInternalStats mocks base method.
 func (m *MockshortenerService) GetInternalStats(ctx context.Context) (*domain.InternalStats, error) {
 	m.ctrl.T.Helper()
@@ -83,6 +100,21 @@ func (mr *MockshortenerServiceMockRecorder) GetInternalStats(ctx any) *gomock.Ca
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetInternalStats", reflect.TypeOf((*MockshortenerService)(nil).GetInternalStats), ctx)
 }
 
+// GetRedirectRules mocks base method.
+func (m *MockshortenerService) GetRedirectRules(ctx context.Context, shortURL, userID string) ([]domain.RedirectRule, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRedirectRules", ctx, shortURL, userID)
+	ret0, _ := ret[0].([]domain.RedirectRule)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRedirectRules indicates an expected call of GetRedirectRules.
+func (mr *MockshortenerServiceMockRecorder) GetRedirectRules(ctx, shortURL, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRedirectRules", reflect.TypeOf((*MockshortenerService)(nil).GetRedirectRules), ctx, shortURL, userID)
+}
+
 // GetUserURLs mocks base method.
 func (m *MockshortenerService) GetUserURLs(ctx context.Context, userID string) ([]domain.ShortenedURL, error) {
 	m.ctrl.T.Helper()
@@ -112,6 +144,34 @@ func (mr *MockshortenerServiceMockRecorder) Ping(ctx any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Ping", reflect.TypeOf((*MockshortenerService)(nil).Ping), ctx)
 }
 
+// RegisterClick mocks base method.
+func (m *MockshortenerService) RegisterClick(ctx context.Context, url *domain.ShortenedURL) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RegisterClick", ctx, url)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RegisterClick indicates an expected call of RegisterClick.
+func (mr *MockshortenerServiceMockRecorder) RegisterClick(ctx, url any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RegisterClick", reflect.TypeOf((*MockshortenerService)(nil).RegisterClick), ctx, url)
+}
+
+// SetRedirectRules mocks base method.
+func (m *MockshortenerService) SetRedirectRules(ctx context.Context, shortURL, userID string, rules []domain.RedirectRule) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetRedirectRules", ctx, shortURL, userID, rules)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetRedirectRules indicates an expected call of SetRedirectRules.
+func (mr *MockshortenerServiceMockRecorder) SetRedirectRules(ctx, shortURL, userID, rules any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetRedirectRules", reflect.TypeOf((*MockshortenerService)(nil).SetRedirectRules), ctx, shortURL, userID, rules)
+}
+
 // ShortBatchURL mocks base method.
 func (m *MockshortenerService) ShortBatchURL(ctx context.Context, urls []domain.ShortBatchURL, userID string) ([]domain.ShortBatchURL, error) {
 	m.ctrl.T.Helper()
@@ -128,16 +188,53 @@ func (mr *MockshortenerServiceMockRecorder) ShortBatchURL(ctx, urls, userID any)
 }
 
 // ShortURL mocks base method.
-func (m *MockshortenerService) ShortURL(ctx context.Context, url, userID string) (*domain.ShortenedURL, error) {
+func (m *MockshortenerService) ShortURL(ctx context.Context, url, userID string, options domain.ShortURLOptions) (*domain.ShortenedURL, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ShortURL", ctx, url, userID)
+	ret := m.ctrl.Call(m, "ShortURL", ctx, url, userID, options)
 	ret0, _ := ret[0].(*domain.ShortenedURL)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // ShortURL indicates an expected call of ShortURL.
-func (mr *MockshortenerServiceMockRecorder) ShortURL(ctx, url, userID any) *gomock.Call {
+func (mr *MockshortenerServiceMockRecorder) ShortURL(ctx, url, userID, options any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ShortURL", reflect.TypeOf((*MockshortenerService)(nil).ShortURL), ctx, url, userID, options)
+}
+
+// MockcountryResolver is a mock of countryResolver interface.
+type MockcountryResolver struct {
+	ctrl     *gomock.Controller
+	recorder *MockcountryResolverMockRecorder
+}
+
+// MockcountryResolverMockRecorder is the mock recorder for MockcountryResolver.
+type MockcountryResolverMockRecorder struct {
+	mock *MockcountryResolver
+}
+
+// NewMockcountryResolver creates a new mock instance.
+func NewMockcountryResolver(ctrl *gomock.Controller) *MockcountryResolver {
+	mock := &MockcountryResolver{ctrl: ctrl}
+	mock.recorder = &MockcountryResolverMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockcountryResolver) EXPECT() *MockcountryResolverMockRecorder {
+	return m.recorder
+}
+
+// Country mocks base method.
+func (m *MockcountryResolver) Country(ip net.IP) string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Country", ip)
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// Country indicates an expected call of Country.
+func (mr *MockcountryResolverMockRecorder) Country(ip any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ShortURL", reflect.TypeOf((*MockshortenerService)(nil).ShortURL), ctx, url, userID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Country", reflect.TypeOf((*MockcountryResolver)(nil).Country), ip)
 }
@@ -0,0 +1,105 @@
+package http
+
+import (
+	"bytes"
+	"embed"
+	"html/template"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/MowlCoder/go-url-shortener/internal/domain"
+)
+
+// permanentRedirectMaxAge is max time, for which clients and proxies cache permanent redirect.
+// Clicks of cached redirect are not counted, so it is kept short.
+const permanentRedirectMaxAge = time.Hour * 24
+
+//go:embed templates/*.html
+var templatesFS embed.FS
+
+var (
+	previewTemplate = template.Must(template.ParseFS(templatesFS, "templates/preview.html"))
+	unfurlTemplate  = template.Must(template.ParseFS(templatesFS, "templates/unfurl.html"))
+)
+
+// previewPage is shown instead of redirect for urls with domain.RedirectPreview redirect type.
+type previewPage struct {
+	ShortURL    string
+	OriginalURL string
+}
+
+// unfurlPage describes short url without following it. OpenGraph metadata is added, when OpenGraph is set.
+type unfurlPage struct {
+	CreatedAt   *time.Time
+	ShortURL    string
+	OriginalURL string
+	Host        string
+	SiteName    string
+	Clicks      int
+	OpenGraph   bool
+}
+
+func renderPreviewPage(page previewPage) ([]byte, error) {
+	return renderTemplate(previewTemplate, page)
+}
+
+func renderUnfurlPage(page unfurlPage) ([]byte, error) {
+	return renderTemplate(unfurlTemplate, page)
+}
+
+func renderTemplate(tmpl *template.Template, data interface{}) ([]byte, error) {
+	var buf bytes.Buffer
+
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+// redirectStatusCode returns http status of redirect type, legacy links without redirect type use 307.
+func redirectStatusCode(redirectType string) int {
+	switch redirectType {
+	case domain.RedirectMovedPermanently:
+		return http.StatusMovedPermanently
+	case domain.RedirectFound:
+		return http.StatusFound
+	case domain.RedirectPermanent:
+		return http.StatusPermanentRedirect
+	default:
+		return http.StatusTemporaryRedirect
+	}
+}
+
+// redirectCacheControl returns Cache-Control header of url response. Permanent redirects are cached
+// publicly until url expires, but no longer than permanentRedirectMaxAge. Temporary redirects, preview page
+// and redirects of urls with redirect rules, destination of which depends on visitor, must be revalidated,
+// so every click reaches the server.
+func redirectCacheControl(shortenedURL *domain.ShortenedURL, now time.Time) string {
+	if len(shortenedURL.RedirectRules) > 0 {
+		return "private, no-cache"
+	}
+
+	if shortenedURL.RedirectType != domain.RedirectMovedPermanently && shortenedURL.RedirectType != domain.RedirectPermanent {
+		return "private, no-cache"
+	}
+
+	maxAge := permanentRedirectMaxAge
+	if shortenedURL.ExpiresAt != nil && shortenedURL.ExpiresAt.Sub(now) < maxAge {
+		maxAge = shortenedURL.ExpiresAt.Sub(now)
+	}
+
+	return "public, max-age=" + strconv.Itoa(int(maxAge.Seconds()))
+}
+
+// urlHost returns host of rawURL, or rawURL itself, when it has no host.
+func urlHost(rawURL string) string {
+	parsedURL, err := url.Parse(rawURL)
+	if err != nil || parsedURL.Host == "" {
+		return rawURL
+	}
+
+	return parsedURL.Host
+}
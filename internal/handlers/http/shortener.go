@@ -6,43 +6,60 @@ import (
 	"errors"
 	"fmt"
 	"io"
-	"log"
+	"net"
 	"net/http"
+	"time"
 
 	"github.com/go-chi/chi/v5"
+	"go.uber.org/zap"
 
 	"github.com/MowlCoder/go-url-shortener/internal/handlers/http/dtos"
 
+	"github.com/MowlCoder/go-url-shortener/internal/apierror"
 	"github.com/MowlCoder/go-url-shortener/internal/config"
 	contextUtil "github.com/MowlCoder/go-url-shortener/internal/context"
 	"github.com/MowlCoder/go-url-shortener/internal/domain"
+	"github.com/MowlCoder/go-url-shortener/internal/logger"
+	"github.com/MowlCoder/go-url-shortener/internal/visitor"
 	"github.com/MowlCoder/go-url-shortener/pkg/httputil"
 )
 
 type shortenerService interface {
-	ShortURL(ctx context.Context, url string, userID string) (*domain.ShortenedURL, error)
+	ShortURL(ctx context.Context, url string, userID string, options domain.ShortURLOptions) (*domain.ShortenedURL, error)
 	ShortBatchURL(ctx context.Context, urls []domain.ShortBatchURL, userID string) ([]domain.ShortBatchURL, error)
 	GetUserURLs(ctx context.Context, userID string) ([]domain.ShortenedURL, error)
-	DeleteURLs(ctx context.Context, urls []string, userID string) error
+	DeleteURLs(ctx context.Context, urls []string, userID string) (*domain.DeleteURLsTask, error)
+	GetDeleteTask(ctx context.Context, id string, userID string) (*domain.DeleteURLsTask, error)
+	GetRedirectRules(ctx context.Context, shortURL string, userID string) ([]domain.RedirectRule, error)
+	SetRedirectRules(ctx context.Context, shortURL string, userID string, rules []domain.RedirectRule) error
 	GetByShortURL(ctx context.Context, url string) (*domain.ShortenedURL, error)
+	RegisterClick(ctx context.Context, url *domain.ShortenedURL) error
 	GetInternalStats(ctx context.Context) (*domain.InternalStats, error)
 	Ping(ctx context.Context) error
 }
 
+type countryResolver interface {
+	Country(ip net.IP) string
+}
+
 // ShortenerHandler contains handlers that responsible for handling http request and give proper http response.
 type ShortenerHandler struct {
-	config  *config.AppConfig
-	service shortenerService
+	config    *config.AppConfig
+	service   shortenerService
+	countries countryResolver
 }
 
-// NewShortenerHandler is contructor function for ShortenerHandler.
+// NewShortenerHandler is contructor function for ShortenerHandler. Countries are used to match
+// redirect rules on country of visitor.
 func NewShortenerHandler(
 	config *config.AppConfig,
 	service shortenerService,
+	countries countryResolver,
 ) *ShortenerHandler {
 	return &ShortenerHandler{
-		config:  config,
-		service: service,
+		config:    config,
+		service:   service,
+		countries: countries,
 	}
 }
 
@@ -52,15 +69,15 @@ func NewShortenerHandler(
 // @Produce json
 // @Param dto body dtos.ShortURLDto true "Short url"
 // @Success 201 {object} dtos.ShortURLResponse
-// @Failure 400
-// @Failure 401
+// @Failure 400 {object} apierror.Response
+// @Failure 401 {object} apierror.Response
 // @Failure 409 {object} dtos.ShortURLResponse
-// @Failure 500
+// @Failure 500 {object} apierror.Response
 // @Router /api/shorten [post]
 func (h *ShortenerHandler) ShortURLJSON(w http.ResponseWriter, r *http.Request) {
 	userID, err := contextUtil.GetUserIDFromContext(r.Context())
 	if err != nil {
-		httputil.SendStatusCode(w, http.StatusUnauthorized)
+		apierror.Write(w, r, apierror.ErrUnauthorized)
 		return
 	}
 
@@ -68,21 +85,25 @@ func (h *ShortenerHandler) ShortURLJSON(w http.ResponseWriter, r *http.Request)
 	rawBody, err := io.ReadAll(r.Body)
 
 	if err != nil {
-		httputil.SendStatusCode(w, http.StatusBadRequest)
+		apierror.Write(w, r, apierror.InvalidRequest(err))
 		return
 	}
 
 	if jsonErr := json.Unmarshal(rawBody, &requestBody); jsonErr != nil {
-		httputil.SendStatusCode(w, http.StatusBadRequest)
+		apierror.Write(w, r, apierror.InvalidRequest(jsonErr))
 		return
 	}
 
 	if requestBody.URL == "" {
-		httputil.SendStatusCode(w, http.StatusBadRequest)
+		apierror.Write(w, r, apierror.Validation(apierror.FieldError{Field: "url", Message: "must not be empty"}))
 		return
 	}
 
-	shortenedURL, err := h.service.ShortURL(r.Context(), requestBody.URL, userID)
+	shortenedURL, err := h.service.ShortURL(r.Context(), requestBody.URL, userID, domain.ShortURLOptions{
+		RedirectType:     requestBody.RedirectType,
+		UTM:              makeUTMParams(requestBody.UTM),
+		QueryPassthrough: requestBody.QueryPassthrough,
+	})
 
 	if errors.Is(err, domain.ErrURLConflict) {
 		httputil.SendJSONResponse(w, http.StatusConflict, dtos.ShortURLResponse{
@@ -92,7 +113,7 @@ func (h *ShortenerHandler) ShortURLJSON(w http.ResponseWriter, r *http.Request)
 	}
 
 	if err != nil {
-		httputil.SendStatusCode(w, http.StatusInternalServerError)
+		apierror.Write(w, r, err)
 		return
 	}
 
@@ -107,14 +128,14 @@ func (h *ShortenerHandler) ShortURLJSON(w http.ResponseWriter, r *http.Request)
 // @Produce json
 // @Param dto body []dtos.ShortBatchURLDto true "Short batch urls"
 // @Success 201 {array} dtos.ShortBatchURLResponse
-// @Failure 400
-// @Failure 401
-// @Failure 500
+// @Failure 400 {object} apierror.Response
+// @Failure 401 {object} apierror.Response
+// @Failure 500 {object} apierror.Response
 // @Router /api/shorten/batch [post]
 func (h *ShortenerHandler) ShortBatchURL(w http.ResponseWriter, r *http.Request) {
 	userID, err := contextUtil.GetUserIDFromContext(r.Context())
 	if err != nil {
-		httputil.SendStatusCode(w, http.StatusUnauthorized)
+		apierror.Write(w, r, apierror.ErrUnauthorized)
 		return
 	}
 
@@ -122,17 +143,17 @@ func (h *ShortenerHandler) ShortBatchURL(w http.ResponseWriter, r *http.Request)
 	rawBody, err := io.ReadAll(r.Body)
 
 	if err != nil {
-		httputil.SendStatusCode(w, http.StatusBadRequest)
+		apierror.Write(w, r, apierror.InvalidRequest(err))
 		return
 	}
 
 	if jsonErr := json.Unmarshal(rawBody, &requestBody); jsonErr != nil {
-		httputil.SendStatusCode(w, http.StatusBadRequest)
+		apierror.Write(w, r, apierror.InvalidRequest(jsonErr))
 		return
 	}
 
 	if len(requestBody) == 0 {
-		httputil.SendStatusCode(w, http.StatusBadRequest)
+		apierror.Write(w, r, apierror.New(http.StatusBadRequest, apierror.CodeValidationFailed, "at least one url is required"))
 		return
 	}
 
@@ -140,16 +161,18 @@ func (h *ShortenerHandler) ShortBatchURL(w http.ResponseWriter, r *http.Request)
 
 	for _, url := range requestBody {
 		urls = append(urls, domain.ShortBatchURL{
-			OriginalURL:   url.OriginalURL,
-			CorrelationID: url.CorrelationID,
+			OriginalURL:      url.OriginalURL,
+			CorrelationID:    url.CorrelationID,
+			RedirectType:     url.RedirectType,
+			UTM:              makeUTMParams(url.UTM),
+			QueryPassthrough: url.QueryPassthrough,
 		})
 	}
 
 	shortenedURLs, err := h.service.ShortBatchURL(r.Context(), urls, userID)
 
 	if err != nil {
-		log.Println(err)
-		httputil.SendStatusCode(w, http.StatusInternalServerError)
+		apierror.Write(w, r, err)
 		return
 	}
 
@@ -171,31 +194,31 @@ func (h *ShortenerHandler) ShortBatchURL(w http.ResponseWriter, r *http.Request)
 // @Produce plain
 // @Param dto body string true "Short url"
 // @Success 201 {string} string "Shortened url"
-// @Failure 400
-// @Failure 401
+// @Failure 400 {object} apierror.Response
+// @Failure 401 {object} apierror.Response
 // @Failure 409 {string} string "Shortened url"
-// @Failure 500
+// @Failure 500 {object} apierror.Response
 // @Router / [post]
 func (h *ShortenerHandler) ShortURL(w http.ResponseWriter, r *http.Request) {
 	userID, err := contextUtil.GetUserIDFromContext(r.Context())
 	if err != nil {
-		httputil.SendStatusCode(w, http.StatusUnauthorized)
+		apierror.Write(w, r, apierror.ErrUnauthorized)
 		return
 	}
 
 	body, err := io.ReadAll(r.Body)
 
 	if err != nil {
-		httputil.SendStatusCode(w, http.StatusBadRequest)
+		apierror.Write(w, r, apierror.InvalidRequest(err))
 		return
 	}
 
 	if len(body) == 0 {
-		httputil.SendStatusCode(w, http.StatusBadRequest)
+		apierror.Write(w, r, apierror.Validation(apierror.FieldError{Field: "url", Message: "must not be empty"}))
 		return
 	}
 
-	shortenedURL, err := h.service.ShortURL(r.Context(), string(body), userID)
+	shortenedURL, err := h.service.ShortURL(r.Context(), string(body), userID, domain.ShortURLOptions{})
 
 	if errors.Is(err, domain.ErrURLConflict) {
 		httputil.SendTextResponse(w, http.StatusConflict, fmt.Sprintf("%s/%s", h.config.BaseShortURLAddr, shortenedURL.ShortURL))
@@ -203,7 +226,7 @@ func (h *ShortenerHandler) ShortURL(w http.ResponseWriter, r *http.Request) {
 	}
 
 	if err != nil {
-		httputil.SendStatusCode(w, http.StatusInternalServerError)
+		apierror.Write(w, r, err)
 		return
 	}
 
@@ -215,20 +238,20 @@ func (h *ShortenerHandler) ShortURL(w http.ResponseWriter, r *http.Request) {
 // @Produce json
 // @Success 200 {array} dtos.UserURLsResponse
 // @Success 204
-// @Failure 401
-// @Failure 500
+// @Failure 401 {object} apierror.Response
+// @Failure 500 {object} apierror.Response
 // @Router /api/user/urls [get]
 func (h *ShortenerHandler) GetMyURLs(w http.ResponseWriter, r *http.Request) {
 	userID, err := contextUtil.GetUserIDFromContext(r.Context())
 	if err != nil {
-		httputil.SendStatusCode(w, http.StatusUnauthorized)
+		apierror.Write(w, r, apierror.ErrUnauthorized)
 		return
 	}
 
 	urls, err := h.service.GetUserURLs(r.Context(), userID)
 
 	if err != nil {
-		httputil.SendStatusCode(w, http.StatusInternalServerError)
+		apierror.Write(w, r, err)
 		return
 	}
 
@@ -241,8 +264,9 @@ func (h *ShortenerHandler) GetMyURLs(w http.ResponseWriter, r *http.Request) {
 
 	for _, url := range urls {
 		responseURLs = append(responseURLs, dtos.UserURLsResponse{
-			ShortURL:    fmt.Sprintf("%s/%s", h.config.BaseShortURLAddr, url.ShortURL),
-			OriginalURL: url.OriginalURL,
+			ShortURL:     fmt.Sprintf("%s/%s", h.config.BaseShortURLAddr, url.ShortURL),
+			OriginalURL:  url.OriginalURL,
+			RedirectType: url.RedirectType,
 		})
 	}
 
@@ -251,16 +275,19 @@ func (h *ShortenerHandler) GetMyURLs(w http.ResponseWriter, r *http.Request) {
 
 // DeleteURLs godoc
 // @Summary Delete user urls
+// @Description Deletion is done in background. Status of deletion can be polled by url from Location header.
 // @Accept json
+// @Produce json
 // @Param dto body dtos.DeleteURLsRequest true "Delete user urls"
-// @Success 202
-// @Failure 400
-// @Failure 401
+// @Success 202 {object} dtos.DeleteTaskResponse
+// @Failure 400 {object} apierror.Response
+// @Failure 401 {object} apierror.Response
+// @Failure 500 {object} apierror.Response
 // @Router /api/user/urls [delete]
 func (h *ShortenerHandler) DeleteURLs(w http.ResponseWriter, r *http.Request) {
 	userID, err := contextUtil.GetUserIDFromContext(r.Context())
 	if err != nil {
-		httputil.SendStatusCode(w, http.StatusUnauthorized)
+		apierror.Write(w, r, apierror.ErrUnauthorized)
 		return
 	}
 
@@ -268,59 +295,267 @@ func (h *ShortenerHandler) DeleteURLs(w http.ResponseWriter, r *http.Request) {
 	rawBody, err := io.ReadAll(r.Body)
 
 	if err != nil {
-		httputil.SendStatusCode(w, http.StatusBadRequest)
+		apierror.Write(w, r, apierror.InvalidRequest(err))
 		return
 	}
 
 	if err := json.Unmarshal(rawBody, &requestBody); err != nil {
-		httputil.SendStatusCode(w, http.StatusBadRequest)
+		apierror.Write(w, r, apierror.InvalidRequest(err))
 		return
 	}
 
 	if len(requestBody) == 0 {
-		httputil.SendStatusCode(w, http.StatusBadRequest)
+		apierror.Write(w, r, apierror.New(http.StatusBadRequest, apierror.CodeValidationFailed, "at least one url is required"))
+		return
+	}
+
+	task, err := h.service.DeleteURLs(r.Context(), requestBody, userID)
+
+	if err != nil {
+		apierror.Write(w, r, err)
+		return
+	}
+
+	w.Header().Set("Location", "/api/user/urls/deletions/"+task.ID)
+	httputil.SendJSONResponse(w, http.StatusAccepted, makeDeleteTaskResponse(task))
+}
+
+// GetDeleteTask godoc
+// @Summary Get status of user urls deletion
+// @Description Task is pending until it is done. Task, that failed 20 times, gets failed status and is not retried.
+// @Produce json
+// @Param id path string true "Delete task ID"
+// @Success 200 {object} dtos.DeleteTaskResponse
+// @Failure 401 {object} apierror.Response
+// @Failure 404 {object} apierror.Response
+// @Failure 500 {object} apierror.Response
+// @Router /api/user/urls/deletions/{id} [get]
+func (h *ShortenerHandler) GetDeleteTask(w http.ResponseWriter, r *http.Request) {
+	userID, err := contextUtil.GetUserIDFromContext(r.Context())
+	if err != nil {
+		apierror.Write(w, r, apierror.ErrUnauthorized)
 		return
 	}
 
-	h.service.DeleteURLs(r.Context(), requestBody, userID)
+	task, err := h.service.GetDeleteTask(r.Context(), chi.URLParam(r, "id"), userID)
+
+	if err != nil {
+		apierror.Write(w, r, err)
+		return
+	}
 
-	httputil.SendStatusCode(w, http.StatusAccepted)
+	httputil.SendJSONResponse(w, http.StatusOK, makeDeleteTaskResponse(task))
+}
+
+// GetRedirectRules godoc
+// @Summary Get redirect rules of user url
+// @Produce json
+// @Param id path string true "Short URL ID"
+// @Success 200 {object} dtos.RedirectRulesResponse
+// @Failure 401 {object} apierror.Response
+// @Failure 404 {object} apierror.Response
+// @Failure 500 {object} apierror.Response
+// @Router /api/user/urls/{id}/rules [get]
+func (h *ShortenerHandler) GetRedirectRules(w http.ResponseWriter, r *http.Request) {
+	userID, err := contextUtil.GetUserIDFromContext(r.Context())
+	if err != nil {
+		apierror.Write(w, r, apierror.ErrUnauthorized)
+		return
+	}
+
+	rules, err := h.service.GetRedirectRules(r.Context(), chi.URLParam(r, "id"), userID)
+	if err != nil {
+		apierror.Write(w, r, err)
+		return
+	}
+
+	httputil.SendJSONResponse(w, http.StatusOK, makeRedirectRulesResponse(rules))
+}
+
+// SetRedirectRules godoc
+// @Summary Replace redirect rules of user url
+// @Description Rules are evaluated in order on redirect, visitor is sent to destination of the first matching rule,
+// @Description or to original url, when no rule matches. Rule matches, when visitor matches all its conditions.
+// @Description Empty list of rules removes all rules.
+// @Accept json
+// @Produce json
+// @Param id path string true "Short URL ID"
+// @Param dto body dtos.RedirectRulesRequest true "Redirect rules"
+// @Success 200 {object} dtos.RedirectRulesResponse
+// @Failure 400 {object} apierror.Response
+// @Failure 401 {object} apierror.Response
+// @Failure 404 {object} apierror.Response
+// @Failure 500 {object} apierror.Response
+// @Router /api/user/urls/{id}/rules [put]
+func (h *ShortenerHandler) SetRedirectRules(w http.ResponseWriter, r *http.Request) {
+	userID, err := contextUtil.GetUserIDFromContext(r.Context())
+	if err != nil {
+		apierror.Write(w, r, apierror.ErrUnauthorized)
+		return
+	}
+
+	requestBody := dtos.RedirectRulesRequest{}
+	rawBody, err := io.ReadAll(r.Body)
+
+	if err != nil {
+		apierror.Write(w, r, apierror.InvalidRequest(err))
+		return
+	}
+
+	if err := json.Unmarshal(rawBody, &requestBody); err != nil {
+		apierror.Write(w, r, apierror.InvalidRequest(err))
+		return
+	}
+
+	rules := make([]domain.RedirectRule, 0, len(requestBody.Rules))
+	for _, rule := range requestBody.Rules {
+		rules = append(rules, domain.RedirectRule{
+			Destination: rule.Destination,
+			Devices:     rule.Devices,
+			Languages:   rule.Languages,
+			Countries:   rule.Countries,
+		})
+	}
+
+	if err := h.service.SetRedirectRules(r.Context(), chi.URLParam(r, "id"), userID, rules); err != nil {
+		apierror.Write(w, r, err)
+		return
+	}
+
+	httputil.SendJSONResponse(w, http.StatusOK, makeRedirectRulesResponse(rules))
 }
 
 // RedirectToURLByID godoc
 // @Summary Redirect from short url to original url
+// @Description Status of redirect is chosen by redirect type of url, links without redirect type use 307.
+// @Description Permanent redirects are cached publicly, temporary redirects and preview page must be revalidated.
+// @Description Urls with redirect rules are sent to destination of the first rule matching User-Agent,
+// @Description Accept-Language and country of visitor, such redirects are never cached publicly.
+// @Description UTM parameters of url are added to original url, query of request too, when url has query passthrough.
+// @Description Parameters of original url are never overridden, UTM parameters win over query of request.
+// @Produce html
 // @Param id path string true "Short URL ID"
+// @Success 200 {string} string "Preview page with link to original url"
+// @Success 301
+// @Success 302
 // @Success 307
-// @Failure 400
-// @Failure 410
+// @Success 308
+// @Failure 400 {object} apierror.Response
+// @Failure 410 {object} apierror.Response
+// @Failure 500 {object} apierror.Response
 // @Router /{id} [get]
 func (h *ShortenerHandler) RedirectToURLByID(w http.ResponseWriter, r *http.Request) {
 	id := chi.URLParam(r, "id")
 	originalURL, err := h.service.GetByShortURL(r.Context(), id)
 
+	if errors.Is(err, domain.ErrURLNotFound) {
+		// status of missing short url is kept from the first version of api
+		apierror.Write(w, r, apierror.FromError(err).WithStatus(http.StatusBadRequest))
+		return
+	}
+
 	if err != nil {
-		httputil.SendStatusCode(w, http.StatusBadRequest)
+		apierror.Write(w, r, err)
 		return
 	}
 
 	if originalURL.IsDeleted {
-		httputil.SendStatusCode(w, http.StatusGone)
+		apierror.Write(w, r, domain.ErrURLDeleted)
+		return
+	}
+
+	if originalURL.IsExpired(time.Now()) {
+		apierror.Write(w, r, apierror.New(http.StatusGone, apierror.CodeGone, "url is expired"))
+		return
+	}
+
+	if err := h.service.RegisterClick(r.Context(), originalURL); err != nil {
+		logger.FromContext(r.Context()).Warn("register click", zap.String("short_url", originalURL.ShortURL), zap.Error(err))
+	}
+
+	destination := domain.Destination(originalURL, h.makeVisitor(r, originalURL))
+
+	w.Header().Set("Cache-Control", redirectCacheControl(originalURL, time.Now()))
+	if len(originalURL.RedirectRules) > 0 {
+		w.Header().Add("Vary", "User-Agent")
+		w.Header().Add("Vary", "Accept-Language")
+	}
+
+	if originalURL.RedirectType == domain.RedirectPreview {
+		page, err := renderPreviewPage(previewPage{
+			ShortURL:    fmt.Sprintf("%s/%s", h.config.BaseShortURLAddr, originalURL.ShortURL),
+			OriginalURL: destination,
+		})
+		if err != nil {
+			apierror.Write(w, r, err)
+			return
+		}
+
+		httputil.SendHTMLResponse(w, http.StatusOK, page)
+		return
+	}
+
+	httputil.SendRedirectResponseWithCode(w, redirectStatusCode(originalURL.RedirectType), destination)
+}
+
+// UnfurlURLByID godoc
+// @Summary Show page describing short url without following it
+// @Description Page contains original url, creation date and count of clicks. OpenGraph metadata of short url
+// @Description is added, when it is enabled in configuration. Viewing page is not counted as click.
+// @Produce html
+// @Param id path string true "Short URL ID"
+// @Success 200 {string} string "Page describing short url"
+// @Failure 404 {object} apierror.Response
+// @Failure 410 {object} apierror.Response
+// @Failure 500 {object} apierror.Response
+// @Router /{id}+ [get]
+// @Router /{id}/preview [get]
+func (h *ShortenerHandler) UnfurlURLByID(w http.ResponseWriter, r *http.Request) {
+	shortenedURL, err := h.service.GetByShortURL(r.Context(), chi.URLParam(r, "id"))
+	if err != nil {
+		apierror.Write(w, r, err)
+		return
+	}
+
+	if shortenedURL.IsDeleted {
+		apierror.Write(w, r, domain.ErrURLDeleted)
+		return
+	}
+
+	if shortenedURL.IsExpired(time.Now()) {
+		apierror.Write(w, r, apierror.New(http.StatusGone, apierror.CodeGone, "url is expired"))
+		return
+	}
+
+	page, err := renderUnfurlPage(unfurlPage{
+		ShortURL:    fmt.Sprintf("%s/%s", h.config.BaseShortURLAddr, shortenedURL.ShortURL),
+		OriginalURL: domain.Destination(shortenedURL, domain.Visitor{}),
+		Host:        urlHost(shortenedURL.OriginalURL),
+		SiteName:    urlHost(h.config.BaseShortURLAddr),
+		CreatedAt:   shortenedURL.CreatedAt,
+		Clicks:      shortenedURL.Clicks,
+		OpenGraph:   h.config.PreviewOpenGraph,
+	})
+	if err != nil {
+		apierror.Write(w, r, err)
 		return
 	}
 
-	httputil.SendRedirectResponse(w, originalURL.OriginalURL)
+	w.Header().Set("Cache-Control", "no-cache")
+	httputil.SendHTMLResponse(w, http.StatusOK, page)
 }
 
 // GetStats godoc
 // @Summary Get internal statistics for metrics
 // @Success 200 {object} dtos.GetStatsResponse
-// @Failure 403
-// @Failure 500
+// @Failure 403 {object} apierror.Response
+// @Failure 500 {object} apierror.Response
 // @Router /api/internal/stats [get]
 func (h *ShortenerHandler) GetStats(w http.ResponseWriter, r *http.Request) {
 	stats, err := h.service.GetInternalStats(r.Context())
 	if err != nil {
-		httputil.SendStatusCode(w, http.StatusInternalServerError)
+		apierror.Write(w, r, err)
+		return
 	}
 
 	httputil.SendJSONResponse(w, 200, dtos.GetStatsResponse{
@@ -332,13 +567,73 @@ func (h *ShortenerHandler) GetStats(w http.ResponseWriter, r *http.Request) {
 // Ping godoc
 // @Summary Checking if server isn't down
 // @Success 200
-// @Failure 500
+// @Failure 500 {object} apierror.Response
 // @Router /ping [get]
 func (h *ShortenerHandler) Ping(w http.ResponseWriter, r *http.Request) {
 	if err := h.service.Ping(r.Context()); err != nil {
-		httputil.SendStatusCode(w, http.StatusInternalServerError)
+		apierror.Write(w, r, err)
 		return
 	}
 
 	httputil.SendStatusCode(w, http.StatusOK)
 }
+
+func makeUTMParams(params *dtos.UTMParams) *domain.UTMParams {
+	if params == nil {
+		return nil
+	}
+
+	return &domain.UTMParams{
+		Source:   params.Source,
+		Medium:   params.Medium,
+		Campaign: params.Campaign,
+		Term:     params.Term,
+		Content:  params.Content,
+	}
+}
+
+// makeVisitor describes visitor of url by request. Country is resolved only for urls with redirect rules,
+// so lookup in database of countries is skipped for most of redirects.
+func (h *ShortenerHandler) makeVisitor(r *http.Request, shortenedURL *domain.ShortenedURL) domain.Visitor {
+	result := domain.Visitor{
+		Query: r.URL.Query(),
+	}
+
+	if len(shortenedURL.RedirectRules) == 0 {
+		return result
+	}
+
+	result.Device = visitor.Device(r.UserAgent())
+	result.Language = visitor.Language(r.Header.Get("Accept-Language"))
+	result.Country = h.countries.Country(contextUtil.GetClientIPFromContext(r.Context()))
+
+	return result
+}
+
+func makeRedirectRulesResponse(rules []domain.RedirectRule) dtos.RedirectRulesResponse {
+	response := dtos.RedirectRulesResponse{
+		Rules: make([]dtos.RedirectRuleDto, 0, len(rules)),
+	}
+
+	for _, rule := range rules {
+		response.Rules = append(response.Rules, dtos.RedirectRuleDto{
+			Destination: rule.Destination,
+			Devices:     rule.Devices,
+			Languages:   rule.Languages,
+			Countries:   rule.Countries,
+		})
+	}
+
+	return response
+}
+
+func makeDeleteTaskResponse(task *domain.DeleteURLsTask) dtos.DeleteTaskResponse {
+	return dtos.DeleteTaskResponse{
+		ID:          task.ID,
+		Status:      task.Status,
+		ShortURLs:   task.ShortURLs,
+		Attempts:    task.Attempts,
+		CreatedAt:   task.CreatedAt,
+		CompletedAt: task.CompletedAt,
+	}
+}
@@ -10,6 +10,7 @@ import (
 	"net/http/httptest"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/go-chi/chi/v5"
 	"go.uber.org/mock/gomock"
@@ -34,6 +35,7 @@ func TestShortURL(t *testing.T) {
 	handler := NewShortenerHandler(
 		&config.AppConfig{},
 		service,
+		nil,
 	)
 
 	type TestCase struct {
@@ -54,7 +56,7 @@ func TestShortURL(t *testing.T) {
 			PrepareServiceFunc: func(ctx context.Context, body string) {
 				service.
 					EXPECT().
-					ShortURL(ctx, body, "1").
+					ShortURL(ctx, body, "1", domain.ShortURLOptions{}).
 					Return(&domain.ShortenedURL{}, nil)
 			},
 			ExpectedStatusCode: http.StatusCreated,
@@ -78,7 +80,7 @@ func TestShortURL(t *testing.T) {
 			PrepareServiceFunc: func(ctx context.Context, body string) {
 				service.
 					EXPECT().
-					ShortURL(ctx, body, "1").
+					ShortURL(ctx, body, "1", domain.ShortURLOptions{}).
 					Return(&domain.ShortenedURL{}, domain.ErrURLConflict)
 			},
 			ExpectedStatusCode: http.StatusConflict,
@@ -89,7 +91,7 @@ func TestShortURL(t *testing.T) {
 			PrepareServiceFunc: func(ctx context.Context, body string) {
 				service.
 					EXPECT().
-					ShortURL(ctx, body, "1").
+					ShortURL(ctx, body, "1", domain.ShortURLOptions{}).
 					Return(nil, errors.New("undefined behaviour"))
 			},
 			ExpectedStatusCode: http.StatusInternalServerError,
@@ -129,6 +131,7 @@ func TestShortURLJSON(t *testing.T) {
 	handler := NewShortenerHandler(
 		&config.AppConfig{},
 		service,
+		nil,
 	)
 
 	type TestCase struct {
@@ -151,7 +154,7 @@ func TestShortURLJSON(t *testing.T) {
 			PrepareServiceFunc: func(ctx context.Context, body *dtos.ShortURLDto) {
 				service.
 					EXPECT().
-					ShortURL(ctx, body.URL, "1").
+					ShortURL(ctx, body.URL, "1", domain.ShortURLOptions{RedirectType: body.RedirectType}).
 					Return(&domain.ShortenedURL{}, nil)
 			},
 			ExpectedStatusCode: http.StatusCreated,
@@ -183,11 +186,25 @@ func TestShortURLJSON(t *testing.T) {
 			PrepareServiceFunc: func(ctx context.Context, body *dtos.ShortURLDto) {
 				service.
 					EXPECT().
-					ShortURL(ctx, body.URL, "1").
+					ShortURL(ctx, body.URL, "1", domain.ShortURLOptions{RedirectType: body.RedirectType}).
 					Return(&domain.ShortenedURL{}, domain.ErrURLConflict)
 			},
 			ExpectedStatusCode: http.StatusConflict,
 		},
+		{
+			Name: "invalid redirect type",
+			Body: &dtos.ShortURLDto{
+				URL:          "https://url.com",
+				RedirectType: "303",
+			},
+			PrepareServiceFunc: func(ctx context.Context, body *dtos.ShortURLDto) {
+				service.
+					EXPECT().
+					ShortURL(ctx, body.URL, "1", domain.ShortURLOptions{RedirectType: body.RedirectType}).
+					Return(nil, domain.ErrInvalidRedirectType)
+			},
+			ExpectedStatusCode: http.StatusBadRequest,
+		},
 		{
 			Name: "internal server error",
 			Body: &dtos.ShortURLDto{
@@ -196,7 +213,7 @@ func TestShortURLJSON(t *testing.T) {
 			PrepareServiceFunc: func(ctx context.Context, body *dtos.ShortURLDto) {
 				service.
 					EXPECT().
-					ShortURL(ctx, body.URL, "1").
+					ShortURL(ctx, body.URL, "1", domain.ShortURLOptions{RedirectType: body.RedirectType}).
 					Return(nil, errors.New("undefined behaviour"))
 			},
 			ExpectedStatusCode: http.StatusInternalServerError,
@@ -244,6 +261,7 @@ func TestShortBatchURL(t *testing.T) {
 	handler := NewShortenerHandler(
 		&config.AppConfig{},
 		service,
+		nil,
 	)
 
 	type TestCase struct {
@@ -401,6 +419,7 @@ func TestGetMyURLs(t *testing.T) {
 	handler := NewShortenerHandler(
 		&config.AppConfig{},
 		service,
+		nil,
 	)
 
 	type TestCase struct {
@@ -484,6 +503,7 @@ func TestDeleteURLs(t *testing.T) {
 	handler := NewShortenerHandler(
 		&config.AppConfig{},
 		service,
+		nil,
 	)
 
 	type TestCase struct {
@@ -503,10 +523,22 @@ func TestDeleteURLs(t *testing.T) {
 			PrepareServiceFunc: func(ctx context.Context) {
 				service.
 					EXPECT().
-					DeleteURLs(ctx, gomock.Any(), "1")
+					DeleteURLs(ctx, gomock.Any(), "1").
+					Return(&domain.DeleteURLsTask{ID: "task", Status: domain.DeleteTaskPending}, nil)
 			},
 			ExpectedStatusCode: http.StatusAccepted,
 		},
+		{
+			Name: "internal server error",
+			Body: dtos.DeleteURLsRequest{"123", "1234"},
+			PrepareServiceFunc: func(ctx context.Context) {
+				service.
+					EXPECT().
+					DeleteURLs(ctx, gomock.Any(), "1").
+					Return(nil, errors.New("undefined behavior"))
+			},
+			ExpectedStatusCode: http.StatusInternalServerError,
+		},
 		{
 			Name:               "nil body",
 			Body:               nil,
@@ -557,6 +589,225 @@ func TestDeleteURLs(t *testing.T) {
 			defer res.Body.Close()
 
 			assert.Equal(t, testCase.ExpectedStatusCode, res.StatusCode)
+
+			if res.StatusCode == http.StatusAccepted {
+				assert.Equal(t, "/api/user/urls/deletions/task", res.Header.Get("Location"))
+			}
+		})
+	}
+}
+
+func TestGetDeleteTask(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	service := handlersmock.NewMockshortenerService(ctrl)
+
+	handler := NewShortenerHandler(
+		&config.AppConfig{},
+		service,
+		nil,
+	)
+
+	type TestCase struct {
+		ServiceTask        *domain.DeleteURLsTask
+		ServiceErr         error
+		Name               string
+		ExpectedStatusCode int
+	}
+
+	testCases := []TestCase{
+		{
+			Name:               "valid",
+			ServiceTask:        &domain.DeleteURLsTask{ID: "task", Status: domain.DeleteTaskCompleted},
+			ExpectedStatusCode: http.StatusOK,
+		},
+		{
+			Name:               "not found",
+			ServiceErr:         domain.ErrDeleteTaskNotFound,
+			ExpectedStatusCode: http.StatusNotFound,
+		},
+		{
+			Name:               "internal server error",
+			ServiceErr:         errors.New("undefined behavior"),
+			ExpectedStatusCode: http.StatusInternalServerError,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			r := httptest.NewRequest(http.MethodGet, "/api/user/urls/deletions/task", nil)
+			rctx := chi.NewRouteContext()
+			rctx.URLParams.Add("id", "task")
+			ctx := context.WithValue(r.Context(), chi.RouteCtxKey, rctx)
+			r = r.WithContext(contextUtil.SetUserIDToContext(ctx, "1"))
+			w := httptest.NewRecorder()
+
+			service.
+				EXPECT().
+				GetDeleteTask(r.Context(), "task", "1").
+				Return(testCase.ServiceTask, testCase.ServiceErr)
+
+			handler.GetDeleteTask(w, r)
+
+			res := w.Result()
+			defer res.Body.Close()
+
+			assert.Equal(t, testCase.ExpectedStatusCode, res.StatusCode)
+
+			if res.StatusCode == http.StatusOK {
+				var responseBody dtos.DeleteTaskResponse
+				require.NoError(t, json.NewDecoder(res.Body).Decode(&responseBody))
+				assert.Equal(t, domain.DeleteTaskCompleted, responseBody.Status)
+			}
+		})
+	}
+}
+
+func TestGetRedirectRules(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	service := handlersmock.NewMockshortenerService(ctrl)
+
+	handler := NewShortenerHandler(
+		&config.AppConfig{},
+		service,
+		nil,
+	)
+
+	type TestCase struct {
+		ServiceErr         error
+		Name               string
+		ServiceRules       []domain.RedirectRule
+		ExpectedStatusCode int
+	}
+
+	testCases := []TestCase{
+		{
+			Name:               "valid",
+			ServiceRules:       []domain.RedirectRule{{Destination: "https://url.de", Countries: []string{"DE"}}},
+			ExpectedStatusCode: http.StatusOK,
+		},
+		{
+			Name:               "not found",
+			ServiceErr:         domain.ErrURLNotFound,
+			ExpectedStatusCode: http.StatusNotFound,
+		},
+		{
+			Name:               "internal server error",
+			ServiceErr:         errors.New("undefined behavior"),
+			ExpectedStatusCode: http.StatusInternalServerError,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			r := httptest.NewRequest(http.MethodGet, "/api/user/urls/abc/rules", nil)
+			rctx := chi.NewRouteContext()
+			rctx.URLParams.Add("id", "abc")
+			ctx := context.WithValue(r.Context(), chi.RouteCtxKey, rctx)
+			r = r.WithContext(contextUtil.SetUserIDToContext(ctx, "1"))
+			w := httptest.NewRecorder()
+
+			service.
+				EXPECT().
+				GetRedirectRules(r.Context(), "abc", "1").
+				Return(testCase.ServiceRules, testCase.ServiceErr)
+
+			handler.GetRedirectRules(w, r)
+
+			res := w.Result()
+			defer res.Body.Close()
+
+			assert.Equal(t, testCase.ExpectedStatusCode, res.StatusCode)
+
+			if res.StatusCode == http.StatusOK {
+				var responseBody dtos.RedirectRulesResponse
+				require.NoError(t, json.NewDecoder(res.Body).Decode(&responseBody))
+				require.Len(t, responseBody.Rules, 1)
+				assert.Equal(t, "https://url.de", responseBody.Rules[0].Destination)
+				assert.Equal(t, []string{"DE"}, responseBody.Rules[0].Countries)
+			}
+		})
+	}
+}
+
+func TestSetRedirectRules(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	service := handlersmock.NewMockshortenerService(ctrl)
+
+	handler := NewShortenerHandler(
+		&config.AppConfig{},
+		service,
+		nil,
+	)
+
+	type TestCase struct {
+		PrepareServiceFunc func(ctx context.Context)
+		Name               string
+		Body               string
+		ExpectedStatusCode int
+	}
+
+	testCases := []TestCase{
+		{
+			Name: "valid",
+			Body: `{"rules":[{"destination":"https://url.de","languages":["de"],"devices":["android"]}]}`,
+			PrepareServiceFunc: func(ctx context.Context) {
+				service.
+					EXPECT().
+					SetRedirectRules(ctx, "abc", "1", []domain.RedirectRule{
+						{Destination: "https://url.de", Languages: []string{"de"}, Devices: []string{domain.DeviceAndroid}},
+					}).
+					Return(nil)
+			},
+			ExpectedStatusCode: http.StatusOK,
+		},
+		{
+			Name: "invalid rules",
+			Body: `{"rules":[{"destination":"https://url.de"}]}`,
+			PrepareServiceFunc: func(ctx context.Context) {
+				service.
+					EXPECT().
+					SetRedirectRules(ctx, "abc", "1", gomock.Any()).
+					Return(domain.ErrInvalidRedirectRules)
+			},
+			ExpectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			Name: "not found",
+			Body: `{"rules":[]}`,
+			PrepareServiceFunc: func(ctx context.Context) {
+				service.
+					EXPECT().
+					SetRedirectRules(ctx, "abc", "1", []domain.RedirectRule{}).
+					Return(domain.ErrURLNotFound)
+			},
+			ExpectedStatusCode: http.StatusNotFound,
+		},
+		{
+			Name:               "invalid json",
+			Body:               `{"rules":`,
+			ExpectedStatusCode: http.StatusBadRequest,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			r := httptest.NewRequest(http.MethodPut, "/api/user/urls/abc/rules", strings.NewReader(testCase.Body))
+			rctx := chi.NewRouteContext()
+			rctx.URLParams.Add("id", "abc")
+			ctx := context.WithValue(r.Context(), chi.RouteCtxKey, rctx)
+			r = r.WithContext(contextUtil.SetUserIDToContext(ctx, "1"))
+			w := httptest.NewRecorder()
+
+			if testCase.PrepareServiceFunc != nil {
+				testCase.PrepareServiceFunc(r.Context())
+			}
+
+			handler.SetRedirectRules(w, r)
+
+			res := w.Result()
+			defer res.Body.Close()
+
+			assert.Equal(t, testCase.ExpectedStatusCode, res.StatusCode)
 		})
 	}
 }
@@ -564,10 +815,12 @@ func TestDeleteURLs(t *testing.T) {
 func TestRedirectToURLByID(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	service := handlersmock.NewMockshortenerService(ctrl)
+	countries := handlersmock.NewMockcountryResolver(ctrl)
 
 	handler := NewShortenerHandler(
 		&config.AppConfig{},
 		service,
+		countries,
 	)
 
 	type TestCase struct {
@@ -575,9 +828,14 @@ func TestRedirectToURLByID(t *testing.T) {
 			ctx context.Context,
 			body string,
 		)
-		Name               string
-		Body               string
-		ExpectedStatusCode int
+		Headers              map[string]string
+		Name                 string
+		Body                 string
+		Query                string
+		ExpectedLocation     string
+		ExpectedCacheControl string
+		ExpectedVary         []string
+		ExpectedStatusCode   int
 	}
 
 	testCases := []TestCase{
@@ -585,24 +843,183 @@ func TestRedirectToURLByID(t *testing.T) {
 			Name: "valid",
 			Body: "1234",
 			PrepareServiceFunc: func(ctx context.Context, body string) {
+				shortenedURL := &domain.ShortenedURL{}
+
 				service.
 					EXPECT().
 					GetByShortURL(ctx, body).
-					Return(&domain.ShortenedURL{}, nil)
+					Return(shortenedURL, nil)
+
+				service.
+					EXPECT().
+					RegisterClick(ctx, shortenedURL).
+					Return(nil)
+			},
+			ExpectedStatusCode:   http.StatusTemporaryRedirect,
+			ExpectedCacheControl: "private, no-cache",
+		},
+		{
+			Name: "permanent redirect",
+			Body: "1234",
+			PrepareServiceFunc: func(ctx context.Context, body string) {
+				shortenedURL := &domain.ShortenedURL{OriginalURL: "https://url.com", RedirectType: domain.RedirectPermanent}
+
+				service.
+					EXPECT().
+					GetByShortURL(ctx, body).
+					Return(shortenedURL, nil)
+
+				service.
+					EXPECT().
+					RegisterClick(ctx, shortenedURL).
+					Return(nil)
+			},
+			ExpectedStatusCode:   http.StatusPermanentRedirect,
+			ExpectedLocation:     "https://url.com",
+			ExpectedCacheControl: "public, max-age=86400",
+		},
+		{
+			Name:  "utm and query passthrough",
+			Body:  "1234",
+			Query: "?ref=mail&utm_source=visitor",
+			PrepareServiceFunc: func(ctx context.Context, body string) {
+				shortenedURL := &domain.ShortenedURL{
+					OriginalURL:      "https://url.com/page?a=1",
+					UTM:              &domain.UTMParams{Source: "newsletter"},
+					QueryPassthrough: true,
+				}
+
+				service.
+					EXPECT().
+					GetByShortURL(ctx, body).
+					Return(shortenedURL, nil)
+
+				service.
+					EXPECT().
+					RegisterClick(ctx, shortenedURL).
+					Return(nil)
 			},
 			ExpectedStatusCode: http.StatusTemporaryRedirect,
+			ExpectedLocation:   "https://url.com/page?a=1&ref=mail&utm_source=newsletter",
 		},
 		{
-			Name: "invalid",
+			Name: "redirect rules",
 			Body: "1234",
+			Headers: map[string]string{
+				"User-Agent":      "Mozilla/5.0 (Linux; Android 14; Pixel 8) Mobile",
+				"Accept-Language": "de-AT, en;q=0.5",
+			},
 			PrepareServiceFunc: func(ctx context.Context, body string) {
+				shortenedURL := &domain.ShortenedURL{
+					OriginalURL:  "https://url.com",
+					RedirectType: domain.RedirectPermanent,
+					RedirectRules: []domain.RedirectRule{
+						{Destination: "https://apps.apple.com/app", Devices: []string{domain.DeviceIOS}},
+						{Destination: "https://url.de", Languages: []string{"de"}, Countries: []string{"AT"}},
+					},
+				}
+
 				service.
 					EXPECT().
 					GetByShortURL(ctx, body).
-					Return(nil, errors.New("undefined behavior"))
+					Return(shortenedURL, nil)
+
+				service.
+					EXPECT().
+					RegisterClick(ctx, shortenedURL).
+					Return(nil)
+
+				countries.
+					EXPECT().
+					Country(gomock.Any()).
+					Return("AT")
+			},
+			ExpectedStatusCode:   http.StatusPermanentRedirect,
+			ExpectedLocation:     "https://url.de",
+			ExpectedCacheControl: "private, no-cache",
+			ExpectedVary:         []string{"Origin", "User-Agent", "Accept-Language"},
+		},
+		{
+			Name: "moved permanently",
+			Body: "1234",
+			PrepareServiceFunc: func(ctx context.Context, body string) {
+				shortenedURL := &domain.ShortenedURL{OriginalURL: "https://url.com", RedirectType: domain.RedirectMovedPermanently}
+
+				service.
+					EXPECT().
+					GetByShortURL(ctx, body).
+					Return(shortenedURL, nil)
+
+				service.
+					EXPECT().
+					RegisterClick(ctx, shortenedURL).
+					Return(nil)
+			},
+			ExpectedStatusCode:   http.StatusMovedPermanently,
+			ExpectedLocation:     "https://url.com",
+			ExpectedCacheControl: "public, max-age=86400",
+		},
+		{
+			Name: "found",
+			Body: "1234",
+			PrepareServiceFunc: func(ctx context.Context, body string) {
+				shortenedURL := &domain.ShortenedURL{OriginalURL: "https://url.com", RedirectType: domain.RedirectFound}
+
+				service.
+					EXPECT().
+					GetByShortURL(ctx, body).
+					Return(shortenedURL, nil)
+
+				service.
+					EXPECT().
+					RegisterClick(ctx, shortenedURL).
+					Return(nil)
+			},
+			ExpectedStatusCode:   http.StatusFound,
+			ExpectedLocation:     "https://url.com",
+			ExpectedCacheControl: "private, no-cache",
+		},
+		{
+			Name: "preview",
+			Body: "1234",
+			PrepareServiceFunc: func(ctx context.Context, body string) {
+				shortenedURL := &domain.ShortenedURL{OriginalURL: "https://url.com", RedirectType: domain.RedirectPreview}
+
+				service.
+					EXPECT().
+					GetByShortURL(ctx, body).
+					Return(shortenedURL, nil)
+
+				service.
+					EXPECT().
+					RegisterClick(ctx, shortenedURL).
+					Return(nil)
+			},
+			ExpectedStatusCode:   http.StatusOK,
+			ExpectedCacheControl: "private, no-cache",
+		},
+		{
+			Name: "not found",
+			Body: "1234",
+			PrepareServiceFunc: func(ctx context.Context, body string) {
+				service.
+					EXPECT().
+					GetByShortURL(ctx, body).
+					Return(nil, domain.ErrURLNotFound)
 			},
 			ExpectedStatusCode: http.StatusBadRequest,
 		},
+		{
+			Name: "storage error",
+			Body: "1234",
+			PrepareServiceFunc: func(ctx context.Context, body string) {
+				service.
+					EXPECT().
+					GetByShortURL(ctx, body).
+					Return(nil, errors.New("undefined behavior"))
+			},
+			ExpectedStatusCode: http.StatusInternalServerError,
+		},
 		{
 			Name: "delete url",
 			Body: "1234",
@@ -614,16 +1031,34 @@ func TestRedirectToURLByID(t *testing.T) {
 			},
 			ExpectedStatusCode: http.StatusGone,
 		},
+		{
+			Name: "expired url",
+			Body: "1234",
+			PrepareServiceFunc: func(ctx context.Context, body string) {
+				expiresAt := time.Now().Add(-time.Minute)
+
+				service.
+					EXPECT().
+					GetByShortURL(ctx, body).
+					Return(&domain.ShortenedURL{ExpiresAt: &expiresAt}, nil)
+			},
+			ExpectedStatusCode: http.StatusGone,
+		},
 	}
 
 	for _, testCase := range testCases {
 		t.Run(testCase.Name, func(t *testing.T) {
-			r := httptest.NewRequest(http.MethodGet, "/", strings.NewReader(testCase.Body))
+			r := httptest.NewRequest(http.MethodGet, "/"+testCase.Query, strings.NewReader(testCase.Body))
 			r.Header.Set("Content-Type", "text/plain")
+			for name, value := range testCase.Headers {
+				r.Header.Set(name, value)
+			}
 			rctx := chi.NewRouteContext()
 			rctx.URLParams.Add("id", testCase.Body)
 			r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
 			w := httptest.NewRecorder()
+			// CORS middleware adds it before handler
+			w.Header().Add("Vary", "Origin")
 
 			if testCase.PrepareServiceFunc != nil {
 				testCase.PrepareServiceFunc(r.Context(), testCase.Body)
@@ -635,10 +1070,179 @@ func TestRedirectToURLByID(t *testing.T) {
 			defer res.Body.Close()
 
 			assert.Equal(t, testCase.ExpectedStatusCode, res.StatusCode)
+			assert.Equal(t, testCase.ExpectedLocation, res.Header.Get("Location"))
+
+			if testCase.ExpectedCacheControl != "" {
+				assert.Equal(t, testCase.ExpectedCacheControl, res.Header.Get("Cache-Control"))
+			}
+
+			if testCase.ExpectedVary != nil {
+				assert.Equal(t, testCase.ExpectedVary, res.Header.Values("Vary"))
+			}
+		})
+	}
+}
+
+func TestRedirectCacheControl(t *testing.T) {
+	now := time.Now()
+	expiresSoon := now.Add(time.Minute)
+	expiresLater := now.Add(time.Hour * 48)
+
+	testCases := []struct {
+		URL      *domain.ShortenedURL
+		Name     string
+		Expected string
+	}{
+		{Name: "legacy", URL: &domain.ShortenedURL{}, Expected: "private, no-cache"},
+		{Name: "temporary", URL: &domain.ShortenedURL{RedirectType: domain.RedirectTemporary}, Expected: "private, no-cache"},
+		{Name: "preview", URL: &domain.ShortenedURL{RedirectType: domain.RedirectPreview}, Expected: "private, no-cache"},
+		{Name: "permanent", URL: &domain.ShortenedURL{RedirectType: domain.RedirectPermanent}, Expected: "public, max-age=86400"},
+		{
+			Name:     "permanent with redirect rules",
+			URL:      &domain.ShortenedURL{RedirectType: domain.RedirectPermanent, RedirectRules: []domain.RedirectRule{{Destination: "https://url.de"}}},
+			Expected: "private, no-cache",
+		},
+		{
+			Name:     "permanent expires soon",
+			URL:      &domain.ShortenedURL{RedirectType: domain.RedirectPermanent, ExpiresAt: &expiresSoon},
+			Expected: "public, max-age=60",
+		},
+		{
+			Name:     "permanent expires later",
+			URL:      &domain.ShortenedURL{RedirectType: domain.RedirectMovedPermanently, ExpiresAt: &expiresLater},
+			Expected: "public, max-age=86400",
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			assert.Equal(t, testCase.Expected, redirectCacheControl(testCase.URL, now))
+		})
+	}
+}
+
+func TestRenderPreviewPage(t *testing.T) {
+	page, err := renderPreviewPage(previewPage{
+		ShortURL:    "http://localhost:8080/abc",
+		OriginalURL: "https://example.com/?a=1&b=<2>",
+	})
+	require.NoError(t, err)
+
+	assert.Contains(t, string(page), `href="https://example.com/?a=1&amp;b=%3c2%3e"`)
+	assert.Contains(t, string(page), "http://localhost:8080/abc")
+}
+
+func TestUnfurlURLByID(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	service := handlersmock.NewMockshortenerService(ctrl)
+
+	handler := NewShortenerHandler(
+		&config.AppConfig{BaseShortURLAddr: "http://localhost:8080", PreviewOpenGraph: true},
+		service,
+		nil,
+	)
+
+	createdAt := time.Date(2024, 3, 5, 10, 0, 0, 0, time.UTC)
+	expiresAt := time.Now().Add(-time.Minute)
+
+	type TestCase struct {
+		ShortenedURL       *domain.ShortenedURL
+		Err                error
+		Name               string
+		ExpectedContent    []string
+		ExpectedStatusCode int
+	}
+
+	testCases := []TestCase{
+		{
+			Name: "valid",
+			ShortenedURL: &domain.ShortenedURL{
+				ShortURL:    "1234",
+				OriginalURL: "https://example.com/page",
+				CreatedAt:   &createdAt,
+				Clicks:      7,
+			},
+			ExpectedStatusCode: http.StatusOK,
+			ExpectedContent: []string{
+				`href="https://example.com/page"`,
+				`<time datetime="2024-03-05T10:00:00Z">March 5, 2024</time>`,
+				"<dd>7</dd>",
+				`<meta property="og:url" content="http://localhost:8080/1234">`,
+				`<meta property="og:title" content="http://localhost:8080/1234 leads to example.com">`,
+				`<meta property="og:site_name" content="localhost:8080">`,
+			},
+		},
+		{
+			Name:               "without creation date",
+			ShortenedURL:       &domain.ShortenedURL{ShortURL: "1234", OriginalURL: "https://example.com"},
+			ExpectedStatusCode: http.StatusOK,
+			ExpectedContent:    []string{"<dd>Unknown</dd>"},
+		},
+		{
+			Name:               "not found",
+			Err:                domain.ErrURLNotFound,
+			ExpectedStatusCode: http.StatusNotFound,
+		},
+		{
+			Name:               "deleted",
+			ShortenedURL:       &domain.ShortenedURL{IsDeleted: true},
+			ExpectedStatusCode: http.StatusGone,
+		},
+		{
+			Name:               "expired",
+			ShortenedURL:       &domain.ShortenedURL{ExpiresAt: &expiresAt},
+			ExpectedStatusCode: http.StatusGone,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			r := httptest.NewRequest(http.MethodGet, "/1234+", nil)
+			rctx := chi.NewRouteContext()
+			rctx.URLParams.Add("id", "1234")
+			r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+			w := httptest.NewRecorder()
+
+			service.
+				EXPECT().
+				GetByShortURL(r.Context(), "1234").
+				Return(testCase.ShortenedURL, testCase.Err)
+
+			handler.UnfurlURLByID(w, r)
+
+			res := w.Result()
+			defer res.Body.Close()
+
+			body, err := io.ReadAll(res.Body)
+			require.NoError(t, err)
+
+			assert.Equal(t, testCase.ExpectedStatusCode, res.StatusCode)
+
+			for _, content := range testCase.ExpectedContent {
+				assert.Contains(t, string(body), content)
+			}
 		})
 	}
 }
 
+func TestRenderUnfurlPage(t *testing.T) {
+	page := unfurlPage{
+		ShortURL:    "http://localhost:8080/abc",
+		OriginalURL: "https://example.com",
+		Host:        "example.com",
+		SiteName:    "localhost:8080",
+	}
+
+	withoutOpenGraph, err := renderUnfurlPage(page)
+	require.NoError(t, err)
+	assert.NotContains(t, string(withoutOpenGraph), "og:")
+
+	page.OpenGraph = true
+	withOpenGraph, err := renderUnfurlPage(page)
+	require.NoError(t, err)
+	assert.Contains(t, string(withOpenGraph), `<meta property="og:description" content="https://example.com">`)
+}
+
 func TestPing(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	service := handlersmock.NewMockshortenerService(ctrl)
@@ -646,6 +1250,7 @@ func TestPing(t *testing.T) {
 	handler := NewShortenerHandler(
 		&config.AppConfig{},
 		service,
+		nil,
 	)
 
 	type TestCase struct {
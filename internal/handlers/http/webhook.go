@@ -0,0 +1,184 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/MowlCoder/go-url-shortener/internal/apierror"
+	contextUtil "github.com/MowlCoder/go-url-shortener/internal/context"
+	"github.com/MowlCoder/go-url-shortener/internal/domain"
+	"github.com/MowlCoder/go-url-shortener/internal/handlers/http/dtos"
+	"github.com/MowlCoder/go-url-shortener/pkg/httputil"
+)
+
+type webhookService interface {
+	Subscribe(ctx context.Context, userID string, webhookURL string, events []string) (*domain.Webhook, error)
+	GetUserWebhooks(ctx context.Context, userID string) ([]domain.Webhook, error)
+	DeleteWebhook(ctx context.Context, id string, userID string) error
+	GetDeliveries(ctx context.Context, id string, userID string) ([]domain.WebhookDelivery, error)
+}
+
+// WebhookHandler contains handlers to manage user webhook subscriptions.
+type WebhookHandler struct {
+	service webhookService
+}
+
+// NewWebhookHandler is constructor function for WebhookHandler.
+func NewWebhookHandler(service webhookService) *WebhookHandler {
+	return &WebhookHandler{
+		service: service,
+	}
+}
+
+// CreateWebhook godoc
+// @Summary Subscribe to link lifecycle events
+// @Accept json
+// @Produce json
+// @Param dto body dtos.CreateWebhookRequest true "Webhook"
+// @Success 201 {object} dtos.WebhookResponse
+// @Failure 400 {object} apierror.Response
+// @Failure 401 {object} apierror.Response
+// @Failure 500 {object} apierror.Response
+// @Router /api/user/webhooks [post]
+func (h *WebhookHandler) CreateWebhook(w http.ResponseWriter, r *http.Request) {
+	userID, err := contextUtil.GetUserIDFromContext(r.Context())
+	if err != nil {
+		apierror.Write(w, r, apierror.ErrUnauthorized)
+		return
+	}
+
+	requestBody := dtos.CreateWebhookRequest{}
+	rawBody, err := io.ReadAll(r.Body)
+
+	if err != nil {
+		apierror.Write(w, r, apierror.InvalidRequest(err))
+		return
+	}
+
+	if jsonErr := json.Unmarshal(rawBody, &requestBody); jsonErr != nil {
+		apierror.Write(w, r, apierror.InvalidRequest(jsonErr))
+		return
+	}
+
+	webhook, err := h.service.Subscribe(r.Context(), userID, requestBody.URL, requestBody.Events)
+
+	if err != nil {
+		apierror.Write(w, r, err)
+		return
+	}
+
+	response := makeWebhookResponse(webhook)
+	response.Secret = webhook.Secret
+
+	httputil.SendJSONResponse(w, http.StatusCreated, response)
+}
+
+// GetMyWebhooks godoc
+// @Summary Get user webhooks
+// @Produce json
+// @Success 200 {array} dtos.WebhookResponse
+// @Failure 401 {object} apierror.Response
+// @Failure 500 {object} apierror.Response
+// @Router /api/user/webhooks [get]
+func (h *WebhookHandler) GetMyWebhooks(w http.ResponseWriter, r *http.Request) {
+	userID, err := contextUtil.GetUserIDFromContext(r.Context())
+	if err != nil {
+		apierror.Write(w, r, apierror.ErrUnauthorized)
+		return
+	}
+
+	webhooks, err := h.service.GetUserWebhooks(r.Context(), userID)
+
+	if err != nil {
+		apierror.Write(w, r, err)
+		return
+	}
+
+	responseWebhooks := make([]dtos.WebhookResponse, 0, len(webhooks))
+
+	for i := range webhooks {
+		responseWebhooks = append(responseWebhooks, makeWebhookResponse(&webhooks[i]))
+	}
+
+	httputil.SendJSONResponse(w, http.StatusOK, responseWebhooks)
+}
+
+// DeleteWebhook godoc
+// @Summary Delete user webhook
+// @Param id path string true "Webhook ID"
+// @Success 204
+// @Failure 401 {object} apierror.Response
+// @Failure 404 {object} apierror.Response
+// @Failure 500 {object} apierror.Response
+// @Router /api/user/webhooks/{id} [delete]
+func (h *WebhookHandler) DeleteWebhook(w http.ResponseWriter, r *http.Request) {
+	userID, err := contextUtil.GetUserIDFromContext(r.Context())
+	if err != nil {
+		apierror.Write(w, r, apierror.ErrUnauthorized)
+		return
+	}
+
+	err = h.service.DeleteWebhook(r.Context(), chi.URLParam(r, "id"), userID)
+
+	if err != nil {
+		apierror.Write(w, r, err)
+		return
+	}
+
+	httputil.SendStatusCode(w, http.StatusNoContent)
+}
+
+// GetWebhookDeliveries godoc
+// @Summary Get delivery log of user webhook
+// @Produce json
+// @Param id path string true "Webhook ID"
+// @Success 200 {array} dtos.WebhookDeliveryResponse
+// @Failure 401 {object} apierror.Response
+// @Failure 404 {object} apierror.Response
+// @Failure 500 {object} apierror.Response
+// @Router /api/user/webhooks/{id}/deliveries [get]
+func (h *WebhookHandler) GetWebhookDeliveries(w http.ResponseWriter, r *http.Request) {
+	userID, err := contextUtil.GetUserIDFromContext(r.Context())
+	if err != nil {
+		apierror.Write(w, r, apierror.ErrUnauthorized)
+		return
+	}
+
+	deliveries, err := h.service.GetDeliveries(r.Context(), chi.URLParam(r, "id"), userID)
+
+	if err != nil {
+		apierror.Write(w, r, err)
+		return
+	}
+
+	responseDeliveries := make([]dtos.WebhookDeliveryResponse, 0, len(deliveries))
+
+	for _, delivery := range deliveries {
+		responseDeliveries = append(responseDeliveries, dtos.WebhookDeliveryResponse{
+			ID:          delivery.ID,
+			EventID:     delivery.EventID,
+			EventType:   delivery.EventType,
+			Error:       delivery.Error,
+			StatusCode:  delivery.StatusCode,
+			Attempt:     delivery.Attempt,
+			DurationMs:  delivery.DurationMs,
+			Success:     delivery.Success,
+			AttemptedAt: delivery.AttemptedAt,
+		})
+	}
+
+	httputil.SendJSONResponse(w, http.StatusOK, responseDeliveries)
+}
+
+func makeWebhookResponse(webhook *domain.Webhook) dtos.WebhookResponse {
+	return dtos.WebhookResponse{
+		ID:        webhook.ID,
+		URL:       webhook.URL,
+		Events:    webhook.Events,
+		CreatedAt: webhook.CreatedAt,
+	}
+}
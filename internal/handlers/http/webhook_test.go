@@ -0,0 +1,269 @@
+package http
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	contextUtil "github.com/MowlCoder/go-url-shortener/internal/context"
+	"github.com/MowlCoder/go-url-shortener/internal/domain"
+	"github.com/MowlCoder/go-url-shortener/internal/handlers/http/dtos"
+	handlersmock "github.com/MowlCoder/go-url-shortener/internal/handlers/http/mocks"
+)
+
+func TestCreateWebhook(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	service := handlersmock.NewMockwebhookService(ctrl)
+	handler := NewWebhookHandler(service)
+
+	type TestCase struct {
+		PrepareServiceFunc func(
+			ctx context.Context,
+			body *dtos.CreateWebhookRequest,
+		)
+		Body               *dtos.CreateWebhookRequest
+		Name               string
+		NotAuth            bool
+		ExpectedStatusCode int
+	}
+
+	testCases := []TestCase{
+		{
+			Name: "valid",
+			Body: &dtos.CreateWebhookRequest{
+				URL:    "https://hooks.example.com",
+				Events: []string{domain.EventLinkCreated},
+			},
+			PrepareServiceFunc: func(ctx context.Context, body *dtos.CreateWebhookRequest) {
+				service.
+					EXPECT().
+					Subscribe(ctx, "1", body.URL, body.Events).
+					Return(&domain.Webhook{ID: "hook", URL: body.URL, Secret: "secret", Events: body.Events}, nil)
+			},
+			ExpectedStatusCode: http.StatusCreated,
+		},
+		{
+			Name:               "nil body",
+			Body:               nil,
+			PrepareServiceFunc: nil,
+			ExpectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			Name:               "not auth",
+			NotAuth:            true,
+			Body:               nil,
+			PrepareServiceFunc: nil,
+			ExpectedStatusCode: http.StatusUnauthorized,
+		},
+		{
+			Name: "invalid url",
+			Body: &dtos.CreateWebhookRequest{
+				URL: "not url",
+			},
+			PrepareServiceFunc: func(ctx context.Context, body *dtos.CreateWebhookRequest) {
+				service.
+					EXPECT().
+					Subscribe(ctx, "1", body.URL, body.Events).
+					Return(nil, domain.ErrInvalidWebhookURL)
+			},
+			ExpectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			Name: "internal server error",
+			Body: &dtos.CreateWebhookRequest{
+				URL: "https://hooks.example.com",
+			},
+			PrepareServiceFunc: func(ctx context.Context, body *dtos.CreateWebhookRequest) {
+				service.
+					EXPECT().
+					Subscribe(ctx, "1", body.URL, body.Events).
+					Return(nil, errors.New("undefined behavior"))
+			},
+			ExpectedStatusCode: http.StatusInternalServerError,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			var rawBody []byte
+			var err error
+
+			if testCase.Body != nil {
+				rawBody, err = json.Marshal(*testCase.Body)
+				require.NoError(t, err)
+			}
+
+			r := httptest.NewRequest(http.MethodPost, "/api/user/webhooks", bytes.NewReader(rawBody))
+
+			if !testCase.NotAuth {
+				ctx := contextUtil.SetUserIDToContext(r.Context(), "1")
+				r = r.WithContext(ctx)
+			}
+
+			w := httptest.NewRecorder()
+
+			if testCase.PrepareServiceFunc != nil {
+				testCase.PrepareServiceFunc(r.Context(), testCase.Body)
+			}
+
+			handler.CreateWebhook(w, r)
+
+			res := w.Result()
+			defer res.Body.Close()
+
+			assert.Equal(t, testCase.ExpectedStatusCode, res.StatusCode)
+
+			if res.StatusCode == http.StatusCreated {
+				response, err := io.ReadAll(res.Body)
+				require.NoError(t, err)
+
+				var responseBody dtos.WebhookResponse
+				require.NoError(t, json.Unmarshal(response, &responseBody))
+				assert.Equal(t, "secret", responseBody.Secret)
+			}
+		})
+	}
+}
+
+func TestGetMyWebhooks(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	service := handlersmock.NewMockwebhookService(ctrl)
+	handler := NewWebhookHandler(service)
+
+	t.Run("valid", func(t *testing.T) {
+		r := httptest.NewRequest(http.MethodGet, "/api/user/webhooks", nil)
+		r = r.WithContext(contextUtil.SetUserIDToContext(r.Context(), "1"))
+		w := httptest.NewRecorder()
+
+		service.
+			EXPECT().
+			GetUserWebhooks(r.Context(), "1").
+			Return([]domain.Webhook{{ID: "hook", Secret: "secret"}}, nil)
+
+		handler.GetMyWebhooks(w, r)
+
+		res := w.Result()
+		defer res.Body.Close()
+
+		assert.Equal(t, http.StatusOK, res.StatusCode)
+
+		response, err := io.ReadAll(res.Body)
+		require.NoError(t, err)
+
+		var responseBody []dtos.WebhookResponse
+		require.NoError(t, json.Unmarshal(response, &responseBody))
+		require.Len(t, responseBody, 1)
+		assert.Empty(t, responseBody[0].Secret)
+	})
+}
+
+func TestDeleteWebhook(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	service := handlersmock.NewMockwebhookService(ctrl)
+	handler := NewWebhookHandler(service)
+
+	type TestCase struct {
+		ServiceErr         error
+		Name               string
+		ExpectedStatusCode int
+	}
+
+	testCases := []TestCase{
+		{
+			Name:               "valid",
+			ExpectedStatusCode: http.StatusNoContent,
+		},
+		{
+			Name:               "not found",
+			ServiceErr:         domain.ErrWebhookNotFound,
+			ExpectedStatusCode: http.StatusNotFound,
+		},
+		{
+			Name:               "internal server error",
+			ServiceErr:         errors.New("undefined behavior"),
+			ExpectedStatusCode: http.StatusInternalServerError,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			r := httptest.NewRequest(http.MethodDelete, "/api/user/webhooks/hook", nil)
+			rctx := chi.NewRouteContext()
+			rctx.URLParams.Add("id", "hook")
+			ctx := context.WithValue(r.Context(), chi.RouteCtxKey, rctx)
+			r = r.WithContext(contextUtil.SetUserIDToContext(ctx, "1"))
+			w := httptest.NewRecorder()
+
+			service.
+				EXPECT().
+				DeleteWebhook(r.Context(), "hook", "1").
+				Return(testCase.ServiceErr)
+
+			handler.DeleteWebhook(w, r)
+
+			res := w.Result()
+			defer res.Body.Close()
+
+			assert.Equal(t, testCase.ExpectedStatusCode, res.StatusCode)
+		})
+	}
+}
+
+func TestGetWebhookDeliveries(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	service := handlersmock.NewMockwebhookService(ctrl)
+	handler := NewWebhookHandler(service)
+
+	type TestCase struct {
+		ServiceErr         error
+		Name               string
+		Deliveries         []domain.WebhookDelivery
+		ExpectedStatusCode int
+	}
+
+	testCases := []TestCase{
+		{
+			Name:               "valid",
+			Deliveries:         []domain.WebhookDelivery{{ID: "1", StatusCode: 200, Success: true}},
+			ExpectedStatusCode: http.StatusOK,
+		},
+		{
+			Name:               "not found",
+			ServiceErr:         domain.ErrWebhookNotFound,
+			ExpectedStatusCode: http.StatusNotFound,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			r := httptest.NewRequest(http.MethodGet, "/api/user/webhooks/hook/deliveries", nil)
+			rctx := chi.NewRouteContext()
+			rctx.URLParams.Add("id", "hook")
+			ctx := context.WithValue(r.Context(), chi.RouteCtxKey, rctx)
+			r = r.WithContext(contextUtil.SetUserIDToContext(ctx, "1"))
+			w := httptest.NewRecorder()
+
+			service.
+				EXPECT().
+				GetDeliveries(r.Context(), "hook", "1").
+				Return(testCase.Deliveries, testCase.ServiceErr)
+
+			handler.GetWebhookDeliveries(w, r)
+
+			res := w.Result()
+			defer res.Body.Close()
+
+			assert.Equal(t, testCase.ExpectedStatusCode, res.StatusCode)
+		})
+	}
+}
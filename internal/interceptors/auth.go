@@ -2,6 +2,7 @@ package interceptors
 
 import (
 	"context"
+	"strings"
 
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
@@ -12,10 +13,19 @@ import (
 	"github.com/MowlCoder/go-url-shortener/internal/jwt"
 )
 
+// TokenMetadataKey is metadata key of user token in requests and in response header.
+const TokenMetadataKey = "token"
+
+// healthMethodPrefix is prefix of gRPC health checking methods. Probes are not users, so they are not authenticated
+// and do not get tokens.
+const healthMethodPrefix = "/grpc.health.v1.Health/"
+
 type userService interface {
 	GenerateUniqueID() string
 }
 
+// CreateAuthInterceptor returns unary interceptor, that puts user id from token in metadata to context.
+// Caller without token gets new token in "token" header metadata. Health checking methods are not authenticated.
 func CreateAuthInterceptor(
 	userService userService,
 ) func(ctx context.Context,
@@ -23,30 +33,91 @@ func CreateAuthInterceptor(
 	info *grpc.UnaryServerInfo,
 	handler grpc.UnaryHandler) (any, error) {
 	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
-		var tokenString string
-		var err error
-		md, ok := metadata.FromIncomingContext(ctx)
+		if isHealthMethod(info.FullMethod) {
+			return handler(ctx, req)
+		}
 
-		if !ok {
-			return nil, status.Error(codes.Internal, "can not get token")
+		ctxWithUserID, issuedToken, err := authenticate(ctx, userService)
+		if err != nil {
+			return nil, err
 		}
 
-		if len(md.Get("token")) == 0 {
-			tokenString, err = jwt.GenerateToken(userService.GenerateUniqueID())
-			if err != nil {
-				return nil, status.Error(codes.Internal, "can not generate token")
+		if issuedToken != "" {
+			if err := grpc.SetHeader(ctx, metadata.Pairs(TokenMetadataKey, issuedToken)); err != nil {
+				return nil, status.Error(codes.Internal, "can not send token")
 			}
-		} else {
-			tokenString = md.Get("token")[0]
 		}
 
-		jwtClaim, err := jwt.ParseToken(tokenString)
+		return handler(ctxWithUserID, req)
+	}
+}
+
+// CreateStreamAuthInterceptor returns stream interceptor, that authenticates user the same way
+// as unary interceptor and passes stream with user id in context to handler.
+func CreateStreamAuthInterceptor(userService userService) grpc.StreamServerInterceptor {
+	return func(srv any, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		if isHealthMethod(info.FullMethod) {
+			return handler(srv, stream)
+		}
+
+		ctxWithUserID, issuedToken, err := authenticate(stream.Context(), userService)
 		if err != nil {
-			return nil, status.Error(codes.Internal, "can not parse token")
+			return err
+		}
+
+		if issuedToken != "" {
+			if err := stream.SetHeader(metadata.Pairs(TokenMetadataKey, issuedToken)); err != nil {
+				return status.Error(codes.Internal, "can not send token")
+			}
 		}
 
-		ctxWithUserID := contextUtil.SetUserIDToContext(ctx, jwtClaim.UserID)
+		return handler(srv, &authenticatedStream{ServerStream: stream, ctx: ctxWithUserID})
+	}
+}
 
-		return handler(ctxWithUserID, req)
+func isHealthMethod(fullMethod string) bool {
+	return strings.HasPrefix(fullMethod, healthMethodPrefix)
+}
+
+// authenticate parses token from metadata or generates token for new user
+// and returns context with user id. Generated token is returned to be sent to the caller.
+// Calls served over gRPC-Web are already authenticated by token cookie in http middleware,
+// so user id from context is kept, when there is no token in metadata.
+func authenticate(ctx context.Context, userService userService) (context.Context, string, error) {
+	var tokenString, issuedToken string
+	var err error
+
+	md, _ := metadata.FromIncomingContext(ctx)
+	tokens := md.Get(TokenMetadataKey)
+
+	if _, userErr := contextUtil.GetUserIDFromContext(ctx); userErr == nil && len(tokens) == 0 {
+		return ctx, "", nil
+	}
+
+	if len(tokens) > 0 {
+		tokenString = tokens[0]
+	} else {
+		tokenString, err = jwt.GenerateToken(userService.GenerateUniqueID())
+		if err != nil {
+			return nil, "", status.Error(codes.Internal, "can not generate token")
+		}
+
+		issuedToken = tokenString
+	}
+
+	jwtClaim, err := jwt.ParseToken(tokenString)
+	if err != nil {
+		return nil, "", status.Error(codes.Unauthenticated, "invalid token")
 	}
+
+	return contextUtil.SetUserIDToContext(ctx, jwtClaim.UserID), issuedToken, nil
+}
+
+type authenticatedStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *authenticatedStream) Context() context.Context {
+	return s.ctx
 }
@@ -0,0 +1,185 @@
+package interceptors
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	contextUtil "github.com/MowlCoder/go-url-shortener/internal/context"
+	"github.com/MowlCoder/go-url-shortener/internal/jwt"
+	"github.com/MowlCoder/go-url-shortener/internal/services"
+)
+
+// fakeTransportStream collects header metadata set by handler.
+type fakeTransportStream struct {
+	grpc.ServerTransportStream
+	header metadata.MD
+}
+
+func (s *fakeTransportStream) SetHeader(md metadata.MD) error {
+	s.header = metadata.Join(s.header, md)
+	return nil
+}
+
+func TestAuthInterceptor(t *testing.T) {
+	interceptor := CreateAuthInterceptor(services.NewUserService())
+
+	validToken, err := jwt.GenerateToken("user")
+	require.NoError(t, err)
+
+	type TestCase struct {
+		Name           string
+		Method         string
+		Metadata       metadata.MD
+		ContextUserID  string
+		ExpectedUserID string
+		ExpectedCode   codes.Code
+		IsTokenIssued  bool
+	}
+
+	testCases := []TestCase{
+		{
+			Name:           "valid token",
+			Metadata:       metadata.Pairs(TokenMetadataKey, validToken),
+			ExpectedUserID: "user",
+			ExpectedCode:   codes.OK,
+		},
+		{
+			Name:          "without token",
+			Metadata:      metadata.MD{},
+			ExpectedCode:  codes.OK,
+			IsTokenIssued: true,
+		},
+		{
+			Name:          "without metadata",
+			ExpectedCode:  codes.OK,
+			IsTokenIssued: true,
+		},
+		{
+			Name:           "user authenticated by http middleware",
+			ContextUserID:  "cookie user",
+			ExpectedUserID: "cookie user",
+			ExpectedCode:   codes.OK,
+		},
+		{
+			Name:           "token takes precedence over user in context",
+			Metadata:       metadata.Pairs(TokenMetadataKey, validToken),
+			ContextUserID:  "cookie user",
+			ExpectedUserID: "user",
+			ExpectedCode:   codes.OK,
+		},
+		{
+			Name:         "invalid token",
+			Metadata:     metadata.Pairs(TokenMetadataKey, "invalid"),
+			ExpectedCode: codes.Unauthenticated,
+		},
+		{
+			Name:         "health check is not authenticated",
+			Method:       healthpb.Health_Check_FullMethodName,
+			ExpectedCode: codes.OK,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			stream := &fakeTransportStream{}
+			ctx := grpc.NewContextWithServerTransportStream(context.Background(), stream)
+
+			if testCase.Metadata != nil {
+				ctx = metadata.NewIncomingContext(ctx, testCase.Metadata)
+			}
+
+			if testCase.ContextUserID != "" {
+				ctx = contextUtil.SetUserIDToContext(ctx, testCase.ContextUserID)
+			}
+
+			var userID string
+
+			_, err := interceptor(ctx, nil, &grpc.UnaryServerInfo{FullMethod: testCase.Method}, func(ctx context.Context, req any) (any, error) {
+				userID, _ = contextUtil.GetUserIDFromContext(ctx)
+				return nil, nil
+			})
+			assert.Equal(t, testCase.ExpectedCode, status.Code(err))
+
+			if testCase.ExpectedCode != codes.OK {
+				return
+			}
+
+			if !testCase.IsTokenIssued {
+				assert.Equal(t, testCase.ExpectedUserID, userID)
+				assert.Empty(t, stream.header.Get(TokenMetadataKey))
+				return
+			}
+
+			tokens := stream.header.Get(TokenMetadataKey)
+			require.Len(t, tokens, 1)
+
+			claims, err := jwt.ParseToken(tokens[0])
+			require.NoError(t, err)
+			assert.Equal(t, claims.UserID, userID)
+		})
+	}
+}
+
+type fakeServerStream struct {
+	grpc.ServerStream
+	ctx    context.Context
+	header metadata.MD
+}
+
+func (s *fakeServerStream) Context() context.Context {
+	return s.ctx
+}
+
+func (s *fakeServerStream) SetHeader(md metadata.MD) error {
+	s.header = metadata.Join(s.header, md)
+	return nil
+}
+
+func TestStreamAuthInterceptor(t *testing.T) {
+	interceptor := CreateStreamAuthInterceptor(services.NewUserService())
+
+	t.Run("without token", func(t *testing.T) {
+		stream := &fakeServerStream{ctx: context.Background()}
+
+		var userID string
+
+		err := interceptor(nil, stream, &grpc.StreamServerInfo{}, func(srv any, stream grpc.ServerStream) error {
+			userID, _ = contextUtil.GetUserIDFromContext(stream.Context())
+			return nil
+		})
+		require.NoError(t, err)
+		assert.NotEmpty(t, userID)
+		assert.Len(t, stream.header.Get(TokenMetadataKey), 1)
+	})
+
+	t.Run("health watch is not authenticated", func(t *testing.T) {
+		stream := &fakeServerStream{ctx: context.Background()}
+
+		err := interceptor(nil, stream, &grpc.StreamServerInfo{FullMethod: healthpb.Health_Watch_FullMethodName}, func(srv any, stream grpc.ServerStream) error {
+			_, err := contextUtil.GetUserIDFromContext(stream.Context())
+			assert.Error(t, err)
+			return nil
+		})
+		require.NoError(t, err)
+		assert.Empty(t, stream.header.Get(TokenMetadataKey))
+	})
+
+	t.Run("invalid token", func(t *testing.T) {
+		stream := &fakeServerStream{
+			ctx: metadata.NewIncomingContext(context.Background(), metadata.Pairs(TokenMetadataKey, "invalid")),
+		}
+
+		err := interceptor(nil, stream, &grpc.StreamServerInfo{}, func(srv any, stream grpc.ServerStream) error {
+			return nil
+		})
+		assert.Equal(t, codes.Unauthenticated, status.Code(err))
+	})
+}
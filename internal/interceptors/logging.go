@@ -0,0 +1,90 @@
+package interceptors
+
+import (
+	"context"
+	"time"
+
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	contextUtil "github.com/MowlCoder/go-url-shortener/internal/context"
+	"github.com/MowlCoder/go-url-shortener/internal/logger"
+	"github.com/MowlCoder/go-url-shortener/internal/requestid"
+)
+
+// CreateLoggingInterceptor returns unary interceptor, that takes request id from "x-request-id" metadata
+// or generates it, sends it back in header metadata and logs method, status code and execution time of call.
+// Request id and logger with request id are saved in context, so handlers log entries linked to call.
+func CreateLoggingInterceptor(customLogger *logger.Logger, resolver clientIPResolver) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		start := time.Now()
+		ctx, callLogger, requestID := withRequestLogger(ctx, customLogger, resolver)
+
+		_ = grpc.SetHeader(ctx, metadata.Pairs(requestid.MetadataKey, requestID))
+
+		resp, err := handler(ctx, req)
+
+		logCall(callLogger, info.FullMethod, err, time.Since(start))
+
+		return resp, err
+	}
+}
+
+// CreateStreamLoggingInterceptor returns stream interceptor, that handles request id and logs calls
+// the same way as unary interceptor.
+func CreateStreamLoggingInterceptor(customLogger *logger.Logger, resolver clientIPResolver) grpc.StreamServerInterceptor {
+	return func(srv any, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		start := time.Now()
+		ctx, callLogger, requestID := withRequestLogger(stream.Context(), customLogger, resolver)
+
+		_ = stream.SetHeader(metadata.Pairs(requestid.MetadataKey, requestID))
+
+		err := handler(srv, &contextStream{ServerStream: stream, ctx: ctx})
+
+		logCall(callLogger, info.FullMethod, err, time.Since(start))
+
+		return err
+	}
+}
+
+func withRequestLogger(
+	ctx context.Context,
+	customLogger *logger.Logger,
+	resolver clientIPResolver,
+) (context.Context, *logger.Logger, string) {
+	md, _ := metadata.FromIncomingContext(ctx)
+	incoming := ""
+	if values := md.Get(requestid.MetadataKey); len(values) > 0 {
+		incoming = values[0]
+	}
+
+	requestID := requestid.FromIncoming(incoming)
+	ctx = contextUtil.SetRequestIDToContext(ctx, requestID)
+
+	callLogger := customLogger.With(
+		zap.String(logger.RequestIDKey, requestID),
+		zap.String(logger.ClientIPKey, resolver.FromGRPC(ctx).String()),
+	)
+
+	return logger.NewContext(ctx, callLogger), callLogger, requestID
+}
+
+func logCall(callLogger *logger.Logger, method string, err error, duration time.Duration) {
+	callLogger.Info(
+		"grpc call",
+		zap.String("method", method),
+		zap.String("code", status.Code(err).String()),
+		zap.Duration("duration", duration),
+	)
+}
+
+type contextStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *contextStream) Context() context.Context {
+	return s.ctx
+}
@@ -0,0 +1,88 @@
+package interceptors
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+
+	contextUtil "github.com/MowlCoder/go-url-shortener/internal/context"
+	"github.com/MowlCoder/go-url-shortener/internal/logger"
+)
+
+type fakeClientIPResolver struct{}
+
+func (fakeClientIPResolver) FromGRPC(ctx context.Context) net.IP {
+	return net.ParseIP("10.0.0.1")
+}
+
+func TestLoggingInterceptor(t *testing.T) {
+	customLogger, err := logger.NewLogger(logger.Options{Level: "error"})
+	require.NoError(t, err)
+
+	interceptor := CreateLoggingInterceptor(customLogger, fakeClientIPResolver{})
+
+	testCases := []struct {
+		name       string
+		metadata   metadata.MD
+		expectedID string
+	}{
+		{name: "id from client", metadata: metadata.Pairs("x-request-id", "client-id-1"), expectedID: "client-id-1"},
+		{name: "invalid id", metadata: metadata.Pairs("x-request-id", "bad id")},
+		{name: "without metadata"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := context.Background()
+			if tc.metadata != nil {
+				ctx = metadata.NewIncomingContext(ctx, tc.metadata)
+			}
+
+			stream := &fakeTransportStream{}
+			ctx = grpc.NewContextWithServerTransportStream(ctx, stream)
+
+			var requestID string
+
+			_, err := interceptor(ctx, nil, &grpc.UnaryServerInfo{FullMethod: "/test"}, func(ctx context.Context, req any) (any, error) {
+				requestID = contextUtil.GetRequestIDFromContext(ctx)
+				return nil, nil
+			})
+			require.NoError(t, err)
+
+			if tc.expectedID != "" {
+				assert.Equal(t, tc.expectedID, requestID)
+			} else {
+				assert.NotEmpty(t, requestID)
+				assert.NotEqual(t, "bad id", requestID)
+			}
+
+			assert.Equal(t, []string{requestID}, stream.header.Get("x-request-id"))
+		})
+	}
+}
+
+func TestStreamLoggingInterceptor(t *testing.T) {
+	customLogger, err := logger.NewLogger(logger.Options{Level: "error"})
+	require.NoError(t, err)
+
+	interceptor := CreateStreamLoggingInterceptor(customLogger, fakeClientIPResolver{})
+	stream := &fakeServerStream{
+		ctx: metadata.NewIncomingContext(context.Background(), metadata.Pairs("x-request-id", "client-id-1")),
+	}
+
+	var requestID string
+
+	err = interceptor(nil, stream, &grpc.StreamServerInfo{FullMethod: "/test"}, func(srv any, stream grpc.ServerStream) error {
+		requestID = contextUtil.GetRequestIDFromContext(stream.Context())
+		return nil
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, "client-id-1", requestID)
+	assert.Equal(t, []string{"client-id-1"}, stream.header.Get("x-request-id"))
+}
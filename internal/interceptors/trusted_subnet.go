@@ -0,0 +1,40 @@
+package interceptors
+
+import (
+	"context"
+	"net"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type trustedSubnet interface {
+	Contains(ip net.IP) bool
+}
+
+type clientIPResolver interface {
+	FromGRPC(ctx context.Context) net.IP
+}
+
+// CreateTrustedSubnetInterceptor returns unary interceptor, that allows to call given methods
+// only from client address in trusted subnet. Empty trusted subnet denies all calls of these methods.
+// Client address is resolved the same way as for http requests, so trusted proxies are honoured.
+func CreateTrustedSubnetInterceptor(trustedSubnet trustedSubnet, resolver clientIPResolver, methods ...string) grpc.UnaryServerInterceptor {
+	protectedMethods := make(map[string]struct{}, len(methods))
+	for _, method := range methods {
+		protectedMethods[method] = struct{}{}
+	}
+
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		if _, ok := protectedMethods[info.FullMethod]; !ok {
+			return handler(ctx, req)
+		}
+
+		if !trustedSubnet.Contains(resolver.FromGRPC(ctx)) {
+			return nil, status.Error(codes.PermissionDenied, "not trusted subnet")
+		}
+
+		return handler(ctx, req)
+	}
+}
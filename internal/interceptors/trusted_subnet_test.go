@@ -0,0 +1,117 @@
+package interceptors
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+
+	"github.com/MowlCoder/go-url-shortener/internal/clientip"
+	"github.com/MowlCoder/go-url-shortener/internal/subnets"
+)
+
+func TestTrustedSubnetInterceptor(t *testing.T) {
+	const protectedMethod = "/shortener.Shortener/GetStats"
+
+	type TestCase struct {
+		Name          string
+		TrustedSubnet string
+		Method        string
+		PeerIP        string
+		RealIP        string
+		ExpectedCode  codes.Code
+	}
+
+	testCases := []TestCase{
+		{
+			Name:          "trusted peer",
+			TrustedSubnet: "192.168.1.0/24",
+			Method:        protectedMethod,
+			PeerIP:        "192.168.1.10",
+			ExpectedCode:  codes.OK,
+		},
+		{
+			Name:          "not trusted peer",
+			TrustedSubnet: "192.168.1.0/24",
+			Method:        protectedMethod,
+			PeerIP:        "10.0.0.1",
+			ExpectedCode:  codes.PermissionDenied,
+		},
+		{
+			Name:          "trusted client behind trusted proxy",
+			TrustedSubnet: "192.168.1.0/24",
+			Method:        protectedMethod,
+			PeerIP:        "10.10.0.1",
+			RealIP:        "192.168.1.10",
+			ExpectedCode:  codes.OK,
+		},
+		{
+			Name:          "spoofed metadata of not trusted peer",
+			TrustedSubnet: "192.168.1.0/24",
+			Method:        protectedMethod,
+			PeerIP:        "10.0.0.1",
+			RealIP:        "192.168.1.10",
+			ExpectedCode:  codes.PermissionDenied,
+		},
+		{
+			Name:          "trusted ipv6 peer",
+			TrustedSubnet: "192.168.1.0/24, fd00::/8",
+			Method:        protectedMethod,
+			PeerIP:        "fd00::10",
+			ExpectedCode:  codes.OK,
+		},
+		{
+			Name:         "empty trusted subnet",
+			Method:       protectedMethod,
+			PeerIP:       "192.168.1.10",
+			ExpectedCode: codes.PermissionDenied,
+		},
+		{
+			Name:          "without peer",
+			TrustedSubnet: "192.168.1.0/24",
+			Method:        protectedMethod,
+			ExpectedCode:  codes.PermissionDenied,
+		},
+		{
+			Name:         "not protected method",
+			Method:       "/shortener.Shortener/ShortURL",
+			PeerIP:       "10.0.0.1",
+			ExpectedCode: codes.OK,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			trustedSubnet, err := subnets.NewTrusted(testCase.TrustedSubnet)
+			require.NoError(t, err)
+
+			trustedProxies, err := subnets.NewTrusted("10.10.0.1")
+			require.NoError(t, err)
+
+			interceptor := CreateTrustedSubnetInterceptor(trustedSubnet, clientip.NewResolver(trustedProxies), protectedMethod)
+			ctx := context.Background()
+
+			if testCase.PeerIP != "" {
+				ctx = peer.NewContext(ctx, &peer.Peer{
+					Addr: &net.TCPAddr{IP: net.ParseIP(testCase.PeerIP), Port: 50000},
+				})
+			}
+
+			if testCase.RealIP != "" {
+				ctx = metadata.NewIncomingContext(ctx, metadata.Pairs("x-real-ip", testCase.RealIP))
+			}
+
+			_, err = interceptor(ctx, nil, &grpc.UnaryServerInfo{FullMethod: testCase.Method}, func(ctx context.Context, req any) (any, error) {
+				return nil, nil
+			})
+			assert.Equal(t, testCase.ExpectedCode, status.Code(err))
+		})
+	}
+}
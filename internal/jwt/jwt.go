@@ -12,6 +12,9 @@ const (
 	envKeyJWTSecret  = "JWT_SECRET"
 )
 
+// TokenTTL is lifetime of generated token.
+const TokenTTL = time.Hour * 24
+
 // Claims is JWT payload.
 type Claims struct {
 	jwt.RegisteredClaims
@@ -22,7 +25,7 @@ type Claims struct {
 func GenerateToken(userID string) (string, error) {
 	token := jwt.NewWithClaims(jwt.SigningMethodHS256, Claims{
 		RegisteredClaims: jwt.RegisteredClaims{
-			ExpiresAt: jwt.NewNumericDate(time.Now().UTC().Add(time.Hour * 24)),
+			ExpiresAt: jwt.NewNumericDate(time.Now().UTC().Add(TokenTTL)),
 		},
 		UserID: userID,
 	})
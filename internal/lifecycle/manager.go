@@ -0,0 +1,123 @@
+// Package lifecycle is responsible for ordered shutdown of application components.
+package lifecycle
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+
+	"go.uber.org/zap"
+)
+
+// StopFunc stops component. It should return when component is stopped or ctx is done.
+type StopFunc func(ctx context.Context) error
+
+type logger interface {
+	Info(msg string, fields ...zap.Field)
+	Warn(msg string, fields ...zap.Field)
+}
+
+type component struct {
+	stop            StopFunc
+	name            string
+	requiresStopped bool
+}
+
+// Manager tracks readiness of application and stops registered components
+// in order of their registration.
+type Manager struct {
+	logger     logger
+	components []component
+	mx         sync.Mutex
+	ready      int32
+}
+
+// NewManager is constructor function to create Manager.
+func NewManager(logger logger) *Manager {
+	return &Manager{
+		logger:     logger,
+		components: make([]component, 0),
+	}
+}
+
+// Append registers component, that will be stopped after all previously registered components.
+func (m *Manager) Append(name string, stop StopFunc) {
+	m.mx.Lock()
+	defer m.mx.Unlock()
+
+	m.components = append(m.components, component{
+		name: name,
+		stop: stop,
+	})
+}
+
+// AppendAfterStopped registers component, that will be stopped after all previously registered components,
+// but only if all of them are stopped without error. It is used for resources like storage,
+// that must not be closed while component, that failed to stop, can still use them.
+func (m *Manager) AppendAfterStopped(name string, stop StopFunc) {
+	m.mx.Lock()
+	defer m.mx.Unlock()
+
+	m.components = append(m.components, component{
+		name:            name,
+		stop:            stop,
+		requiresStopped: true,
+	})
+}
+
+// SetReady marks application as ready to accept traffic.
+func (m *Manager) SetReady() {
+	atomic.StoreInt32(&m.ready, 1)
+}
+
+// IsReady reports if application is ready to accept traffic.
+func (m *Manager) IsReady() bool {
+	return atomic.LoadInt32(&m.ready) == 1
+}
+
+// Shutdown marks application as not ready and then stops all components one by one.
+// Failure of one component does not prevent stopping of next ones, except of components registered
+// by AppendAfterStopped, which are skipped. All errors are returned joined.
+func (m *Manager) Shutdown(ctx context.Context) error {
+	atomic.StoreInt32(&m.ready, 0)
+
+	m.mx.Lock()
+	components := m.components
+	m.mx.Unlock()
+
+	errs := make([]error, 0)
+
+	for _, c := range components {
+		if c.requiresStopped && len(errs) > 0 {
+			m.logger.Warn("component is not stopped, because previous components failed to stop", zap.String("component", c.name))
+			continue
+		}
+
+		m.logger.Info("stopping component", zap.String("component", c.name))
+
+		if err := c.stop(ctx); err != nil {
+			errs = append(errs, fmt.Errorf("stop %s: %w", c.name, err))
+			continue
+		}
+
+		m.logger.Info("component stopped", zap.String("component", c.name))
+	}
+
+	return errors.Join(errs...)
+}
+
+// WaitDone returns StopFunc, that calls cancel to stop background worker and waits until done is closed.
+func WaitDone(cancel context.CancelFunc, done <-chan struct{}) StopFunc {
+	return func(ctx context.Context) error {
+		cancel()
+
+		select {
+		case <-done:
+			return nil
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
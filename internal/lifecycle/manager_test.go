@@ -0,0 +1,197 @@
+package lifecycle
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/MowlCoder/go-url-shortener/internal/domain"
+	"github.com/MowlCoder/go-url-shortener/internal/services"
+	"github.com/MowlCoder/go-url-shortener/internal/storage"
+)
+
+type nopLogger struct{}
+
+func (nopLogger) Info(string, ...zap.Field) {}
+
+func (nopLogger) Warn(string, ...zap.Field) {}
+
+func (nopLogger) Error(string, ...zap.Field) {}
+
+type nopEventEmitter struct{}
+
+func (nopEventEmitter) Emit(ctx context.Context, eventType string, userID string, links []domain.LinkEventData) {
+}
+
+func TestManager_Shutdown(t *testing.T) {
+	t.Run("stop in order", func(t *testing.T) {
+		manager := NewManager(nopLogger{})
+		stopped := make([]string, 0)
+
+		manager.Append("first", func(ctx context.Context) error {
+			assert.False(t, manager.IsReady())
+			stopped = append(stopped, "first")
+			return nil
+		})
+		manager.Append("second", func(ctx context.Context) error {
+			stopped = append(stopped, "second")
+			return nil
+		})
+
+		manager.SetReady()
+		assert.True(t, manager.IsReady())
+
+		require.NoError(t, manager.Shutdown(context.Background()))
+		assert.Equal(t, []string{"first", "second"}, stopped)
+		assert.False(t, manager.IsReady())
+	})
+
+	t.Run("continue on error", func(t *testing.T) {
+		manager := NewManager(nopLogger{})
+		stopErr := errors.New("undefined behavior")
+		lastStopped := false
+
+		manager.Append("failed", func(ctx context.Context) error {
+			return stopErr
+		})
+		manager.Append("last", func(ctx context.Context) error {
+			lastStopped = true
+			return nil
+		})
+
+		err := manager.Shutdown(context.Background())
+		assert.ErrorIs(t, err, stopErr)
+		assert.True(t, lastStopped)
+	})
+
+	t.Run("skip after stopped on error", func(t *testing.T) {
+		manager := NewManager(nopLogger{})
+		stopErr := errors.New("undefined behavior")
+		storageClosed := false
+
+		manager.Append("worker", func(ctx context.Context) error {
+			return stopErr
+		})
+		manager.AppendAfterStopped("storage", func(ctx context.Context) error {
+			storageClosed = true
+			return nil
+		})
+
+		err := manager.Shutdown(context.Background())
+		assert.ErrorIs(t, err, stopErr)
+		assert.False(t, storageClosed)
+	})
+
+	t.Run("stop after stopped", func(t *testing.T) {
+		manager := NewManager(nopLogger{})
+		storageClosed := false
+
+		manager.Append("worker", func(ctx context.Context) error {
+			return nil
+		})
+		manager.AppendAfterStopped("storage", func(ctx context.Context) error {
+			storageClosed = true
+			return nil
+		})
+
+		require.NoError(t, manager.Shutdown(context.Background()))
+		assert.True(t, storageClosed)
+	})
+}
+
+func TestWaitDone(t *testing.T) {
+	t.Run("done", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		done := make(chan struct{})
+
+		go func() {
+			<-ctx.Done()
+			close(done)
+		}()
+
+		require.NoError(t, WaitDone(cancel, done)(context.Background()))
+	})
+
+	t.Run("timeout", func(t *testing.T) {
+		shutdownCtx, shutdownCancel := context.WithTimeout(context.Background(), time.Millisecond)
+		defer shutdownCancel()
+
+		err := WaitDone(func() {}, make(chan struct{}))(shutdownCtx)
+		assert.ErrorIs(t, err, context.DeadlineExceeded)
+	})
+}
+
+func TestManager_ShutdownDoesNotLoseAcceptedDeletions(t *testing.T) {
+	const urlsCount = 100
+
+	ctx := context.Background()
+
+	urlStorage, err := storage.NewInMemoryStorage()
+	require.NoError(t, err)
+
+	for i := 0; i < urlsCount; i++ {
+		_, err := urlStorage.SaveURL(ctx, domain.SaveShortURLDto{
+			OriginalURL: fmt.Sprintf("https://example.com/%d", i),
+			ShortURL:    fmt.Sprintf("short%d", i),
+			UserID:      "1",
+		})
+		require.NoError(t, err)
+	}
+
+	queue := services.NewDeleteURLQueue(urlStorage, nopLogger{}, nopEventEmitter{}, services.DeleteURLQueueOptions{
+		FlushInterval: time.Hour,
+		BatchSize:     7,
+	})
+
+	queueCtx, stopQueue := context.WithCancel(ctx)
+	go queue.Start(queueCtx)
+
+	// in-flight requests, that accepted deletions while shutdown is started
+	var requests sync.WaitGroup
+
+	for i := 0; i < urlsCount; i++ {
+		requests.Add(1)
+
+		go func(i int) {
+			defer requests.Done()
+
+			assert.NoError(t, queue.Push(ctx, &domain.DeleteURLsTask{
+				ShortURLs: []string{fmt.Sprintf("short%d", i)},
+				UserID:    "1",
+			}))
+		}(i)
+	}
+
+	manager := NewManager(nopLogger{})
+	manager.Append("http server", func(ctx context.Context) error {
+		requests.Wait()
+		return nil
+	})
+	manager.Append("delete url queue", WaitDone(stopQueue, queue.Done()))
+	manager.AppendAfterStopped("storage", func(ctx context.Context) error {
+		return urlStorage.Close()
+	})
+	manager.SetReady()
+
+	shutdownCtx, shutdownCancel := context.WithTimeout(ctx, time.Second*10)
+	defer shutdownCancel()
+
+	require.NoError(t, manager.Shutdown(shutdownCtx))
+
+	for i := 0; i < urlsCount; i++ {
+		url, err := urlStorage.GetByShortURL(ctx, fmt.Sprintf("short%d", i))
+		require.NoError(t, err)
+		assert.True(t, url.IsDeleted, url.ShortURL)
+	}
+
+	pendingTasks, err := urlStorage.GetPendingDeleteTasks(ctx, urlsCount)
+	require.NoError(t, err)
+	assert.Empty(t, pendingTasks)
+}
@@ -1,25 +1,51 @@
 package logger
 
 import (
+	"context"
+	"fmt"
+
 	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
 )
 
 // Logger for logging app activity.
 type Logger struct {
 	zapLogger *zap.Logger
+	level     zap.AtomicLevel
 }
 
 // Options for setting up logger.
 type Options struct {
-	Level        string
+	Level string
+	// Format is json or console. Empty format is json in production and console otherwise.
+	Format       string
 	IsProduction bool
 }
 
+// Field is structured field of log entry.
+type Field = zap.Field
+
 // LogInfo Available log levels
 const (
 	LogInfo = "INFO"
 )
 
+// Available log formats.
+const (
+	FormatJSON    = "json"
+	FormatConsole = "console"
+)
+
+// Names of fields shared by log entries of different components.
+const (
+	RequestIDKey = "request_id"
+	ClientIPKey  = "client_ip"
+)
+
+type loggerContextKey struct{}
+
+var nopLogger = &Logger{zapLogger: zap.NewNop(), level: zap.NewAtomicLevel()}
+
 // NewLogger is contructor function to create Logger with Options.
 func NewLogger(options Options) (*Logger, error) {
 	atomicLevel, err := zap.ParseAtomicLevel(options.Level)
@@ -34,6 +60,14 @@ func NewLogger(options Options) (*Logger, error) {
 		cfg = zap.NewProductionConfig()
 	}
 
+	switch options.Format {
+	case "":
+	case FormatJSON, FormatConsole:
+		cfg.Encoding = options.Format
+	default:
+		return nil, fmt.Errorf("unknown log format: %s", options.Format)
+	}
+
 	cfg.Level = atomicLevel
 
 	zl, err := cfg.Build()
@@ -44,10 +78,58 @@ func NewLogger(options Options) (*Logger, error) {
 
 	return &Logger{
 		zapLogger: zl.WithOptions(zap.AddCallerSkip(1)),
+		level:     atomicLevel,
 	}, nil
 }
 
+// Debug logs message, that is useful only for debugging.
+func (l Logger) Debug(msg string, fields ...Field) {
+	l.zapLogger.Debug(msg, fields...)
+}
+
 // Info put message to standard output.
-func (l Logger) Info(msg string) {
-	l.zapLogger.Info(msg)
+func (l Logger) Info(msg string, fields ...Field) {
+	l.zapLogger.Info(msg, fields...)
+}
+
+// Warn logs message about unexpected situation, that is handled.
+func (l Logger) Warn(msg string, fields ...Field) {
+	l.zapLogger.Warn(msg, fields...)
+}
+
+// Error logs message about failed operation.
+func (l Logger) Error(msg string, fields ...Field) {
+	l.zapLogger.Error(msg, fields...)
+}
+
+// With creates child logger, that adds fields to every entry. Child shares level with parent.
+func (l Logger) With(fields ...Field) *Logger {
+	return &Logger{
+		zapLogger: l.zapLogger.With(fields...),
+		level:     l.level,
+	}
+}
+
+// SetLevel changes level of logger at runtime. Level is parsed by zapcore.ParseLevel.
+func (l Logger) SetLevel(level zapcore.Level) {
+	l.level.SetLevel(level)
+}
+
+// Level returns current level of logger.
+func (l Logger) Level() string {
+	return l.level.String()
+}
+
+// NewContext returns context with logger, usually logger of one request.
+func NewContext(ctx context.Context, l *Logger) context.Context {
+	return context.WithValue(ctx, loggerContextKey{}, l)
+}
+
+// FromContext returns logger saved by NewContext. Logger, that discards entries, is returned when there is no logger.
+func FromContext(ctx context.Context) *Logger {
+	if l, ok := ctx.Value(loggerContextKey{}).(*Logger); ok {
+		return l
+	}
+
+	return nopLogger
 }
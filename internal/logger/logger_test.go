@@ -4,6 +4,7 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zapcore"
 )
 
 func TestNewLogger(t *testing.T) {
@@ -46,3 +47,14 @@ func TestLogger_Info(t *testing.T) {
 
 	l.Info("test message")
 }
+
+func TestLogger_SetLevel(t *testing.T) {
+	l, err := NewLogger(Options{
+		Level: LogInfo,
+	})
+	require.NoError(t, err)
+	require.Equal(t, "info", l.Level())
+
+	l.SetLevel(zapcore.WarnLevel)
+	require.Equal(t, "warn", l.Level())
+}
@@ -2,10 +2,12 @@ package middlewares
 
 import (
 	"net/http"
+	"strings"
+	"time"
 
+	"github.com/MowlCoder/go-url-shortener/internal/apierror"
 	"github.com/MowlCoder/go-url-shortener/internal/context"
 	"github.com/MowlCoder/go-url-shortener/internal/jwt"
-	"github.com/MowlCoder/go-url-shortener/pkg/httputil"
 )
 
 type userService interface {
@@ -15,15 +17,67 @@ type userService interface {
 // CookieName is cookie name where store token.
 const CookieName = "token"
 
+// CookieOptions is policy of token cookie.
+type CookieOptions struct {
+	// Domain of cookie, empty domain means host of request.
+	Domain string
+	// SameSite mode of cookie.
+	SameSite http.SameSite
+	// RefreshBefore is time before token expiry, when middleware issues new token.
+	RefreshBefore time.Duration
+	// Secure allows to send cookie only over HTTPS.
+	Secure bool
+}
+
+// ParseSameSite converts lax, strict or none to http.SameSite. Unknown mode is converted to default mode.
+func ParseSameSite(mode string) http.SameSite {
+	switch strings.ToLower(mode) {
+	case "lax":
+		return http.SameSiteLaxMode
+	case "strict":
+		return http.SameSiteStrictMode
+	case "none":
+		return http.SameSiteNoneMode
+	default:
+		return http.SameSiteDefaultMode
+	}
+}
+
+// TokenCookie creates cookie with token, that lives as long as token.
+func (o CookieOptions) TokenCookie(token string) *http.Cookie {
+	return &http.Cookie{
+		Name:     CookieName,
+		Value:    token,
+		Path:     "/",
+		Domain:   o.Domain,
+		MaxAge:   int(jwt.TokenTTL.Seconds()),
+		Expires:  time.Now().Add(jwt.TokenTTL),
+		HttpOnly: true,
+		// browsers reject SameSite=None cookies without Secure
+		Secure:   o.Secure || o.SameSite == http.SameSiteNoneMode,
+		SameSite: o.SameSite,
+	}
+}
+
+// ExpiredCookie creates cookie, that removes token cookie from browser.
+func (o CookieOptions) ExpiredCookie() *http.Cookie {
+	cookie := o.TokenCookie("")
+	cookie.MaxAge = -1
+	cookie.Expires = time.Unix(0, 0)
+
+	return cookie
+}
+
 // AuthMiddleware handle authorization. If user not middleware create token and save in cookie.
 // If user provide valid token, parse token and save user id in request context.
-func AuthMiddleware(handler http.Handler, userService userService) http.Handler {
+// Token, that expires in less than CookieOptions.RefreshBefore, is replaced by new token of the same user.
+func AuthMiddleware(handler http.Handler, userService userService, cookieOptions CookieOptions) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		authHandler(w, r, handler, userService)
+		authHandler(w, r, handler, userService, cookieOptions)
 	})
 }
 
-func authHandler(w http.ResponseWriter, r *http.Request, handler http.Handler, userService userService) {
+func authHandler(w http.ResponseWriter, r *http.Request, handler http.Handler, userService userService, cookieOptions CookieOptions) {
 	var tokenString string
 
 	cookie, err := r.Cookie(CookieName)
@@ -32,14 +86,11 @@ func authHandler(w http.ResponseWriter, r *http.Request, handler http.Handler, u
 		tokenString, err = jwt.GenerateToken(userService.GenerateUniqueID())
 
 		if err != nil {
-			httputil.SendStatusCode(w, http.StatusInternalServerError)
+			apierror.Write(w, r, err)
 			return
 		}
 
-		http.SetCookie(w, &http.Cookie{
-			Name:  CookieName,
-			Value: tokenString,
-		})
+		http.SetCookie(w, cookieOptions.TokenCookie(tokenString))
 	} else {
 		tokenString = cookie.Value
 	}
@@ -47,10 +98,21 @@ func authHandler(w http.ResponseWriter, r *http.Request, handler http.Handler, u
 	jwtClaim, err := jwt.ParseToken(tokenString)
 
 	if err != nil {
-		httputil.SendStatusCode(w, http.StatusUnauthorized)
+		apierror.Write(w, r, apierror.New(http.StatusUnauthorized, apierror.CodeUnauthorized, "invalid token"))
 		return
 	}
 
+	if jwtClaim.ExpiresAt != nil && time.Until(jwtClaim.ExpiresAt.Time) < cookieOptions.RefreshBefore {
+		tokenString, err = jwt.GenerateToken(jwtClaim.UserID)
+
+		if err != nil {
+			apierror.Write(w, r, err)
+			return
+		}
+
+		http.SetCookie(w, cookieOptions.TokenCookie(tokenString))
+	}
+
 	ctx := context.SetUserIDToContext(r.Context(), jwtClaim.UserID)
 
 	handler.ServeHTTP(w, r.WithContext(ctx))
@@ -5,9 +5,13 @@ import (
 	"net/http/httptest"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
+	"github.com/MowlCoder/go-url-shortener/internal/context"
+	"github.com/MowlCoder/go-url-shortener/internal/jwt"
 	"github.com/MowlCoder/go-url-shortener/internal/services"
 )
 
@@ -19,7 +23,7 @@ func TestAuthMiddleware(t *testing.T) {
 		w := httptest.NewRecorder()
 		authHandler(w, request, http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
 			w.WriteString("OK")
-		}), userService)
+		}), userService, CookieOptions{SameSite: http.SameSiteLaxMode, Secure: true})
 
 		res := w.Result()
 		isFoundTokenCookie := false
@@ -29,9 +33,86 @@ func TestAuthMiddleware(t *testing.T) {
 		for _, cookie := range res.Cookies() {
 			if cookie.Name == "token" {
 				isFoundTokenCookie = true
+
+				assert.Equal(t, "/", cookie.Path)
+				assert.Equal(t, int(jwt.TokenTTL.Seconds()), cookie.MaxAge)
+				assert.True(t, cookie.HttpOnly)
+				assert.True(t, cookie.Secure)
+				assert.Equal(t, http.SameSiteLaxMode, cookie.SameSite)
 			}
 		}
 
 		assert.True(t, isFoundTokenCookie)
 	})
+
+	t.Run("refresh token near expiry", func(t *testing.T) {
+		userService := services.NewUserService()
+
+		token, err := jwt.GenerateToken("user")
+		require.NoError(t, err)
+
+		type TestCase struct {
+			Name          string
+			RefreshBefore time.Duration
+			IsRefreshed   bool
+		}
+
+		testCases := []TestCase{
+			{
+				Name:          "token is far from expiry",
+				RefreshBefore: time.Hour,
+			},
+			{
+				Name:          "token is near expiry",
+				RefreshBefore: jwt.TokenTTL + time.Hour,
+				IsRefreshed:   true,
+			},
+		}
+
+		for _, testCase := range testCases {
+			t.Run(testCase.Name, func(t *testing.T) {
+				request := httptest.NewRequest(http.MethodGet, "/api/user/urls", nil)
+				request.AddCookie(&http.Cookie{Name: CookieName, Value: token})
+				w := httptest.NewRecorder()
+
+				var userID string
+
+				authHandler(w, request, http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+					userID, _ = context.GetUserIDFromContext(request.Context())
+				}), userService, CookieOptions{RefreshBefore: testCase.RefreshBefore})
+
+				res := w.Result()
+				defer res.Body.Close()
+
+				assert.Equal(t, "user", userID)
+
+				if !testCase.IsRefreshed {
+					assert.Empty(t, res.Cookies())
+					return
+				}
+
+				require.Len(t, res.Cookies(), 1)
+
+				claims, err := jwt.ParseToken(res.Cookies()[0].Value)
+				require.NoError(t, err)
+				assert.Equal(t, "user", claims.UserID)
+			})
+		}
+	})
+}
+
+func TestCookieOptions(t *testing.T) {
+	options := CookieOptions{Domain: "example.com", SameSite: ParseSameSite("None")}
+
+	cookie := options.TokenCookie("token")
+	assert.Equal(t, "example.com", cookie.Domain)
+	assert.Equal(t, http.SameSiteNoneMode, cookie.SameSite)
+	assert.True(t, cookie.Secure)
+
+	expired := options.ExpiredCookie()
+	assert.Empty(t, expired.Value)
+	assert.Equal(t, -1, expired.MaxAge)
+
+	assert.Equal(t, http.SameSiteStrictMode, ParseSameSite("strict"))
+	assert.Equal(t, http.SameSiteDefaultMode, ParseSameSite("unknown"))
 }
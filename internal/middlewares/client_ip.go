@@ -0,0 +1,24 @@
+package middlewares
+
+import (
+	"net"
+	"net/http"
+
+	"github.com/MowlCoder/go-url-shortener/internal/context"
+)
+
+type clientIPResolver interface {
+	FromRequest(r *http.Request) net.IP
+}
+
+// ClientIPMiddleware resolves ip address of client and saves it in request context,
+// so every handler and middleware uses the same address.
+func ClientIPMiddleware(resolver clientIPResolver) func(next http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			ctx := context.SetClientIPToContext(r.Context(), resolver.FromRequest(r))
+
+			next.ServeHTTP(w, r.WithContext(ctx))
+		})
+	}
+}
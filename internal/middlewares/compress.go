@@ -6,6 +6,8 @@ import (
 	"io"
 	"net/http"
 	"strings"
+
+	"github.com/MowlCoder/go-url-shortener/internal/apierror"
 )
 
 // CompressWriter responsible for compressing response of specified content-type.
@@ -14,11 +16,14 @@ type CompressWriter struct {
 	http.ResponseWriter
 	contentTypes map[string]struct{}
 	encodeFormat string
+	wroteHeader  bool
 }
 
 // WriteHeader if content type is not compressible just writing header.
 // If compressible, then adding Content-Encoding header as addition.
 func (cw *CompressWriter) WriteHeader(code int) {
+	cw.wroteHeader = true
+
 	if !cw.isCompressible() {
 		cw.ResponseWriter.WriteHeader(code)
 		return
@@ -29,11 +34,26 @@ func (cw *CompressWriter) WriteHeader(code int) {
 }
 
 // Write writes bytes to writer. Writer is base http writer if response content-type is not compressible.
-// If compressible, then writer is given io.Writer.
+// If compressible, then writer is given io.Writer. Header is written with 200 status, if it was not written before.
 func (cw *CompressWriter) Write(b []byte) (int, error) {
+	if !cw.wroteHeader {
+		cw.WriteHeader(http.StatusOK)
+	}
+
 	return cw.writer().Write(b)
 }
 
+// Flush flushes compressed data and sends buffered data to the client, so streaming responses work through middleware.
+func (cw *CompressWriter) Flush() {
+	if f, ok := cw.writer().(interface{ Flush() error }); ok {
+		f.Flush()
+	}
+
+	if f, ok := cw.ResponseWriter.(http.Flusher); ok {
+		f.Flush()
+	}
+}
+
 // Close closes writer.
 func (cw *CompressWriter) Close() error {
 	if c, ok := cw.writer().(io.WriteCloser); ok {
@@ -103,7 +123,7 @@ func (compressor CompressMiddleware) Handler(next http.Handler) http.Handler {
 			gr, err := gzip.NewReader(r.Body)
 
 			if err != nil {
-				http.Error(w, err.Error(), http.StatusInternalServerError)
+				apierror.Write(w, r, apierror.InvalidRequest(err))
 				return
 			}
 
@@ -50,4 +50,21 @@ func TestGzipCompress(t *testing.T) {
 
 		assert.Equal(t, "gzip", compress.Header().Get("Content-Encoding"))
 	})
+
+	t.Run("write without header", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		gz, err := gzip.NewWriterLevel(w, gzip.BestSpeed)
+
+		require.NoError(t, err)
+
+		compress := newGzipWriter(w, gz)
+
+		compress.Header().Set("Content-Type", "application/json")
+		_, err = compress.Write([]byte(`{}`))
+		require.NoError(t, err)
+		compress.Close()
+
+		assert.Equal(t, 200, w.Code)
+		assert.Equal(t, "gzip", w.Header().Get("Content-Encoding"))
+	})
 }
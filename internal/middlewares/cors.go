@@ -0,0 +1,108 @@
+package middlewares
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/MowlCoder/go-url-shortener/internal/requestid"
+)
+
+// AllowedOrigins is set of origins, which browsers are allowed to call api from. "*" allows any origin,
+// but without credentials.
+type AllowedOrigins map[string]struct{}
+
+// ParseAllowedOrigins parses comma separated list of origins.
+func ParseAllowedOrigins(origins string) AllowedOrigins {
+	allowedOrigins := make(AllowedOrigins)
+
+	for _, origin := range strings.Split(origins, ",") {
+		if origin = strings.TrimSpace(origin); origin != "" {
+			allowedOrigins[origin] = struct{}{}
+		}
+	}
+
+	return allowedOrigins
+}
+
+// Allows reports whether requests from origin are allowed.
+func (o AllowedOrigins) Allows(origin string) bool {
+	return o.AllowsAny() || o.Lists(origin)
+}
+
+// AllowsAny reports whether requests from any origin are allowed by "*".
+func (o AllowedOrigins) AllowsAny() bool {
+	_, ok := o["*"]
+	return ok
+}
+
+// Lists reports whether origin is listed explicitly, "*" is not taken into account.
+func (o AllowedOrigins) Lists(origin string) bool {
+	_, ok := o[origin]
+	return ok
+}
+
+// CORSOptions configure CORSMiddleware.
+type CORSOptions struct {
+	// AllowedOrigins is comma separated list of allowed origins.
+	AllowedOrigins string
+	// AllowedMethods is comma separated list of methods allowed in cross-origin requests.
+	AllowedMethods string
+	// MaxAge is how long browser can cache result of preflight request. Zero disables caching header.
+	MaxAge time.Duration
+	// AllowCredentials allows browser to send token cookie in cross-origin requests from listed origins.
+	AllowCredentials bool
+}
+
+// CORSMiddleware returns middleware, that adds CORS headers to responses for allowed origins
+// and answers preflight requests. Requests from other origins are passed without CORS headers,
+// so browser blocks them.
+func CORSMiddleware(options CORSOptions) func(next http.Handler) http.Handler {
+	allowedOrigins := ParseAllowedOrigins(options.AllowedOrigins)
+	allowedMethods := strings.ToUpper(strings.ReplaceAll(options.AllowedMethods, " ", ""))
+	maxAge := strconv.Itoa(int(options.MaxAge.Seconds()))
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			origin := r.Header.Get("Origin")
+			w.Header().Add("Vary", "Origin")
+
+			if origin == "" || !allowedOrigins.Allows(origin) {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			// origin allowed only by "*" gets literal "*" without credentials, so browser never sends
+			// token cookie to the server from page of any site
+			if allowedOrigins.Lists(origin) {
+				w.Header().Set("Access-Control-Allow-Origin", origin)
+				if options.AllowCredentials {
+					w.Header().Set("Access-Control-Allow-Credentials", "true")
+				}
+			} else {
+				w.Header().Set("Access-Control-Allow-Origin", "*")
+			}
+
+			if r.Method != http.MethodOptions || r.Header.Get("Access-Control-Request-Method") == "" {
+				w.Header().Set("Access-Control-Expose-Headers", "Location, Content-Disposition, "+requestid.Header)
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			w.Header().Add("Vary", "Access-Control-Request-Method")
+			w.Header().Add("Vary", "Access-Control-Request-Headers")
+			w.Header().Set("Access-Control-Allow-Methods", allowedMethods)
+
+			if requestHeaders := r.Header.Get("Access-Control-Request-Headers"); requestHeaders != "" {
+				w.Header().Set("Access-Control-Allow-Headers", requestHeaders)
+			}
+
+			if options.MaxAge > 0 {
+				w.Header().Set("Access-Control-Max-Age", maxAge)
+			}
+
+			w.WriteHeader(http.StatusNoContent)
+		})
+	}
+}
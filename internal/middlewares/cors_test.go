@@ -0,0 +1,158 @@
+package middlewares
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCORSMiddleware(t *testing.T) {
+	middleware := CORSMiddleware(CORSOptions{
+		AllowedOrigins:   "https://app.example.com, https://admin.example.com",
+		AllowedMethods:   "get, post",
+		MaxAge:           time.Minute,
+		AllowCredentials: true,
+	})
+
+	type TestCase struct {
+		Name            string
+		Method          string
+		Origin          string
+		RequestMethod   string
+		ExpectedOrigin  string
+		ExpectedMethods string
+		ExpectedMaxAge  string
+		ExpectedCode    int
+		IsNextCalled    bool
+	}
+
+	testCases := []TestCase{
+		{
+			Name:         "same origin request",
+			Method:       http.MethodGet,
+			ExpectedCode: http.StatusOK,
+			IsNextCalled: true,
+		},
+		{
+			Name:           "allowed origin",
+			Method:         http.MethodPost,
+			Origin:         "https://admin.example.com",
+			ExpectedOrigin: "https://admin.example.com",
+			ExpectedCode:   http.StatusOK,
+			IsNextCalled:   true,
+		},
+		{
+			Name:         "not allowed origin",
+			Method:       http.MethodPost,
+			Origin:       "https://evil.example.com",
+			ExpectedCode: http.StatusOK,
+			IsNextCalled: true,
+		},
+		{
+			Name:            "preflight",
+			Method:          http.MethodOptions,
+			Origin:          "https://app.example.com",
+			RequestMethod:   http.MethodDelete,
+			ExpectedOrigin:  "https://app.example.com",
+			ExpectedMethods: "GET,POST",
+			ExpectedMaxAge:  "60",
+			ExpectedCode:    http.StatusNoContent,
+		},
+		{
+			Name:          "preflight from not allowed origin",
+			Method:        http.MethodOptions,
+			Origin:        "https://evil.example.com",
+			RequestMethod: http.MethodDelete,
+			ExpectedCode:  http.StatusOK,
+			IsNextCalled:  true,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			isNextCalled := false
+			handler := middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				isNextCalled = true
+			}))
+
+			r := httptest.NewRequest(testCase.Method, "/api/user/urls", nil)
+			if testCase.Origin != "" {
+				r.Header.Set("Origin", testCase.Origin)
+			}
+
+			if testCase.RequestMethod != "" {
+				r.Header.Set("Access-Control-Request-Method", testCase.RequestMethod)
+			}
+
+			w := httptest.NewRecorder()
+			handler.ServeHTTP(w, r)
+
+			assert.Equal(t, testCase.ExpectedCode, w.Code)
+			assert.Equal(t, testCase.IsNextCalled, isNextCalled)
+			assert.Equal(t, testCase.ExpectedOrigin, w.Header().Get("Access-Control-Allow-Origin"))
+			assert.Equal(t, testCase.ExpectedMethods, w.Header().Get("Access-Control-Allow-Methods"))
+			assert.Equal(t, testCase.ExpectedMaxAge, w.Header().Get("Access-Control-Max-Age"))
+
+			if testCase.ExpectedOrigin != "" {
+				assert.Equal(t, "true", w.Header().Get("Access-Control-Allow-Credentials"))
+			}
+
+			if testCase.ExpectedOrigin != "" && testCase.IsNextCalled {
+				assert.Equal(t, "Location, Content-Disposition, X-Request-ID", w.Header().Get("Access-Control-Expose-Headers"))
+			}
+		})
+	}
+}
+
+func TestCORSMiddleware_AnyOrigin(t *testing.T) {
+	middleware := CORSMiddleware(CORSOptions{
+		AllowedOrigins:   "https://app.example.com, *",
+		AllowedMethods:   "GET",
+		AllowCredentials: true,
+	})
+	handler := middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+
+	testCases := []struct {
+		Name                string
+		Origin              string
+		ExpectedOrigin      string
+		ExpectedCredentials string
+	}{
+		{
+			Name:                "listed origin gets credentials",
+			Origin:              "https://app.example.com",
+			ExpectedOrigin:      "https://app.example.com",
+			ExpectedCredentials: "true",
+		},
+		{
+			Name:           "other origin gets * without credentials",
+			Origin:         "https://evil.example.com",
+			ExpectedOrigin: "*",
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			r := httptest.NewRequest(http.MethodGet, "/api/user/urls", nil)
+			r.Header.Set("Origin", testCase.Origin)
+
+			w := httptest.NewRecorder()
+			handler.ServeHTTP(w, r)
+
+			assert.Equal(t, testCase.ExpectedOrigin, w.Header().Get("Access-Control-Allow-Origin"))
+			assert.Equal(t, testCase.ExpectedCredentials, w.Header().Get("Access-Control-Allow-Credentials"))
+		})
+	}
+}
+
+func TestAllowedOrigins(t *testing.T) {
+	assert.True(t, ParseAllowedOrigins("*").Allows("https://any.example.com"))
+	assert.True(t, ParseAllowedOrigins(" https://a.com ,https://b.com").Allows("https://b.com"))
+	assert.False(t, ParseAllowedOrigins("https://a.com").Allows("https://b.com"))
+	assert.False(t, ParseAllowedOrigins("").Allows("https://a.com"))
+	assert.False(t, ParseAllowedOrigins("*").Lists("https://any.example.com"))
+	assert.True(t, ParseAllowedOrigins("*, https://a.com").Lists("https://a.com"))
+}
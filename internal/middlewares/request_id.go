@@ -0,0 +1,20 @@
+package middlewares
+
+import (
+	"net/http"
+
+	"github.com/MowlCoder/go-url-shortener/internal/context"
+	"github.com/MowlCoder/go-url-shortener/internal/requestid"
+)
+
+// RequestIDMiddleware takes request id from X-Request-ID header or generates new one, saves it in request context
+// and sends it back in X-Request-ID response header.
+func RequestIDMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requestID := requestid.FromIncoming(r.Header.Get(requestid.Header))
+
+		w.Header().Set(requestid.Header, requestID)
+
+		next.ServeHTTP(w, r.WithContext(context.SetRequestIDToContext(r.Context(), requestID)))
+	})
+}
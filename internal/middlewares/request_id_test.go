@@ -0,0 +1,42 @@
+package middlewares
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/MowlCoder/go-url-shortener/internal/context"
+)
+
+func TestRequestIDMiddleware(t *testing.T) {
+	var requestID string
+
+	handler := RequestIDMiddleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requestID = context.GetRequestIDFromContext(r.Context())
+	}))
+
+	t.Run("id from client", func(t *testing.T) {
+		r := httptest.NewRequest(http.MethodGet, "/", nil)
+		r.Header.Set("X-Request-ID", "client-id-1")
+		w := httptest.NewRecorder()
+
+		handler.ServeHTTP(w, r)
+
+		assert.Equal(t, "client-id-1", requestID)
+		assert.Equal(t, "client-id-1", w.Header().Get("X-Request-ID"))
+	})
+
+	t.Run("generated id", func(t *testing.T) {
+		r := httptest.NewRequest(http.MethodGet, "/", nil)
+		r.Header.Set("X-Request-ID", "bad id")
+		w := httptest.NewRecorder()
+
+		handler.ServeHTTP(w, r)
+
+		assert.NotEmpty(t, requestID)
+		assert.NotEqual(t, "bad id", requestID)
+		assert.Equal(t, requestID, w.Header().Get("X-Request-ID"))
+	})
+}
@@ -0,0 +1,40 @@
+package middlewares
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// SecurityHeadersOptions configure SecurityHeadersMiddleware.
+type SecurityHeadersOptions struct {
+	// ContentSecurityPolicy is value of Content-Security-Policy header, empty value disables header.
+	ContentSecurityPolicy string
+	// HSTSMaxAge is max-age of Strict-Transport-Security header.
+	HSTSMaxAge time.Duration
+	// EnableHSTS enables Strict-Transport-Security header, it must be enabled only when server is served over HTTPS.
+	EnableHSTS bool
+}
+
+// SecurityHeadersMiddleware returns middleware, that adds security headers to every response.
+func SecurityHeadersMiddleware(options SecurityHeadersOptions) func(next http.Handler) http.Handler {
+	hsts := fmt.Sprintf("max-age=%d; includeSubDomains", int(options.HSTSMaxAge.Seconds()))
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("X-Content-Type-Options", "nosniff")
+			w.Header().Set("X-Frame-Options", "DENY")
+			w.Header().Set("Referrer-Policy", "strict-origin-when-cross-origin")
+
+			if options.ContentSecurityPolicy != "" {
+				w.Header().Set("Content-Security-Policy", options.ContentSecurityPolicy)
+			}
+
+			if options.EnableHSTS && options.HSTSMaxAge > 0 {
+				w.Header().Set("Strict-Transport-Security", hsts)
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
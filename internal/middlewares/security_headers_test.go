@@ -0,0 +1,51 @@
+package middlewares
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSecurityHeadersMiddleware(t *testing.T) {
+	type TestCase struct {
+		Name         string
+		Options      SecurityHeadersOptions
+		ExpectedCSP  string
+		ExpectedHSTS string
+	}
+
+	testCases := []TestCase{
+		{
+			Name: "http",
+			Options: SecurityHeadersOptions{
+				ContentSecurityPolicy: "default-src 'none'",
+				HSTSMaxAge:            time.Hour,
+			},
+			ExpectedCSP: "default-src 'none'",
+		},
+		{
+			Name: "https",
+			Options: SecurityHeadersOptions{
+				HSTSMaxAge: time.Hour,
+				EnableHSTS: true,
+			},
+			ExpectedHSTS: "max-age=3600; includeSubDomains",
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			handler := SecurityHeadersMiddleware(testCase.Options)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+
+			w := httptest.NewRecorder()
+			handler.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/", nil))
+
+			assert.Equal(t, "nosniff", w.Header().Get("X-Content-Type-Options"))
+			assert.Equal(t, testCase.ExpectedCSP, w.Header().Get("Content-Security-Policy"))
+			assert.Equal(t, testCase.ExpectedHSTS, w.Header().Get("Strict-Transport-Security"))
+		})
+	}
+}
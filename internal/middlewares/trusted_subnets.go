@@ -1,36 +1,25 @@
 package middlewares
 
 import (
-	"log"
 	"net"
 	"net/http"
 
-	"github.com/MowlCoder/go-url-shortener/pkg/httputil"
+	"github.com/MowlCoder/go-url-shortener/internal/apierror"
+	"github.com/MowlCoder/go-url-shortener/internal/context"
 )
 
-// TrustedSubnetsMiddleware return middleware that not allow to request handlers from not trusted ip
-func TrustedSubnetsMiddleware(trustedSubnet string) func(next http.Handler) http.Handler {
-	var ipNet *net.IPNet
-	var err error
-
-	if trustedSubnet != "" {
-		_, ipNet, err = net.ParseCIDR(trustedSubnet)
-		if err != nil {
-			log.Fatal("initialize trusted subnet middleware:", err)
-		}
-	}
+type trustedSubnet interface {
+	Contains(ip net.IP) bool
+}
 
+// TrustedSubnetsMiddleware return middleware that not allow to request handlers from not trusted ip.
+// Client ip is taken from context, where it is saved by ClientIPMiddleware.
+// Subnets are checked on every request, so they can be changed without restart.
+func TrustedSubnetsMiddleware(trustedSubnet trustedSubnet) func(next http.Handler) http.Handler {
 	return func(next http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-			if trustedSubnet == "" {
-				httputil.SendStatusCode(w, http.StatusForbidden)
-				return
-			}
-
-			userIP := net.ParseIP(r.Header.Get("X-Real-IP"))
-
-			if !ipNet.Contains(userIP) {
-				httputil.SendStatusCode(w, http.StatusForbidden)
+			if !trustedSubnet.Contains(context.GetClientIPFromContext(r.Context())) {
+				apierror.Write(w, r, apierror.ErrForbidden)
 				return
 			}
 
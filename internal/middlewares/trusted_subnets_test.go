@@ -0,0 +1,54 @@
+package middlewares
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/MowlCoder/go-url-shortener/internal/clientip"
+	"github.com/MowlCoder/go-url-shortener/internal/subnets"
+)
+
+func TestTrustedSubnetsMiddleware(t *testing.T) {
+	trustedSubnet, err := subnets.NewTrusted("192.168.1.0/24, fd00::/8")
+	require.NoError(t, err)
+
+	// httptest requests come from 192.0.2.1
+	trustedProxies, err := subnets.NewTrusted("192.0.2.1")
+	require.NoError(t, err)
+
+	handler := ClientIPMiddleware(clientip.NewResolver(trustedProxies))(
+		TrustedSubnetsMiddleware(trustedSubnet)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusOK)
+		})),
+	)
+
+	doRequest := func(realIP string) int {
+		r := httptest.NewRequest(http.MethodGet, "/api/internal/stats", nil)
+		if realIP != "" {
+			r.Header.Set("X-Real-IP", realIP)
+		}
+		w := httptest.NewRecorder()
+
+		handler.ServeHTTP(w, r)
+
+		return w.Code
+	}
+
+	assert.Equal(t, http.StatusOK, doRequest("192.168.1.10"))
+	assert.Equal(t, http.StatusOK, doRequest("fd00::1"))
+	assert.Equal(t, http.StatusForbidden, doRequest("10.0.0.1"))
+	assert.Equal(t, http.StatusForbidden, doRequest(""))
+
+	// subnet changed at runtime is applied to next request
+	require.NoError(t, trustedSubnet.Update("10.0.0.0/8"))
+	assert.Equal(t, http.StatusOK, doRequest("10.0.0.1"))
+	assert.Equal(t, http.StatusForbidden, doRequest("192.168.1.10"))
+
+	// header of not trusted proxy is ignored
+	require.NoError(t, trustedProxies.Update(""))
+	assert.Equal(t, http.StatusForbidden, doRequest("10.0.0.1"))
+}
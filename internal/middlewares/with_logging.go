@@ -1,14 +1,14 @@
 package middlewares
 
 import (
-	"fmt"
 	"net/http"
 	"time"
-)
 
-type logger interface {
-	Info(msg string)
-}
+	"go.uber.org/zap"
+
+	"github.com/MowlCoder/go-url-shortener/internal/context"
+	"github.com/MowlCoder/go-url-shortener/internal/logger"
+)
 
 type (
 	responseData struct {
@@ -35,13 +35,21 @@ func (r *loggingResponseWriter) WriteHeader(statusCode int) {
 	r.responseData.status = statusCode
 }
 
+// Flush sends buffered data to the client, so streaming responses work through middleware.
+func (r *loggingResponseWriter) Flush() {
+	if f, ok := r.ResponseWriter.(http.Flusher); ok {
+		f.Flush()
+	}
+}
+
 // WithLogging is middleware to log request method, path and response size, status code, execution time.
-func WithLogging(handler http.Handler, logger logger) http.Handler {
+// Logger with request id and client ip is saved in request context, so handlers log entries linked to request.
+func WithLogging(handler http.Handler, customLogger *logger.Logger) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		start := time.Now()
 
 		responseData := &responseData{
-			status: 0,
+			status: http.StatusOK,
 			size:   0,
 		}
 		lw := loggingResponseWriter{
@@ -49,16 +57,20 @@ func WithLogging(handler http.Handler, logger logger) http.Handler {
 			responseData:   responseData,
 		}
 
-		handler.ServeHTTP(&lw, r)
-		duration := time.Since(start)
+		requestLogger := customLogger.With(
+			zap.String(logger.RequestIDKey, context.GetRequestIDFromContext(r.Context())),
+			zap.String(logger.ClientIPKey, context.GetClientIPFromContext(r.Context()).String()),
+		)
+
+		handler.ServeHTTP(&lw, r.WithContext(logger.NewContext(r.Context(), requestLogger)))
 
-		logger.Info(fmt.Sprintf(
-			"%s %s - %d %dB in %s",
-			r.Method,
-			r.RequestURI,
-			lw.responseData.status,
-			lw.responseData.size,
-			duration.String()),
+		requestLogger.Info(
+			"request",
+			zap.String("method", r.Method),
+			zap.String("uri", r.RequestURI),
+			zap.Int("status", lw.responseData.status),
+			zap.Int("size", lw.responseData.size),
+			zap.Duration("duration", time.Since(start)),
 		)
 	})
 }
@@ -0,0 +1,268 @@
+// Package migrate moves links between URL storages.
+package migrate
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+
+	"go.uber.org/zap"
+
+	"github.com/MowlCoder/go-url-shortener/internal/domain"
+)
+
+// DefaultBatchSize is default count of links read from source and written to destination at once.
+const DefaultBatchSize = 500
+
+var (
+	// ErrVerificationFailed returned when count of urls in destination storage did not grow by count of imported links.
+	ErrVerificationFailed = errors.New("migration verification failed")
+	// ErrCheckpointMismatch returned when checkpoint was saved by migration between other storages.
+	ErrCheckpointMismatch = errors.New("checkpoint belongs to migration between other storages")
+)
+
+type sourceStorage interface {
+	GetURLsAfter(ctx context.Context, afterShortURL string, limit int) ([]domain.ShortenedURL, error)
+	GetInternalStats(ctx context.Context) (*domain.InternalStats, error)
+}
+
+type destinationStorage interface {
+	ImportURLs(ctx context.Context, urls []domain.ShortenedURL) ([]string, error)
+	GetInternalStats(ctx context.Context) (*domain.InternalStats, error)
+}
+
+type logger interface {
+	Info(msg string, fields ...zap.Field)
+}
+
+// Options for setting up Migrator.
+type Options struct {
+	// CheckpointPath is path to file where progress is saved after every batch.
+	// If file exists, migration continues from saved position. Empty path disables checkpoints.
+	CheckpointPath string
+	// Source and Destination identify storages, e.g. by file path or database DSN. They are saved
+	// to checkpoint as hashes, so checkpoint is not used by migration between other storages.
+	Source      string
+	Destination string
+	BatchSize   int
+	// DryRun only reads links from source without writing to destination.
+	DryRun bool
+}
+
+// Result of migration.
+type Result struct {
+	SourceStats      *domain.InternalStats
+	DestinationStats *domain.InternalStats
+	// Read is count of links read from source, including links read before resume.
+	Read int
+	// Imported is count of links saved to destination.
+	Imported int
+	// Skipped is count of links, that already existed in destination.
+	Skipped int
+	// SkippedURLs are short urls of links skipped in this run, links skipped before resume are only counted.
+	SkippedURLs []string
+	// DestinationURLsBefore is count of urls in destination before migration was started.
+	DestinationURLsBefore int
+}
+
+type checkpoint struct {
+	Source       string `json:"source"`
+	Destination  string `json:"destination"`
+	LastShortURL string `json:"last_short_url"`
+	Read         int    `json:"read"`
+	Imported     int    `json:"imported"`
+	Skipped      int    `json:"skipped"`
+	// DestinationURLs is count of urls in destination before migration was started.
+	DestinationURLs int `json:"destination_urls"`
+}
+
+// Migrator streams all links from source storage to destination storage.
+type Migrator struct {
+	source      sourceStorage
+	destination destinationStorage
+	logger      logger
+	options     Options
+}
+
+// NewMigrator is constructor function to create Migrator.
+func NewMigrator(source sourceStorage, destination destinationStorage, logger logger, options Options) *Migrator {
+	if options.BatchSize <= 0 {
+		options.BatchSize = DefaultBatchSize
+	}
+
+	return &Migrator{
+		source:      source,
+		destination: destination,
+		logger:      logger,
+		options:     options,
+	}
+}
+
+// Run migrates links batch by batch and verifies via internal stats at the end, that destination grew
+// by count of imported links. Links, that already existed in destination, are skipped and reported in result.
+// Checkpoint is removed after successful migration.
+func (m *Migrator) Run(ctx context.Context) (*Result, error) {
+	progress, err := m.loadCheckpoint()
+	if err != nil {
+		return nil, err
+	}
+
+	if progress.LastShortURL != "" {
+		m.logger.Info("resume migration", zap.String("after", progress.LastShortURL), zap.Int("read", progress.Read))
+	} else if !m.options.DryRun {
+		destinationStats, err := m.destination.GetInternalStats(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("get destination stats: %w", err)
+		}
+
+		progress.DestinationURLs = destinationStats.URLs
+	}
+
+	skippedURLs := make([]string, 0)
+
+	for {
+		urls, err := m.source.GetURLsAfter(ctx, progress.LastShortURL, m.options.BatchSize)
+		if err != nil {
+			return nil, fmt.Errorf("read source: %w", err)
+		}
+
+		if len(urls) == 0 {
+			break
+		}
+
+		var skipped []string
+
+		if !m.options.DryRun {
+			skipped, err = m.destination.ImportURLs(ctx, urls)
+			if err != nil {
+				return nil, fmt.Errorf("write destination: %w", err)
+			}
+		}
+
+		progress.LastShortURL = urls[len(urls)-1].ShortURL
+		progress.Read += len(urls)
+		progress.Imported += len(urls) - len(skipped)
+		progress.Skipped += len(skipped)
+		skippedURLs = append(skippedURLs, skipped...)
+
+		if len(skipped) > 0 {
+			m.logger.Info("links already exist in destination", zap.Strings("short_urls", skipped))
+		}
+
+		if err := m.saveCheckpoint(progress); err != nil {
+			return nil, err
+		}
+
+		m.logger.Info("links migrated", zap.Int("imported", progress.Imported), zap.Int("skipped", progress.Skipped))
+
+		if len(urls) < m.options.BatchSize {
+			break
+		}
+	}
+
+	result := &Result{
+		Read:                  progress.Read,
+		Imported:              progress.Imported,
+		Skipped:               progress.Skipped,
+		SkippedURLs:           skippedURLs,
+		DestinationURLsBefore: progress.DestinationURLs,
+	}
+
+	if result.SourceStats, err = m.source.GetInternalStats(ctx); err != nil {
+		return nil, fmt.Errorf("get source stats: %w", err)
+	}
+
+	if m.options.DryRun {
+		return result, nil
+	}
+
+	if result.DestinationStats, err = m.destination.GetInternalStats(ctx); err != nil {
+		return nil, fmt.Errorf("get destination stats: %w", err)
+	}
+
+	if err := m.removeCheckpoint(); err != nil {
+		return nil, err
+	}
+
+	// destination can have own urls, so only urls added by migration are compared
+	if added := result.DestinationStats.URLs - result.DestinationURLsBefore; added != result.Imported {
+		return result, fmt.Errorf(
+			"%w: %d links are imported, but destination has %d new urls",
+			ErrVerificationFailed, result.Imported, added,
+		)
+	}
+
+	return result, nil
+}
+
+func (m *Migrator) loadCheckpoint() (checkpoint, error) {
+	progress := checkpoint{
+		Source:      storageFingerprint(m.options.Source),
+		Destination: storageFingerprint(m.options.Destination),
+	}
+
+	if m.options.CheckpointPath == "" || m.options.DryRun {
+		return progress, nil
+	}
+
+	rawCheckpoint, err := os.ReadFile(m.options.CheckpointPath)
+	if errors.Is(err, os.ErrNotExist) {
+		return progress, nil
+	}
+
+	if err != nil {
+		return progress, err
+	}
+
+	saved := checkpoint{}
+	if err := json.Unmarshal(rawCheckpoint, &saved); err != nil {
+		return progress, fmt.Errorf("parse checkpoint: %w", err)
+	}
+
+	if saved.Source != progress.Source || saved.Destination != progress.Destination {
+		return progress, fmt.Errorf("%w: remove %s to start migration from the beginning", ErrCheckpointMismatch, m.options.CheckpointPath)
+	}
+
+	return saved, nil
+}
+
+// storageFingerprint returns hash of storage identifier, so credentials from DSN are not written to checkpoint.
+func storageFingerprint(storage string) string {
+	hash := sha256.Sum256([]byte(storage))
+	return hex.EncodeToString(hash[:])
+}
+
+func (m *Migrator) saveCheckpoint(progress checkpoint) error {
+	if m.options.CheckpointPath == "" || m.options.DryRun {
+		return nil
+	}
+
+	rawCheckpoint, err := json.Marshal(progress)
+	if err != nil {
+		return err
+	}
+
+	// write to temporary file and rename it, so checkpoint is never left half written
+	tmpPath := m.options.CheckpointPath + ".tmp"
+	if err := os.WriteFile(tmpPath, rawCheckpoint, 0644); err != nil {
+		return err
+	}
+
+	return os.Rename(tmpPath, m.options.CheckpointPath)
+}
+
+func (m *Migrator) removeCheckpoint() error {
+	if m.options.CheckpointPath == "" {
+		return nil
+	}
+
+	if err := os.Remove(m.options.CheckpointPath); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,204 @@
+package migrate
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/MowlCoder/go-url-shortener/internal/domain"
+	"github.com/MowlCoder/go-url-shortener/internal/storage"
+)
+
+type nopLogger struct{}
+
+func (nopLogger) Info(string, ...zap.Field) {}
+
+// failingDestination fails after given count of import calls.
+type failingDestination struct {
+	*storage.InMemoryStorage
+	failAfter int
+	calls     int
+}
+
+func (d *failingDestination) ImportURLs(ctx context.Context, urls []domain.ShortenedURL) ([]string, error) {
+	d.calls++
+
+	if d.calls > d.failAfter {
+		return nil, errors.New("connection refused")
+	}
+
+	return d.InMemoryStorage.ImportURLs(ctx, urls)
+}
+
+// lostWritesDestination reports all urls as imported, but saves nothing.
+type lostWritesDestination struct {
+	*storage.InMemoryStorage
+}
+
+func (d *lostWritesDestination) ImportURLs(ctx context.Context, urls []domain.ShortenedURL) ([]string, error) {
+	return nil, nil
+}
+
+func makeSourceStorage(t *testing.T, count int) *storage.InMemoryStorage {
+	t.Helper()
+
+	source, err := storage.NewInMemoryStorage()
+	require.NoError(t, err)
+
+	for i := 0; i < count; i++ {
+		_, err := source.SaveURL(context.Background(), domain.SaveShortURLDto{
+			OriginalURL: fmt.Sprintf("https://example.com/%d", i),
+			ShortURL:    fmt.Sprintf("short%03d", i),
+			UserID:      fmt.Sprintf("user%d", i%3),
+		})
+		require.NoError(t, err)
+	}
+
+	require.NoError(t, source.DeleteByShortURLs(context.Background(), []string{"short001"}, "user1"))
+
+	return source
+}
+
+func TestMigrator_Run(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("valid", func(t *testing.T) {
+		source := makeSourceStorage(t, 25)
+		destination, err := storage.NewInMemoryStorage()
+		require.NoError(t, err)
+
+		migrator := NewMigrator(source, destination, nopLogger{}, Options{
+			CheckpointPath: filepath.Join(t.TempDir(), "checkpoint"),
+			BatchSize:      10,
+		})
+
+		result, err := migrator.Run(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, 25, result.Read)
+		assert.Equal(t, 25, result.Imported)
+		assert.Equal(t, *result.SourceStats, *result.DestinationStats)
+
+		url, err := destination.GetByShortURL(ctx, "short001")
+		require.NoError(t, err)
+		assert.Equal(t, "user1", url.UserID)
+		assert.True(t, url.IsDeleted)
+	})
+
+	t.Run("dry run", func(t *testing.T) {
+		source := makeSourceStorage(t, 5)
+		destination, err := storage.NewInMemoryStorage()
+		require.NoError(t, err)
+
+		migrator := NewMigrator(source, destination, nopLogger{}, Options{DryRun: true})
+
+		result, err := migrator.Run(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, 5, result.Read)
+		assert.Nil(t, result.DestinationStats)
+
+		stats, err := destination.GetInternalStats(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, 0, stats.URLs)
+	})
+
+	t.Run("resume from checkpoint", func(t *testing.T) {
+		checkpointPath := filepath.Join(t.TempDir(), "checkpoint")
+		source := makeSourceStorage(t, 25)
+		memoryDestination, err := storage.NewInMemoryStorage()
+		require.NoError(t, err)
+		destination := &failingDestination{InMemoryStorage: memoryDestination, failAfter: 1}
+
+		migrator := NewMigrator(source, destination, nopLogger{}, Options{
+			CheckpointPath: checkpointPath,
+			BatchSize:      10,
+		})
+
+		_, err = migrator.Run(ctx)
+		require.Error(t, err)
+
+		destination.failAfter = 100
+		result, err := migrator.Run(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, 25, result.Read)
+		assert.Equal(t, 25, result.Imported)
+		assert.Equal(t, 0, result.Skipped)
+		assert.NoFileExists(t, checkpointPath)
+	})
+
+	t.Run("checkpoint of other storages", func(t *testing.T) {
+		checkpointPath := filepath.Join(t.TempDir(), "checkpoint")
+		source := makeSourceStorage(t, 25)
+		memoryDestination, err := storage.NewInMemoryStorage()
+		require.NoError(t, err)
+		destination := &failingDestination{InMemoryStorage: memoryDestination, failAfter: 1}
+
+		migrator := NewMigrator(source, destination, nopLogger{}, Options{
+			CheckpointPath: checkpointPath,
+			Source:         "file:/tmp/first.json",
+			Destination:    "dsn:postgres://localhost/first",
+			BatchSize:      10,
+		})
+
+		_, err = migrator.Run(ctx)
+		require.Error(t, err)
+		require.FileExists(t, checkpointPath)
+
+		destination.failAfter = 100
+		otherMigrator := NewMigrator(source, destination, nopLogger{}, Options{
+			CheckpointPath: checkpointPath,
+			Source:         "file:/tmp/second.json",
+			Destination:    "dsn:postgres://localhost/first",
+			BatchSize:      10,
+		})
+
+		_, err = otherMigrator.Run(ctx)
+		assert.ErrorIs(t, err, ErrCheckpointMismatch)
+
+		result, err := migrator.Run(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, 25, result.Read)
+	})
+
+	t.Run("existing links are skipped", func(t *testing.T) {
+		source := makeSourceStorage(t, 5)
+		destination, err := storage.NewInMemoryStorage()
+		require.NoError(t, err)
+
+		for _, dto := range []domain.SaveShortURLDto{
+			{OriginalURL: "https://another.com", ShortURL: "another", UserID: "another"},
+			{OriginalURL: "https://example.com/2", ShortURL: "same-original", UserID: "another"},
+		} {
+			_, err = destination.SaveURL(ctx, dto)
+			require.NoError(t, err)
+		}
+
+		migrator := NewMigrator(source, destination, nopLogger{}, Options{})
+
+		result, err := migrator.Run(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, 4, result.Imported)
+		assert.Equal(t, 1, result.Skipped)
+		assert.Equal(t, []string{"short002"}, result.SkippedURLs)
+		assert.Equal(t, 2, result.DestinationURLsBefore)
+		assert.Equal(t, 6, result.DestinationStats.URLs)
+	})
+
+	t.Run("verification failed", func(t *testing.T) {
+		source := makeSourceStorage(t, 5)
+		memoryDestination, err := storage.NewInMemoryStorage()
+		require.NoError(t, err)
+
+		migrator := NewMigrator(source, &lostWritesDestination{InMemoryStorage: memoryDestination}, nopLogger{}, Options{})
+
+		result, err := migrator.Run(ctx)
+		assert.ErrorIs(t, err, ErrVerificationFailed)
+		require.NotNil(t, result)
+		assert.Equal(t, 5, result.Imported)
+	})
+}
@@ -0,0 +1,53 @@
+// Package requestid generates ids, that link log entries of one http request or gRPC call.
+package requestid
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+)
+
+// Header is http header, that carries request id from client and back to client.
+// gRPC metadata key is the same name in lowercase.
+const Header = "X-Request-ID"
+
+// MetadataKey is gRPC metadata key of request id.
+const MetadataKey = "x-request-id"
+
+const maxLength = 128
+
+// New generates random request id.
+func New() string {
+	id := make([]byte, 16)
+
+	// crypto/rand.Read never returns error on supported platforms
+	_, _ = rand.Read(id)
+
+	return hex.EncodeToString(id)
+}
+
+// FromIncoming returns id sent by client, if it is safe to log, otherwise generates new id.
+func FromIncoming(id string) string {
+	if !isValid(id) {
+		return New()
+	}
+
+	return id
+}
+
+// isValid allows only short ids of letters, digits and separators, so client can't inject text into logs.
+func isValid(id string) bool {
+	if id == "" || len(id) > maxLength {
+		return false
+	}
+
+	for _, c := range id {
+		isLetter := (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z')
+		isDigit := c >= '0' && c <= '9'
+
+		if !isLetter && !isDigit && c != '-' && c != '_' && c != '.' && c != ':' {
+			return false
+		}
+	}
+
+	return true
+}
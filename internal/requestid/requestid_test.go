@@ -0,0 +1,42 @@
+package requestid
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNew(t *testing.T) {
+	id := New()
+
+	assert.Len(t, id, 32)
+	assert.NotEqual(t, id, New())
+}
+
+func TestFromIncoming(t *testing.T) {
+	testCases := []struct {
+		name   string
+		id     string
+		isKept bool
+	}{
+		{name: "uuid", id: "3f2b8c1e-7a4d-4e5f-9b0a-1c2d3e4f5a6b", isKept: true},
+		{name: "separators", id: "trace_1.span:2", isKept: true},
+		{name: "empty", id: ""},
+		{name: "new line", id: "id\nfake log entry"},
+		{name: "too long", id: strings.Repeat("a", 129)},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			id := FromIncoming(tc.id)
+
+			if tc.isKept {
+				assert.Equal(t, tc.id, id)
+			} else {
+				assert.NotEqual(t, tc.id, id)
+				assert.Len(t, id, 32)
+			}
+		})
+	}
+}
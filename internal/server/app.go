@@ -0,0 +1,174 @@
+package server
+
+import (
+	"compress/gzip"
+	"context"
+	"net"
+	"net/http"
+	"os"
+
+	"google.golang.org/grpc"
+
+	"github.com/MowlCoder/go-url-shortener/internal/clientip"
+	"github.com/MowlCoder/go-url-shortener/internal/config"
+	grpcHandlers "github.com/MowlCoder/go-url-shortener/internal/handlers/grpc"
+	httpHandlers "github.com/MowlCoder/go-url-shortener/internal/handlers/http"
+	"github.com/MowlCoder/go-url-shortener/internal/lifecycle"
+	"github.com/MowlCoder/go-url-shortener/internal/logger"
+	"github.com/MowlCoder/go-url-shortener/internal/services"
+	"github.com/MowlCoder/go-url-shortener/internal/storage"
+	"github.com/MowlCoder/go-url-shortener/internal/subnets"
+)
+
+type countryResolver interface {
+	Country(ip net.IP) string
+}
+
+// AppOptions are dependencies of App, that differ between server binary and tests.
+type AppOptions struct {
+	Storage   storage.URLStorage
+	Countries countryResolver
+	Logger    *logger.Logger
+	// Lifecycle reports readiness of application and stops servers and workers of App.
+	Lifecycle *lifecycle.Manager
+	// LoadConfig loads configuration again, when it is reloaded.
+	LoadConfig func() (*config.AppConfig, error)
+	// GRPCServerOptions are passed to gRPC server, e.g. TLS credentials.
+	GRPCServerOptions []grpc.ServerOption
+}
+
+// App is URL shortener with services, handlers and background workers wired together.
+// It is used by server binary and by in-process test server, so both are built the same way.
+type App struct {
+	// HTTPHandler serves REST api, short urls, /api/v2 gateway and gRPC-Web.
+	HTTPHandler http.Handler
+	// GRPCServer serves gRPC api.
+	GRPCServer *grpc.Server
+
+	lifecycle         *lifecycle.Manager
+	grpcHealthHandler *grpcHandlers.HealthHandler
+	deleteURLQueue    *services.DeleteURLQueue
+	webhookService    *services.WebhookService
+	clickCounter      *services.ClickCounter
+	linkImportService *services.LinkImportService
+	configReloader    *services.ConfigReloader
+}
+
+// NewApp is constructor function to create App. Servers and workers are not started.
+func NewApp(appConfig *config.AppConfig, options AppOptions) (*App, error) {
+	gzipWriter, err := gzip.NewWriterLevel(nil, gzip.BestSpeed)
+	if err != nil {
+		return nil, err
+	}
+
+	trustedSubnet, err := subnets.NewTrusted(appConfig.TrustedSubnet)
+	if err != nil {
+		return nil, err
+	}
+
+	trustedProxies, err := subnets.NewTrusted(appConfig.TrustedProxies)
+	if err != nil {
+		return nil, err
+	}
+
+	urlStorage := options.Storage
+	customLogger := options.Logger
+
+	userService := services.NewUserService()
+	webhookService := services.NewWebhookService(urlStorage, customLogger)
+	deleteURLQueue := services.NewDeleteURLQueue(
+		urlStorage,
+		customLogger,
+		webhookService,
+		services.DeleteURLQueueOptions{
+			FlushInterval: appConfig.DeleteFlushInterval,
+			BatchSize:     appConfig.DeleteBatchSize,
+		},
+	)
+	clickCounter := services.NewClickCounter(urlStorage, customLogger, webhookService, services.ClickCounterOptions{})
+	healthService := services.NewHealthService(urlStorage, options.Lifecycle, customLogger, appConfig.MaxDeleteBacklog)
+	shortenerService := services.NewShortenerService(
+		urlStorage,
+		services.NewStringGenerator(),
+		deleteURLQueue,
+		webhookService,
+		clickCounter,
+	)
+	linkImportService := services.NewLinkImportService(shortenerService, customLogger, appConfig.BaseShortURLAddr)
+
+	clientIPResolver := clientip.NewResolver(trustedProxies)
+	configReloader := services.NewConfigReloader(appConfig, options.LoadConfig, trustedSubnet, trustedProxies, customLogger)
+
+	grpcShortenerHandler := grpcHandlers.NewShortenerHandler(appConfig, shortenerService)
+	grpcHealthHandler := grpcHandlers.NewHealthHandler(healthService)
+	grpcServer := NewGRPCServer(
+		GRPCHandlers{
+			Shortener: grpcShortenerHandler,
+			Health:    grpcHealthHandler,
+		},
+		userService,
+		trustedSubnet,
+		clientIPResolver,
+		customLogger,
+		options.GRPCServerOptions...,
+	)
+	httpHandler := NewRouter(
+		HTTPHandlers{
+			Shortener:   httpHandlers.NewShortenerHandler(appConfig, shortenerService, options.Countries),
+			Webhook:     httpHandlers.NewWebhookHandler(webhookService),
+			LinkImport:  httpHandlers.NewLinkImportHandler(linkImportService),
+			Health:      httpHandlers.NewHealthHandler(healthService),
+			Config:      httpHandlers.NewConfigHandler(configReloader),
+			ShortenerV2: grpcShortenerHandler,
+			GRPCWeb:     NewGRPCWebHandler(grpcServer, appConfig.CORSAllowedOrigins),
+		},
+		userService,
+		trustedSubnet,
+		clientIPResolver,
+		customLogger,
+		gzipWriter,
+		appConfig,
+	)
+
+	return &App{
+		HTTPHandler:       httpHandler,
+		GRPCServer:        grpcServer,
+		lifecycle:         options.Lifecycle,
+		grpcHealthHandler: grpcHealthHandler,
+		deleteURLQueue:    deleteURLQueue,
+		webhookService:    webhookService,
+		clickCounter:      clickCounter,
+		linkImportService: linkImportService,
+		configReloader:    configReloader,
+	}, nil
+}
+
+// Start starts background workers and registers stopping of servers and workers in lifecycle manager:
+// http server is stopped first, then gRPC server, then import jobs and workers. Configuration is reloaded
+// on every signal from reloadSignals. Resources used by App, like storage, should be registered after Start
+// by lifecycle.Manager.AppendAfterStopped.
+func (a *App) Start(reloadSignals <-chan os.Signal, stopHTTPServer lifecycle.StopFunc, stopGRPCServer lifecycle.StopFunc) {
+	queueCtx, stopQueue := context.WithCancel(context.Background())
+	go a.deleteURLQueue.Start(queueCtx)
+
+	webhooksCtx, stopWebhooks := context.WithCancel(context.Background())
+	go a.webhookService.Start(webhooksCtx)
+
+	clicksCtx, stopClicks := context.WithCancel(context.Background())
+	go a.clickCounter.Start(clicksCtx)
+
+	reloaderCtx, stopReloader := context.WithCancel(context.Background())
+	go a.configReloader.Start(reloaderCtx, reloadSignals)
+
+	a.lifecycle.Append("http server", stopHTTPServer)
+	a.lifecycle.Append("grpc server", func(ctx context.Context) error {
+		// health watch streams are ended first, otherwise graceful stop waits for them until ctx is done
+		a.grpcHealthHandler.Shutdown()
+		return stopGRPCServer(ctx)
+	})
+	a.lifecycle.Append("link import jobs", a.linkImportService.Shutdown)
+	a.lifecycle.Append("click counter", lifecycle.WaitDone(stopClicks, a.clickCounter.Done()))
+	a.lifecycle.Append("delete url queue", lifecycle.WaitDone(stopQueue, a.deleteURLQueue.Done()))
+	a.lifecycle.Append("webhook service", lifecycle.WaitDone(stopWebhooks, a.webhookService.Done()))
+	a.lifecycle.Append("config reloader", lifecycle.WaitDone(stopReloader, a.configReloader.Done()))
+}
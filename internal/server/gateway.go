@@ -0,0 +1,38 @@
+package server
+
+import (
+	"context"
+	"log"
+	"net/http"
+
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"google.golang.org/protobuf/encoding/protojson"
+
+	"github.com/MowlCoder/go-url-shortener/proto"
+)
+
+// V2Prefix is path prefix of REST api generated from google.api.http annotations of shortener.proto.
+const V2Prefix = "/api/v2"
+
+// newGatewayHandler creates handler of /api/v2 routes, that calls gRPC implementation of shortener in-process.
+// gRPC interceptors are not applied, user id is put to request context by http auth middleware.
+// Fields are named as in proto files, so JSON of v2 api looks like JSON of legacy api.
+func newGatewayHandler(shortener proto.ShortenerServer) http.Handler {
+	mux := runtime.NewServeMux(
+		runtime.WithMarshalerOption(runtime.MIMEWildcard, &runtime.JSONPb{
+			MarshalOptions: protojson.MarshalOptions{
+				UseProtoNames:   true,
+				EmitUnpopulated: true,
+			},
+			UnmarshalOptions: protojson.UnmarshalOptions{
+				DiscardUnknown: true,
+			},
+		}),
+	)
+
+	if err := proto.RegisterShortenerHandlerServer(context.Background(), mux, shortener); err != nil {
+		log.Fatal("register gateway handlers:", err)
+	}
+
+	return mux
+}
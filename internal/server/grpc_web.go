@@ -0,0 +1,27 @@
+package server
+
+import (
+	"net/http"
+
+	"github.com/improbable-eng/grpc-web/go/grpcweb"
+	"google.golang.org/grpc"
+
+	customMiddlewares "github.com/MowlCoder/go-url-shortener/internal/middlewares"
+	"github.com/MowlCoder/go-url-shortener/proto"
+)
+
+// grpcWebPrefix is path prefix of Shortener service methods called over gRPC-Web.
+var grpcWebPrefix = "/" + proto.Shortener_ServiceDesc.ServiceName + "/"
+
+// NewGRPCWebHandler creates handler, that serves gRPC-Web requests by given gRPC server, so browsers can call it.
+// Requests go through http middlewares of router, so user is identified by the same token cookie as in REST api.
+// Cross-origin requests are allowed from allowedOrigins, see config.AppConfig.CORSAllowedOrigins.
+// "*" is ignored, because gRPC-Web always allows credentials, so only listed origins are allowed.
+func NewGRPCWebHandler(grpcServer *grpc.Server, allowedOrigins string) http.Handler {
+	origins := customMiddlewares.ParseAllowedOrigins(allowedOrigins)
+
+	return grpcweb.WrapServer(
+		grpcServer,
+		grpcweb.WithOriginFunc(origins.Lists),
+	)
+}
@@ -0,0 +1,52 @@
+package server_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+
+	"github.com/MowlCoder/go-url-shortener/internal/server"
+)
+
+func TestNewGRPCWebHandler_Origins(t *testing.T) {
+	testCases := []struct {
+		name           string
+		allowedOrigins string
+		origin         string
+		expectedOrigin string
+	}{
+		{
+			name:           "listed origin",
+			allowedOrigins: "https://app.example.com",
+			origin:         "https://app.example.com",
+			expectedOrigin: "https://app.example.com",
+		},
+		{
+			name:           "any origin is not allowed with credentials",
+			allowedOrigins: "*",
+			origin:         "https://evil.example.com",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			handler := server.NewGRPCWebHandler(grpc.NewServer(), tc.allowedOrigins)
+
+			r := httptest.NewRequest(http.MethodPost, "/shortener.Shortener/GetMyURLs", nil)
+			r.Header.Set("Origin", tc.origin)
+			r.Header.Set("Content-Type", "application/grpc-web+proto")
+			r.Header.Set("X-Grpc-Web", "1")
+
+			w := httptest.NewRecorder()
+			handler.ServeHTTP(w, r)
+
+			assert.Equal(t, tc.expectedOrigin, w.Header().Get("Access-Control-Allow-Origin"))
+			if tc.expectedOrigin == "" {
+				assert.Empty(t, w.Header().Get("Access-Control-Allow-Credentials"))
+			}
+		})
+	}
+}
@@ -0,0 +1,152 @@
+// Package server builds http router and gRPC server of URL shortener from handlers.
+package server
+
+import (
+	"compress/gzip"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/chi/v5/middleware"
+	"google.golang.org/grpc"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+
+	"github.com/MowlCoder/go-url-shortener/internal/clientip"
+	"github.com/MowlCoder/go-url-shortener/internal/config"
+	grpcHandlers "github.com/MowlCoder/go-url-shortener/internal/handlers/grpc"
+	httpHandlers "github.com/MowlCoder/go-url-shortener/internal/handlers/http"
+	"github.com/MowlCoder/go-url-shortener/internal/interceptors"
+	"github.com/MowlCoder/go-url-shortener/internal/logger"
+	customMiddlewares "github.com/MowlCoder/go-url-shortener/internal/middlewares"
+	"github.com/MowlCoder/go-url-shortener/internal/services"
+	"github.com/MowlCoder/go-url-shortener/internal/subnets"
+	"github.com/MowlCoder/go-url-shortener/proto"
+)
+
+// HTTPHandlers are handlers served by http router.
+type HTTPHandlers struct {
+	Shortener  *httpHandlers.ShortenerHandler
+	Webhook    *httpHandlers.WebhookHandler
+	LinkImport *httpHandlers.LinkImportHandler
+	Health     *httpHandlers.HealthHandler
+	Config     *httpHandlers.ConfigHandler
+	// ShortenerV2 is gRPC implementation of shortener, that serves /api/v2 routes.
+	ShortenerV2 proto.ShortenerServer
+	// GRPCWeb serves Shortener service to browsers over gRPC-Web, see NewGRPCWebHandler.
+	GRPCWeb http.Handler
+}
+
+// GRPCHandlers are services registered in gRPC server.
+type GRPCHandlers struct {
+	Shortener *grpcHandlers.ShortenerHandler
+	Health    *grpcHandlers.HealthHandler
+}
+
+// NewRouter creates http router with all middlewares and routes of URL shortener.
+// Legacy routes are served by http handlers, /api/v2 routes are served by gRPC implementation through gateway.
+// Internal routes are allowed only from trustedSubnet, client address is found by clientIPResolver.
+func NewRouter(
+	handlers HTTPHandlers,
+	userService *services.UserService,
+	trustedSubnet *subnets.Trusted,
+	clientIPResolver *clientip.Resolver,
+	customLogger *logger.Logger,
+	gzipWriter *gzip.Writer,
+	appConfig *config.AppConfig,
+) http.Handler {
+	mux := chi.NewRouter()
+	cookieOptions := newCookieOptions(appConfig)
+	authHandler := httpHandlers.NewAuthHandler(cookieOptions)
+	gateway := newGatewayHandler(handlers.ShortenerV2)
+
+	mux.Use(customMiddlewares.RequestIDMiddleware)
+	mux.Use(customMiddlewares.ClientIPMiddleware(clientIPResolver))
+	mux.Use(middleware.Recoverer)
+	mux.Use(customMiddlewares.SecurityHeadersMiddleware(customMiddlewares.SecurityHeadersOptions{
+		ContentSecurityPolicy: appConfig.ContentSecurityPolicy,
+		HSTSMaxAge:            appConfig.HSTSMaxAge,
+		EnableHSTS:            appConfig.EnableHTTPS,
+	}))
+	mux.Use(customMiddlewares.CORSMiddleware(customMiddlewares.CORSOptions{
+		AllowedOrigins:   appConfig.CORSAllowedOrigins,
+		AllowedMethods:   appConfig.CORSAllowedMethods,
+		MaxAge:           appConfig.CORSMaxAge,
+		AllowCredentials: appConfig.CORSAllowCredentials,
+	}))
+	mux.Use(customMiddlewares.NewCompressMiddleware(gzipWriter).Handler)
+	mux.Use(func(handler http.Handler) http.Handler {
+		return customMiddlewares.WithLogging(handler, customLogger)
+	})
+	mux.Use(func(handler http.Handler) http.Handler {
+		return customMiddlewares.AuthMiddleware(handler, userService, cookieOptions)
+	})
+
+	mux.Group(func(privateRouter chi.Router) {
+		privateRouter.Use(customMiddlewares.TrustedSubnetsMiddleware(trustedSubnet))
+		privateRouter.Get("/api/internal/stats", handlers.Shortener.GetStats)
+		privateRouter.Post("/api/internal/config/reload", handlers.Config.ReloadConfig)
+		privateRouter.Get(V2Prefix+"/internal/stats", gateway.ServeHTTP)
+	})
+
+	mux.Post("/api/shorten/batch", handlers.Shortener.ShortBatchURL)
+	mux.Post("/api/shorten", handlers.Shortener.ShortURLJSON)
+	mux.Post("/", handlers.Shortener.ShortURL)
+	mux.Delete("/api/user/urls", handlers.Shortener.DeleteURLs)
+	mux.Get("/api/user/urls", handlers.Shortener.GetMyURLs)
+	mux.Post("/api/user/logout", authHandler.Logout)
+	mux.Get("/api/user/urls/deletions/{id}", handlers.Shortener.GetDeleteTask)
+	mux.Get("/api/user/urls/{id}/rules", handlers.Shortener.GetRedirectRules)
+	mux.Put("/api/user/urls/{id}/rules", handlers.Shortener.SetRedirectRules)
+	mux.Post("/api/user/urls/import", handlers.LinkImport.ImportURLs)
+	mux.Get("/api/user/urls/import/{id}", handlers.LinkImport.GetImportJob)
+	mux.Get("/api/user/urls/export", handlers.LinkImport.ExportURLs)
+	mux.Post("/api/user/webhooks", handlers.Webhook.CreateWebhook)
+	mux.Get("/api/user/webhooks", handlers.Webhook.GetMyWebhooks)
+	mux.Delete("/api/user/webhooks/{id}", handlers.Webhook.DeleteWebhook)
+	mux.Get("/api/user/webhooks/{id}/deliveries", handlers.Webhook.GetWebhookDeliveries)
+	mux.Handle(V2Prefix+"/*", gateway)
+	mux.Handle(grpcWebPrefix+"*", handlers.GRPCWeb)
+	mux.Get("/ping", handlers.Shortener.Ping)
+	mux.Get("/healthz", handlers.Health.Healthz)
+	mux.Get("/readyz", handlers.Health.Readyz)
+	mux.Get("/{id}+", handlers.Shortener.UnfurlURLByID)
+	mux.Get("/{id}/preview", handlers.Shortener.UnfurlURLByID)
+	mux.Get("/{id}", handlers.Shortener.RedirectToURLByID)
+
+	return mux
+}
+
+func newCookieOptions(appConfig *config.AppConfig) customMiddlewares.CookieOptions {
+	return customMiddlewares.CookieOptions{
+		Domain:        appConfig.CookieDomain,
+		SameSite:      customMiddlewares.ParseSameSite(appConfig.CookieSameSite),
+		RefreshBefore: appConfig.TokenRefreshBefore,
+		Secure:        appConfig.EnableHTTPS,
+	}
+}
+
+// NewGRPCServer creates gRPC server with shortener and health services.
+// Every call is logged by customLogger with request id. GetStats is allowed only from trusted subnet. Additional options, like TLS credentials, are passed to grpc.NewServer.
+func NewGRPCServer(
+	handlers GRPCHandlers,
+	userService *services.UserService,
+	trustedSubnet *subnets.Trusted,
+	clientIPResolver *clientip.Resolver,
+	customLogger *logger.Logger,
+	opts ...grpc.ServerOption,
+) *grpc.Server {
+	grpcServer := grpc.NewServer(append([]grpc.ServerOption{
+		grpc.ChainUnaryInterceptor(
+			interceptors.CreateLoggingInterceptor(customLogger, clientIPResolver),
+			interceptors.CreateAuthInterceptor(userService),
+			interceptors.CreateTrustedSubnetInterceptor(trustedSubnet, clientIPResolver, proto.Shortener_GetStats_FullMethodName),
+		),
+		grpc.ChainStreamInterceptor(
+			interceptors.CreateStreamLoggingInterceptor(customLogger, clientIPResolver),
+			interceptors.CreateStreamAuthInterceptor(userService),
+		),
+	}, opts...)...)
+	proto.RegisterShortenerServer(grpcServer, handlers.Shortener)
+	healthpb.RegisterHealthServer(grpcServer, handlers.Health)
+
+	return grpcServer
+}
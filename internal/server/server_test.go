@@ -0,0 +1,450 @@
+package server_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/cookiejar"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	protobuf "google.golang.org/protobuf/proto"
+
+	"github.com/MowlCoder/go-url-shortener/pkg/client/clienttest"
+	"github.com/MowlCoder/go-url-shortener/proto"
+)
+
+func TestGRPCServer_Streams(t *testing.T) {
+	server := clienttest.NewServer(t)
+	httpClient := server.NewHTTPClient()
+
+	_, err := httpClient.ShortURL(context.Background(), "https://example.com")
+	require.NoError(t, err)
+
+	conn, err := grpc.Dial(server.GRPCAddr, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	require.NoError(t, err)
+	defer conn.Close()
+
+	shortenerClient := proto.NewShortenerClient(conn)
+	ctx := metadata.AppendToOutgoingContext(context.Background(), "token", httpClient.Token())
+
+	shortStream, err := shortenerClient.ShortURLStream(ctx)
+	require.NoError(t, err)
+
+	for _, dto := range []*proto.RequestBatchURLDto{
+		{OriginalUrl: "https://a.example.com", CorrelationId: "1"},
+		{OriginalUrl: "", CorrelationId: "2"},
+		{OriginalUrl: "https://example.com", CorrelationId: "3"},
+	} {
+		require.NoError(t, shortStream.Send(dto))
+
+		// result is sent before next url is received
+		response, err := shortStream.Recv()
+		require.NoError(t, err)
+		assert.Equal(t, dto.CorrelationId, response.CorrelationId)
+
+		switch dto.CorrelationId {
+		case "2":
+			assert.NotEmpty(t, response.Error)
+		case "3":
+			// url is shortened by http client before, existing short url is sent with error
+			assert.NotEmpty(t, response.ShortUrl)
+			assert.NotEmpty(t, response.Error)
+		default:
+			assert.NotEmpty(t, response.ShortUrl)
+			assert.Empty(t, response.Error)
+		}
+	}
+
+	require.NoError(t, shortStream.CloseSend())
+	_, err = shortStream.Recv()
+	assert.ErrorIs(t, err, io.EOF)
+
+	urlsStream, err := shortenerClient.StreamMyURLs(ctx, &proto.StreamMyURLsRequest{})
+	require.NoError(t, err)
+
+	originalURLs := make([]string, 0)
+
+	for {
+		url, err := urlsStream.Recv()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+
+		require.NoError(t, err)
+		originalURLs = append(originalURLs, url.OriginalUrl)
+	}
+
+	assert.ElementsMatch(t, []string{"https://example.com", "https://a.example.com"}, originalURLs)
+}
+
+func TestGRPCServer_StreamMyURLsPages(t *testing.T) {
+	server := clienttest.NewServer(t)
+	httpClient := server.NewHTTPClient()
+
+	// more urls than fit in one page of storage
+	originalURLs := make([]string, 0, 250)
+	for i := 0; i < cap(originalURLs); i++ {
+		originalURLs = append(originalURLs, fmt.Sprintf("https://example.com/%d", i))
+	}
+
+	_, err := httpClient.ShortBatch(context.Background(), originalURLs)
+	require.NoError(t, err)
+
+	conn, err := grpc.Dial(server.GRPCAddr, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	require.NoError(t, err)
+	defer conn.Close()
+
+	ctx := metadata.AppendToOutgoingContext(context.Background(), "token", httpClient.Token())
+
+	urlsStream, err := proto.NewShortenerClient(conn).StreamMyURLs(ctx, &proto.StreamMyURLsRequest{})
+	require.NoError(t, err)
+
+	streamedURLs := make([]string, 0)
+
+	for {
+		url, err := urlsStream.Recv()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+
+		require.NoError(t, err)
+		streamedURLs = append(streamedURLs, url.OriginalUrl)
+	}
+
+	assert.ElementsMatch(t, originalURLs, streamedURLs)
+}
+
+func TestRouter_APIv2(t *testing.T) {
+	server := clienttest.NewServer(t)
+
+	jar, err := cookiejar.New(nil)
+	require.NoError(t, err)
+
+	httpClient := &http.Client{Jar: jar}
+
+	doRequest := func(method string, path string, body string, headers map[string]string) (int, map[string]any) {
+		t.Helper()
+
+		request, err := http.NewRequest(method, server.HTTPURL+path, bytes.NewBufferString(body))
+		require.NoError(t, err)
+
+		for key, value := range headers {
+			request.Header.Set(key, value)
+		}
+
+		response, err := httpClient.Do(request)
+		require.NoError(t, err)
+		defer response.Body.Close()
+
+		rawBody, err := io.ReadAll(response.Body)
+		require.NoError(t, err)
+
+		var responseBody map[string]any
+		if len(rawBody) > 0 {
+			require.NoError(t, json.Unmarshal(rawBody, &responseBody))
+		}
+
+		return response.StatusCode, responseBody
+	}
+
+	statusCode, body := doRequest(http.MethodPost, "/api/v2/shorten", `{"url":"https://example.com"}`, nil)
+	require.Equal(t, http.StatusOK, statusCode)
+	shortURL := body["result"]
+	assert.NotEmpty(t, shortURL)
+
+	// legacy route is kept and shares user and urls with v2 routes
+	statusCode, body = doRequest(http.MethodPost, "/api/shorten", `{"url":"https://example.com"}`, nil)
+	assert.Equal(t, http.StatusConflict, statusCode)
+	assert.Equal(t, shortURL, body["result"])
+
+	statusCode, body = doRequest(http.MethodPost, "/api/v2/shorten", `{"url":"https://example.com"}`, nil)
+	assert.Equal(t, http.StatusConflict, statusCode)
+	assert.Equal(t, shortURL, findDetail(t, body, "google.rpc.ResourceInfo")["resource_name"])
+	assert.Equal(t, "URL_CONFLICT", findDetail(t, body, "google.rpc.ErrorInfo")["reason"])
+
+	statusCode, body = doRequest(http.MethodPost, "/api/v2/shorten", `{"url":""}`, nil)
+	assert.Equal(t, http.StatusBadRequest, statusCode)
+	assert.Equal(t, "invalid url", body["message"])
+	violations := findDetail(t, body, "google.rpc.BadRequest")["field_violations"].([]any)
+	assert.Equal(t, "url", violations[0].(map[string]any)["field"])
+
+	statusCode, body = doRequest(http.MethodPost, "/api/v2/shorten/batch", `{"dtos":[{"original_url":"https://a.example.com","correlation_id":"1"}]}`, nil)
+	assert.Equal(t, http.StatusOK, statusCode)
+	assert.Len(t, body["dtos"], 1)
+
+	statusCode, body = doRequest(http.MethodGet, "/api/v2/user/urls", "", nil)
+	assert.Equal(t, http.StatusOK, statusCode)
+	assert.Len(t, body["result"], 2)
+
+	statusCode, _ = doRequest(http.MethodGet, "/api/v2/internal/stats", "", nil)
+	assert.Equal(t, http.StatusForbidden, statusCode)
+
+	statusCode, body = doRequest(http.MethodGet, "/api/v2/internal/stats", "", map[string]string{"X-Real-IP": clienttest.TrustedIP})
+	assert.Equal(t, http.StatusOK, statusCode)
+	assert.Equal(t, "2", body["urls"])
+
+	statusCode, body = doRequest(http.MethodDelete, "/api/v2/user/urls", `{"urls":[]}`, nil)
+	assert.Equal(t, http.StatusBadRequest, statusCode)
+	assert.NotEmpty(t, body["message"])
+}
+
+// findDetail returns detail of gRPC status with given type from error response of gateway.
+func findDetail(t *testing.T, body map[string]any, detailType string) map[string]any {
+	t.Helper()
+
+	details, _ := body["details"].([]any)
+	for _, detail := range details {
+		if detailMap, ok := detail.(map[string]any); ok && detailMap["@type"] == "type.googleapis.com/"+detailType {
+			return detailMap
+		}
+	}
+
+	require.Failf(t, "detail not found", "%s in %v", detailType, details)
+
+	return nil
+}
+
+func TestRouter_UnfurlPage(t *testing.T) {
+	server := clienttest.NewServer(t)
+
+	jar, err := cookiejar.New(nil)
+	require.NoError(t, err)
+
+	httpClient := &http.Client{
+		Jar: jar,
+		CheckRedirect: func(req *http.Request, via []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+	}
+
+	response, err := httpClient.Post(server.HTTPURL+"/", "text/plain", bytes.NewBufferString("https://example.com/page"))
+	require.NoError(t, err)
+	rawShortURL, err := io.ReadAll(response.Body)
+	response.Body.Close()
+	require.NoError(t, err)
+	require.Equal(t, http.StatusCreated, response.StatusCode)
+
+	shortURL := string(rawShortURL)
+
+	for _, path := range []string{shortURL + "+", shortURL + "/preview"} {
+		response, err = httpClient.Get(path)
+		require.NoError(t, err)
+		page, err := io.ReadAll(response.Body)
+		response.Body.Close()
+		require.NoError(t, err)
+
+		assert.Equal(t, http.StatusOK, response.StatusCode, path)
+		assert.Equal(t, "text/html; charset=utf-8", response.Header.Get("Content-Type"))
+		assert.Contains(t, string(page), `href="https://example.com/page"`)
+		assert.Contains(t, string(page), `<meta property="og:url" content="`+shortURL+`">`)
+		assert.Contains(t, string(page), "<dd>0</dd>")
+	}
+
+	// short url without suffix is still redirected
+	response, err = httpClient.Get(shortURL)
+	require.NoError(t, err)
+	response.Body.Close()
+	assert.Equal(t, http.StatusTemporaryRedirect, response.StatusCode)
+
+	response, err = httpClient.Get(server.HTTPURL + "/missing+")
+	require.NoError(t, err)
+	response.Body.Close()
+	assert.Equal(t, http.StatusNotFound, response.StatusCode)
+}
+
+func TestRouter_RedirectRules(t *testing.T) {
+	server := clienttest.NewServer(t)
+
+	jar, err := cookiejar.New(nil)
+	require.NoError(t, err)
+
+	httpClient := &http.Client{
+		Jar: jar,
+		CheckRedirect: func(req *http.Request, via []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+	}
+
+	doRequest := func(method string, path string, body string, headers map[string]string) *http.Response {
+		t.Helper()
+
+		request, err := http.NewRequest(method, path, bytes.NewBufferString(body))
+		require.NoError(t, err)
+
+		for key, value := range headers {
+			request.Header.Set(key, value)
+		}
+
+		response, err := httpClient.Do(request)
+		require.NoError(t, err)
+
+		return response
+	}
+
+	response := doRequest(http.MethodPost, server.HTTPURL+"/", "https://example.com/page", nil)
+	rawShortURL, err := io.ReadAll(response.Body)
+	response.Body.Close()
+	require.NoError(t, err)
+	require.Equal(t, http.StatusCreated, response.StatusCode)
+
+	shortURL := string(rawShortURL)
+	id := shortURL[strings.LastIndex(shortURL, "/")+1:]
+
+	response = doRequest(
+		http.MethodPut,
+		server.HTTPURL+"/api/user/urls/"+id+"/rules",
+		`{"rules":[{"destination":"https://play.example.com/app","devices":["android"]}]}`,
+		nil,
+	)
+	response.Body.Close()
+	require.Equal(t, http.StatusOK, response.StatusCode)
+
+	response = doRequest(http.MethodGet, shortURL, "", map[string]string{"User-Agent": "Mozilla/5.0 (Linux; Android 14) Mobile"})
+	response.Body.Close()
+	assert.Equal(t, "https://play.example.com/app", response.Header.Get("Location"))
+	assert.Equal(t, "private, no-cache", response.Header.Get("Cache-Control"))
+
+	response = doRequest(http.MethodGet, shortURL, "", map[string]string{"User-Agent": "Mozilla/5.0 (Windows NT 10.0; Win64; x64)"})
+	response.Body.Close()
+	assert.Equal(t, "https://example.com/page", response.Header.Get("Location"))
+
+	// rules are shared with v2 routes
+	response = doRequest(http.MethodGet, server.HTTPURL+"/api/v2/user/urls/"+id+"/rules", "", nil)
+	var body map[string]any
+	require.NoError(t, json.NewDecoder(response.Body).Decode(&body))
+	response.Body.Close()
+	require.Equal(t, http.StatusOK, response.StatusCode)
+	assert.Len(t, body["rules"], 1)
+
+	response = doRequest(http.MethodPut, server.HTTPURL+"/api/v2/user/urls/"+id+"/rules", `{"rules":[{"destination":"https://example.de"}]}`, nil)
+	require.NoError(t, json.NewDecoder(response.Body).Decode(&body))
+	response.Body.Close()
+	assert.Equal(t, http.StatusBadRequest, response.StatusCode)
+	violations := findDetail(t, body, "google.rpc.BadRequest")["field_violations"].([]any)
+	assert.Equal(t, "rules", violations[0].(map[string]any)["field"])
+
+	response = doRequest(http.MethodGet, server.HTTPURL+"/api/user/urls/missing/rules", "", nil)
+	response.Body.Close()
+	assert.Equal(t, http.StatusNotFound, response.StatusCode)
+}
+
+func TestRouter_ReloadConfig(t *testing.T) {
+	server := clienttest.NewServer(t)
+
+	reload := func(realIP string) *http.Response {
+		t.Helper()
+
+		request, err := http.NewRequest(http.MethodPost, server.HTTPURL+"/api/internal/config/reload", nil)
+		require.NoError(t, err)
+		request.Header.Set("X-Real-IP", realIP)
+
+		response, err := http.DefaultClient.Do(request)
+		require.NoError(t, err)
+
+		return response
+	}
+
+	response := reload("10.0.0.1")
+	response.Body.Close()
+	assert.Equal(t, http.StatusForbidden, response.StatusCode)
+
+	response = reload(clienttest.TrustedIP)
+	defer response.Body.Close()
+	require.Equal(t, http.StatusOK, response.StatusCode)
+
+	var body map[string][]any
+	require.NoError(t, json.NewDecoder(response.Body).Decode(&body))
+	assert.Empty(t, body["applied"])
+	assert.Empty(t, body["restart_required"])
+}
+
+func TestRouter_GRPCWeb(t *testing.T) {
+	server := clienttest.NewServer(t)
+
+	jar, err := cookiejar.New(nil)
+	require.NoError(t, err)
+
+	httpClient := &http.Client{Jar: jar}
+
+	response, err := httpClient.Post(server.HTTPURL+"/", "text/plain", bytes.NewBufferString("https://example.com"))
+	require.NoError(t, err)
+	response.Body.Close()
+	require.Equal(t, http.StatusCreated, response.StatusCode)
+
+	callGRPCWeb := func(client *http.Client, method string, in protobuf.Message, out protobuf.Message) *http.Response {
+		t.Helper()
+
+		message, err := protobuf.Marshal(in)
+		require.NoError(t, err)
+
+		frame := make([]byte, 5, 5+len(message))
+		binary.BigEndian.PutUint32(frame[1:], uint32(len(message)))
+		frame = append(frame, message...)
+
+		request, err := http.NewRequest(http.MethodPost, server.HTTPURL+"/shortener.Shortener/"+method, bytes.NewReader(frame))
+		require.NoError(t, err)
+		request.Header.Set("Content-Type", "application/grpc-web+proto")
+		request.Header.Set("X-Grpc-Web", "1")
+
+		response, err := client.Do(request)
+		require.NoError(t, err)
+		defer response.Body.Close()
+		require.Equal(t, http.StatusOK, response.StatusCode)
+
+		body, err := io.ReadAll(response.Body)
+		require.NoError(t, err)
+		require.GreaterOrEqual(t, len(body), 5)
+
+		// first frame is message, trailers frame follows it
+		require.Equal(t, byte(0), body[0])
+		length := binary.BigEndian.Uint32(body[1:5])
+		require.NoError(t, protobuf.Unmarshal(body[5:5+length], out))
+
+		return response
+	}
+
+	// browser with token cookie sees links created by REST api
+	urls := &proto.GetMyURLsResponse{}
+	callGRPCWeb(httpClient, "GetMyURLs", &proto.GetMyURLsRequest{}, urls)
+	require.Len(t, urls.Result, 1)
+	assert.Equal(t, "https://example.com", urls.Result[0].OriginalUrl)
+
+	// new browser gets token cookie
+	response = callGRPCWeb(http.DefaultClient, "GetMyURLs", &proto.GetMyURLsRequest{}, urls)
+	assert.Empty(t, urls.Result)
+	assert.NotEmpty(t, response.Cookies())
+
+	preflight := func(origin string) *http.Response {
+		request, err := http.NewRequest(http.MethodOptions, server.HTTPURL+"/shortener.Shortener/GetMyURLs", nil)
+		require.NoError(t, err)
+		request.Header.Set("Origin", origin)
+		request.Header.Set("Access-Control-Request-Method", http.MethodPost)
+		request.Header.Set("Access-Control-Request-Headers", "content-type,x-grpc-web")
+
+		response, err := http.DefaultClient.Do(request)
+		require.NoError(t, err)
+		response.Body.Close()
+
+		return response
+	}
+
+	response = preflight(clienttest.AllowedOrigin)
+	assert.Equal(t, clienttest.AllowedOrigin, response.Header.Get("Access-Control-Allow-Origin"))
+	// redirect rules are set by PUT
+	assert.Contains(t, response.Header.Get("Access-Control-Allow-Methods"), http.MethodPut)
+	assert.Equal(t, "true", response.Header.Get("Access-Control-Allow-Credentials"))
+
+	response = preflight("http://evil.example.com")
+	assert.Empty(t, response.Header.Get("Access-Control-Allow-Origin"))
+}
@@ -0,0 +1,191 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/MowlCoder/go-url-shortener/internal/domain"
+)
+
+// Default values of ClickCounterOptions.
+const (
+	DefaultClickFlushInterval = time.Second * 5
+	DefaultClickBatchSize     = 1000
+)
+
+// clickCounterMaxPendingFactor limits count of urls with unsaved clicks, when storage is unavailable.
+const clickCounterMaxPendingFactor = 100
+
+var errClickBufferFull = errors.New("click buffer is full")
+
+type clickStorage interface {
+	IncrementClicks(ctx context.Context, shortURL string, count int) (int, error)
+}
+
+// ClickCounterOptions for setting up ClickCounter.
+type ClickCounterOptions struct {
+	FlushInterval time.Duration
+	BatchSize     int
+}
+
+type pendingClicks struct {
+	userID      string
+	originalURL string
+	count       int
+}
+
+// ClickCounter buffers clicks of urls in memory and saves them to storage in background,
+// so redirects do not wait for storage.
+type ClickCounter struct {
+	storage       clickStorage
+	logger        logger
+	eventEmitter  linkEventEmitter
+	pending       map[string]*pendingClicks
+	wakeup        chan struct{}
+	done          chan struct{}
+	flushInterval time.Duration
+	batchSize     int
+	mu            sync.Mutex
+}
+
+// NewClickCounter is constructor function to create ClickCounter.
+// On the first saved click of url link.first_clicked event is emitted through eventEmitter.
+func NewClickCounter(
+	storage clickStorage,
+	logger logger,
+	eventEmitter linkEventEmitter,
+	options ClickCounterOptions,
+) *ClickCounter {
+	if options.FlushInterval <= 0 {
+		options.FlushInterval = DefaultClickFlushInterval
+	}
+
+	if options.BatchSize <= 0 {
+		options.BatchSize = DefaultClickBatchSize
+	}
+
+	return &ClickCounter{
+		storage:       storage,
+		logger:        logger,
+		eventEmitter:  eventEmitter,
+		pending:       make(map[string]*pendingClicks),
+		wakeup:        make(chan struct{}, 1),
+		done:          make(chan struct{}),
+		flushInterval: options.FlushInterval,
+		batchSize:     options.BatchSize,
+	}
+}
+
+// Start starts counter job. Clicks are saved every flush interval or earlier, when batch size
+// of urls has clicks. When ctx is done counter saves buffered clicks and stops, after that Done channel is closed.
+func (c *ClickCounter) Start(ctx context.Context) {
+	defer close(c.done)
+
+	ticker := time.NewTicker(c.flushInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			c.flush()
+			return
+		case <-c.wakeup:
+		case <-ticker.C:
+		}
+
+		c.flush()
+	}
+}
+
+// Done returns channel that is closed when counter is stopped and buffered clicks are saved.
+func (c *ClickCounter) Done() <-chan struct{} {
+	return c.done
+}
+
+// Register add click of url to buffer. Click is dropped, when too many clicks are waiting for storage.
+func (c *ClickCounter) Register(url *domain.ShortenedURL) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if !c.add(url.ShortURL, pendingClicks{userID: url.UserID, originalURL: url.OriginalURL, count: 1}) {
+		return errClickBufferFull
+	}
+
+	if len(c.pending) >= c.batchSize {
+		select {
+		case c.wakeup <- struct{}{}:
+		default:
+		}
+	}
+
+	return nil
+}
+
+func (c *ClickCounter) add(shortURL string, clicks pendingClicks) bool {
+	if existing, ok := c.pending[shortURL]; ok {
+		existing.count += clicks.count
+		return true
+	}
+
+	if len(c.pending) >= c.batchSize*clickCounterMaxPendingFactor {
+		return false
+	}
+
+	c.pending[shortURL] = &clicks
+
+	return true
+}
+
+func (c *ClickCounter) flush() {
+	c.mu.Lock()
+	pending := c.pending
+	c.pending = make(map[string]*pendingClicks)
+	c.mu.Unlock()
+
+	if len(pending) == 0 {
+		return
+	}
+
+	ctx := context.Background()
+	failed := make(map[string]*pendingClicks)
+	var lastErr error
+
+	for shortURL, clicks := range pending {
+		total, err := c.storage.IncrementClicks(ctx, shortURL, clicks.count)
+		if errors.Is(err, domain.ErrURLNotFound) {
+			continue
+		}
+
+		if err != nil {
+			failed[shortURL] = clicks
+			lastErr = err
+			continue
+		}
+
+		if total == clicks.count {
+			c.eventEmitter.Emit(ctx, domain.EventLinkFirstClicked, clicks.userID, []domain.LinkEventData{
+				{
+					ShortURL:    shortURL,
+					OriginalURL: clicks.originalURL,
+				},
+			})
+		}
+	}
+
+	if len(failed) == 0 {
+		return
+	}
+
+	c.logger.Error("save clicks", zap.Int("urls", len(failed)), zap.Error(lastErr))
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for shortURL, clicks := range failed {
+		c.add(shortURL, *clicks)
+	}
+}
@@ -0,0 +1,113 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/MowlCoder/go-url-shortener/internal/domain"
+	servicesmocks "github.com/MowlCoder/go-url-shortener/internal/services/mocks"
+)
+
+func TestNewClickCounter(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	storage := servicesmocks.NewMockclickStorage(ctrl)
+	loggerInstance := servicesmocks.NewMocklogger(ctrl)
+	eventEmitter := servicesmocks.NewMocklinkEventEmitter(ctrl)
+
+	counter := NewClickCounter(storage, loggerInstance, eventEmitter, ClickCounterOptions{})
+	require.NotNil(t, counter)
+	assert.Equal(t, DefaultClickFlushInterval, counter.flushInterval)
+	assert.Equal(t, DefaultClickBatchSize, counter.batchSize)
+}
+
+func TestClickCounter_Register(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	storage := servicesmocks.NewMockclickStorage(ctrl)
+	loggerInstance := servicesmocks.NewMocklogger(ctrl)
+	eventEmitter := servicesmocks.NewMocklinkEventEmitter(ctrl)
+	counter := NewClickCounter(storage, loggerInstance, eventEmitter, ClickCounterOptions{BatchSize: 2})
+
+	require.NoError(t, counter.Register(&domain.ShortenedURL{ShortURL: "1"}))
+	require.NoError(t, counter.Register(&domain.ShortenedURL{ShortURL: "1"}))
+	assert.Equal(t, 2, counter.pending["1"].count)
+	assert.Len(t, counter.wakeup, 0)
+
+	require.NoError(t, counter.Register(&domain.ShortenedURL{ShortURL: "2"}))
+	assert.Len(t, counter.wakeup, 1)
+
+	for i := len(counter.pending); i < 2*clickCounterMaxPendingFactor; i++ {
+		counter.pending[string(rune('a'+i))] = &pendingClicks{count: 1}
+	}
+
+	assert.ErrorIs(t, counter.Register(&domain.ShortenedURL{ShortURL: "3"}), errClickBufferFull)
+	require.NoError(t, counter.Register(&domain.ShortenedURL{ShortURL: "1"}))
+	assert.Equal(t, 3, counter.pending["1"].count)
+}
+
+func TestClickCounter_flush(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	storage := servicesmocks.NewMockclickStorage(ctrl)
+	loggerInstance := servicesmocks.NewMocklogger(ctrl)
+	eventEmitter := servicesmocks.NewMocklinkEventEmitter(ctrl)
+	counter := NewClickCounter(storage, loggerInstance, eventEmitter, ClickCounterOptions{})
+
+	first := &domain.ShortenedURL{ShortURL: "first", OriginalURL: "https://first.com", UserID: "1"}
+	second := &domain.ShortenedURL{ShortURL: "second", UserID: "1"}
+	missing := &domain.ShortenedURL{ShortURL: "missing", UserID: "1"}
+	failing := &domain.ShortenedURL{ShortURL: "failing", UserID: "1"}
+
+	for _, url := range []*domain.ShortenedURL{first, first, second, missing, failing} {
+		require.NoError(t, counter.Register(url))
+	}
+
+	storage.EXPECT().IncrementClicks(gomock.Any(), "first", 2).Return(2, nil)
+	storage.EXPECT().IncrementClicks(gomock.Any(), "second", 1).Return(5, nil)
+	storage.EXPECT().IncrementClicks(gomock.Any(), "missing", 1).Return(0, domain.ErrURLNotFound)
+	storage.EXPECT().IncrementClicks(gomock.Any(), "failing", 1).Return(0, errors.New("undefined behavior"))
+
+	eventEmitter.
+		EXPECT().
+		Emit(gomock.Any(), domain.EventLinkFirstClicked, "1", []domain.LinkEventData{
+			{
+				ShortURL:    "first",
+				OriginalURL: "https://first.com",
+			},
+		})
+
+	loggerInstance.EXPECT().Error("save clicks", gomock.Any())
+
+	counter.flush()
+
+	require.Len(t, counter.pending, 1)
+	assert.Equal(t, 1, counter.pending["failing"].count)
+}
+
+func TestClickCounter_Start(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	storage := servicesmocks.NewMockclickStorage(ctrl)
+	loggerInstance := servicesmocks.NewMocklogger(ctrl)
+	eventEmitter := servicesmocks.NewMocklinkEventEmitter(ctrl)
+	counter := NewClickCounter(storage, loggerInstance, eventEmitter, ClickCounterOptions{FlushInterval: time.Hour})
+
+	require.NoError(t, counter.Register(&domain.ShortenedURL{ShortURL: "1", UserID: "1"}))
+
+	storage.EXPECT().IncrementClicks(gomock.Any(), "1", 1).Return(3, nil)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	go counter.Start(ctx)
+	cancel()
+
+	select {
+	case <-counter.Done():
+	case <-time.After(time.Second):
+		t.Fatal("click counter is not stopped")
+	}
+
+	assert.Empty(t, counter.pending)
+}
@@ -0,0 +1,179 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"os"
+	"sync"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+
+	"github.com/MowlCoder/go-url-shortener/internal/config"
+	"github.com/MowlCoder/go-url-shortener/internal/domain"
+	"github.com/MowlCoder/go-url-shortener/internal/subnets"
+)
+
+type trustedSubnetUpdater interface {
+	Set(ipNets []*net.IPNet)
+}
+
+type configLogger interface {
+	Info(msg string, fields ...zap.Field)
+	Warn(msg string, fields ...zap.Field)
+	SetLevel(level zapcore.Level)
+}
+
+// reloadableSettings are parsed reloadable settings, that are applied to running server together.
+type reloadableSettings struct {
+	trustedSubnet  []*net.IPNet
+	trustedProxies []*net.IPNet
+	logLevel       zapcore.Level
+}
+
+// ConfigReloader loads configuration again and applies reloadable settings (see config.IsReloadable)
+// to running server. Other changed settings are only logged, they are applied after restart.
+type ConfigReloader struct {
+	trustedSubnet  trustedSubnetUpdater
+	trustedProxies trustedSubnetUpdater
+	logger         configLogger
+	load           func() (*config.AppConfig, error)
+	current        *config.AppConfig
+	done           chan struct{}
+	mu             sync.Mutex
+}
+
+// NewConfigReloader is constructor function to create ConfigReloader. Current configuration is copied,
+// load is called on every reload and must return validated configuration.
+func NewConfigReloader(
+	current *config.AppConfig,
+	load func() (*config.AppConfig, error),
+	trustedSubnet trustedSubnetUpdater,
+	trustedProxies trustedSubnetUpdater,
+	logger configLogger,
+) *ConfigReloader {
+	currentCopy := *current
+
+	return &ConfigReloader{
+		trustedSubnet:  trustedSubnet,
+		trustedProxies: trustedProxies,
+		logger:         logger,
+		load:           load,
+		current:        &currentCopy,
+		done:           make(chan struct{}),
+	}
+}
+
+// Reload loads configuration and applies changed reloadable settings. Invalid configuration is rejected
+// with domain.ErrInvalidConfig and nothing is applied.
+func (r *ConfigReloader) Reload() (domain.ConfigReload, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	newConfig, err := r.load()
+	if err != nil {
+		r.logger.Warn("config reload is rejected", zap.Error(err))
+		return domain.ConfigReload{}, fmt.Errorf("%w: %s", domain.ErrInvalidConfig, err)
+	}
+
+	result := domain.ConfigReload{
+		Applied:         make([]domain.ConfigChange, 0),
+		RestartRequired: make([]domain.ConfigChange, 0),
+	}
+
+	for _, change := range config.Diff(r.current, newConfig) {
+		if config.IsReloadable(change.Key) {
+			result.Applied = append(result.Applied, change)
+		} else {
+			result.RestartRequired = append(result.RestartRequired, change)
+		}
+	}
+
+	// all settings are parsed before any of them is applied, so configuration is never applied partially
+	settings, err := parseReloadableSettings(newConfig)
+	if err != nil {
+		r.logger.Warn("config reload is rejected", zap.Error(err))
+		return domain.ConfigReload{}, fmt.Errorf("%w: %s", domain.ErrInvalidConfig, err)
+	}
+
+	r.apply(newConfig, settings)
+
+	for _, change := range result.Applied {
+		r.logger.Info("config reloaded", zap.String("key", change.Key), zap.String("old", change.Old), zap.String("new", change.New))
+	}
+
+	for _, change := range result.RestartRequired {
+		r.logger.Warn(
+			"config reloaded, restart is required to apply change",
+			zap.String("key", change.Key), zap.String("old", change.Old), zap.String("new", change.New),
+		)
+	}
+
+	if len(result.Applied) == 0 && len(result.RestartRequired) == 0 {
+		r.logger.Info("config reloaded: nothing changed")
+	}
+
+	return result, nil
+}
+
+func parseReloadableSettings(newConfig *config.AppConfig) (reloadableSettings, error) {
+	trustedSubnet, err := subnets.Parse(newConfig.TrustedSubnet)
+	if err != nil {
+		return reloadableSettings{}, fmt.Errorf("trusted_subnet: %w", err)
+	}
+
+	trustedProxies, err := subnets.Parse(newConfig.TrustedProxies)
+	if err != nil {
+		return reloadableSettings{}, fmt.Errorf("trusted_proxies: %w", err)
+	}
+
+	logLevel, err := zapcore.ParseLevel(newConfig.LogLevel)
+	if err != nil {
+		return reloadableSettings{}, fmt.Errorf("log_level: %w", err)
+	}
+
+	return reloadableSettings{
+		trustedSubnet:  trustedSubnet,
+		trustedProxies: trustedProxies,
+		logLevel:       logLevel,
+	}, nil
+}
+
+// apply swaps changed reloadable settings, applying of parsed settings cannot fail.
+func (r *ConfigReloader) apply(newConfig *config.AppConfig, settings reloadableSettings) {
+	if r.current.TrustedSubnet != newConfig.TrustedSubnet {
+		r.trustedSubnet.Set(settings.trustedSubnet)
+		r.current.TrustedSubnet = newConfig.TrustedSubnet
+	}
+
+	if r.current.TrustedProxies != newConfig.TrustedProxies {
+		r.trustedProxies.Set(settings.trustedProxies)
+		r.current.TrustedProxies = newConfig.TrustedProxies
+	}
+
+	if r.current.LogLevel != newConfig.LogLevel {
+		r.logger.SetLevel(settings.logLevel)
+		r.current.LogLevel = newConfig.LogLevel
+	}
+}
+
+// Start reloads configuration on every signal, until ctx is done.
+func (r *ConfigReloader) Start(ctx context.Context, signals <-chan os.Signal) {
+	defer close(r.done)
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-signals:
+			// result and error are logged by Reload
+			_, _ = r.Reload()
+		}
+	}
+}
+
+// Done returns channel that is closed when reloading on signals is stopped.
+func (r *ConfigReloader) Done() <-chan struct{} {
+	return r.done
+}
@@ -0,0 +1,158 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"os"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"go.uber.org/zap/zapcore"
+
+	"github.com/MowlCoder/go-url-shortener/internal/config"
+	"github.com/MowlCoder/go-url-shortener/internal/domain"
+	servicesmocks "github.com/MowlCoder/go-url-shortener/internal/services/mocks"
+)
+
+func TestConfigReloader_Reload(t *testing.T) {
+	current := config.Default()
+	current.TrustedSubnet = "192.168.1.0/24"
+
+	t.Run("applies reloadable settings", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		trustedSubnet := servicesmocks.NewMocktrustedSubnetUpdater(ctrl)
+		trustedProxies := servicesmocks.NewMocktrustedSubnetUpdater(ctrl)
+		logger := servicesmocks.NewMockconfigLogger(ctrl)
+
+		newConfig := *current
+		newConfig.TrustedSubnet = "10.0.0.0/8"
+		newConfig.TrustedProxies = "10.0.0.1"
+		newConfig.LogLevel = "debug"
+		newConfig.BaseHTTPAddr = "localhost:9090"
+
+		reloader := NewConfigReloader(current, func() (*config.AppConfig, error) {
+			loaded := newConfig
+			return &loaded, nil
+		}, trustedSubnet, trustedProxies, logger)
+
+		trustedSubnet.EXPECT().Set(gomock.Len(1)).Times(1)
+		trustedProxies.EXPECT().Set(gomock.Len(1)).Times(1)
+		logger.EXPECT().SetLevel(zapcore.DebugLevel).Times(1)
+		logger.EXPECT().Info("config reloaded", gomock.Any(), gomock.Any(), gomock.Any()).Times(3)
+		logger.EXPECT().Warn(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Times(1)
+
+		result, err := reloader.Reload()
+		require.NoError(t, err)
+
+		assert.ElementsMatch(t, []domain.ConfigChange{
+			{Key: "trusted_subnet", Old: "192.168.1.0/24", New: "10.0.0.0/8"},
+			{Key: "trusted_proxies", Old: "127.0.0.1,::1", New: "10.0.0.1"},
+			{Key: "log_level", Old: "info", New: "debug"},
+		}, result.Applied)
+		assert.Equal(t, []domain.ConfigChange{
+			{Key: "base_http_addr", Old: "localhost:8080", New: "localhost:9090"},
+		}, result.RestartRequired)
+
+		// applied settings are not applied again, settings requiring restart are still reported
+		logger.EXPECT().Warn(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Times(1)
+
+		result, err = reloader.Reload()
+		require.NoError(t, err)
+
+		assert.Empty(t, result.Applied)
+		assert.Len(t, result.RestartRequired, 1)
+	})
+
+	t.Run("rejects invalid config", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		trustedSubnet := servicesmocks.NewMocktrustedSubnetUpdater(ctrl)
+		logger := servicesmocks.NewMockconfigLogger(ctrl)
+
+		reloader := NewConfigReloader(current, func() (*config.AppConfig, error) {
+			return nil, errors.New("trusted_subnet: invalid CIDR address: bad")
+		}, trustedSubnet, trustedSubnet, logger)
+
+		logger.EXPECT().Warn("config reload is rejected", gomock.Any()).Times(1)
+
+		_, err := reloader.Reload()
+		assert.ErrorIs(t, err, domain.ErrInvalidConfig)
+		assert.ErrorContains(t, err, "trusted_subnet")
+	})
+
+	t.Run("applies nothing, when one of settings is invalid", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		trustedSubnet := servicesmocks.NewMocktrustedSubnetUpdater(ctrl)
+		logger := servicesmocks.NewMockconfigLogger(ctrl)
+
+		reloader := NewConfigReloader(current, func() (*config.AppConfig, error) {
+			loaded := *current
+			loaded.TrustedSubnet = "10.0.0.0/8"
+			loaded.LogLevel = "verbose"
+			return &loaded, nil
+		}, trustedSubnet, trustedSubnet, logger)
+
+		// mocks fail the test, if trusted subnet is set before invalid log level is found
+		logger.EXPECT().Warn("config reload is rejected", gomock.Any()).Times(1)
+
+		_, err := reloader.Reload()
+		assert.ErrorIs(t, err, domain.ErrInvalidConfig)
+		assert.ErrorContains(t, err, "log_level")
+	})
+
+	t.Run("nothing changed", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		trustedSubnet := servicesmocks.NewMocktrustedSubnetUpdater(ctrl)
+		logger := servicesmocks.NewMockconfigLogger(ctrl)
+
+		reloader := NewConfigReloader(current, func() (*config.AppConfig, error) {
+			loaded := *current
+			return &loaded, nil
+		}, trustedSubnet, trustedSubnet, logger)
+
+		logger.EXPECT().Info("config reloaded: nothing changed").Times(1)
+
+		result, err := reloader.Reload()
+		require.NoError(t, err)
+
+		assert.Empty(t, result.Applied)
+		assert.Empty(t, result.RestartRequired)
+	})
+}
+
+func TestConfigReloader_Start(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	trustedSubnet := servicesmocks.NewMocktrustedSubnetUpdater(ctrl)
+	logger := servicesmocks.NewMockconfigLogger(ctrl)
+
+	loaded := make(chan struct{}, 1)
+	reloader := NewConfigReloader(config.Default(), func() (*config.AppConfig, error) {
+		loaded <- struct{}{}
+		return config.Default(), nil
+	}, trustedSubnet, trustedSubnet, logger)
+
+	logger.EXPECT().Info(gomock.Any(), gomock.Any()).AnyTimes()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	signals := make(chan os.Signal, 1)
+	go reloader.Start(ctx, signals)
+
+	signals <- syscall.SIGHUP
+
+	select {
+	case <-loaded:
+	case <-time.After(time.Second):
+		t.Fatal("config is not reloaded on signal")
+	}
+
+	cancel()
+
+	select {
+	case <-reloader.Done():
+	case <-time.After(time.Second):
+		t.Fatal("reloader is not stopped")
+	}
+}
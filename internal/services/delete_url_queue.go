@@ -2,74 +2,217 @@ package services
 
 import (
 	"context"
-	"fmt"
+	"sync/atomic"
 	"time"
 
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+
 	"github.com/MowlCoder/go-url-shortener/internal/domain"
 )
 
+// Default values of DeleteURLQueueOptions.
+const (
+	DefaultDeleteFlushInterval = time.Second * 5
+	DefaultDeleteBatchSize     = 500
+)
+
+const deleteQueueMaxRetryInterval = time.Minute
+
 type urlStorage interface {
 	DoDeleteURLTasks(ctx context.Context, tasks []domain.DeleteURLsTask) error
+	SaveDeleteTask(ctx context.Context, task domain.DeleteURLsTask) error
+	GetPendingDeleteTasks(ctx context.Context, limit int) ([]domain.DeleteURLsTask, error)
+	FailDeleteTasks(ctx context.Context, ids []string, reason string) error
 }
 
 type logger interface {
-	Info(msg string)
+	Info(msg string, fields ...zap.Field)
+	Warn(msg string, fields ...zap.Field)
+	Error(msg string, fields ...zap.Field)
+}
+
+// DeleteURLQueueOptions for setting up DeleteURLQueue.
+type DeleteURLQueueOptions struct {
+	FlushInterval time.Duration
+	BatchSize     int
 }
 
 // DeleteURLQueue responsible for accepting tasks for url deletion and do them in order.
+// Tasks are persisted in storage before they are acknowledged, so they are not lost on crash.
 type DeleteURLQueue struct {
-	ch         chan *domain.DeleteURLsTask
-	urlStorage urlStorage
-	logger     logger
-	tasks      []domain.DeleteURLsTask
+	urlStorage    urlStorage
+	logger        logger
+	eventEmitter  linkEventEmitter
+	now           func() time.Time
+	wakeup        chan struct{}
+	done          chan struct{}
+	retryAt       time.Time
+	flushInterval time.Duration
+	batchSize     int
+	failures      int
+	pushed        int64
 }
 
 // NewDeleteURLQueue is contructor function to create DeleteURLQueue.
-func NewDeleteURLQueue(urlStorage urlStorage, logger logger, maxWorker int) *DeleteURLQueue {
+// After every flush link.deleted events are emitted through eventEmitter.
+func NewDeleteURLQueue(
+	urlStorage urlStorage,
+	logger logger,
+	eventEmitter linkEventEmitter,
+	options DeleteURLQueueOptions,
+) *DeleteURLQueue {
+	if options.FlushInterval <= 0 {
+		options.FlushInterval = DefaultDeleteFlushInterval
+	}
+
+	if options.BatchSize <= 0 {
+		options.BatchSize = DefaultDeleteBatchSize
+	}
+
 	return &DeleteURLQueue{
-		urlStorage: urlStorage,
-		logger:     logger,
-		ch:         make(chan *domain.DeleteURLsTask, maxWorker),
-		tasks:      make([]domain.DeleteURLsTask, 0, 500),
+		urlStorage:    urlStorage,
+		logger:        logger,
+		eventEmitter:  eventEmitter,
+		now:           time.Now,
+		wakeup:        make(chan struct{}, 1),
+		done:          make(chan struct{}),
+		flushInterval: options.FlushInterval,
+		batchSize:     options.BatchSize,
 	}
 }
 
-// Start starts queue job. Queue accepting tasks through channel and every 5 seconds do tasks.
+// Start starts queue job. Queue does pending tasks every flush interval or earlier,
+// when batch size of tasks is pushed. If storage fails, tasks are retried with growing interval.
+// When ctx is done queue drains pending tasks and stops, after that Done channel is closed.
 func (q *DeleteURLQueue) Start(ctx context.Context) {
-	ticker := time.NewTicker(time.Second * 5)
+	defer close(q.done)
+
+	ticker := time.NewTicker(q.flushInterval)
 	defer ticker.Stop()
 
 	for {
 		select {
-		case task := <-q.ch:
-			q.tasks = append(q.tasks, *task)
 		case <-ctx.Done():
-			if err := q.doDeleteTasks(); err != nil {
-				q.logger.Info(err.Error())
-			}
+			q.drain()
+			return
+		case <-q.wakeup:
 		case <-ticker.C:
-			if err := q.doDeleteTasks(); err != nil {
-				q.logger.Info(err.Error())
-			}
 		}
+
+		if q.now().Before(q.retryAt) {
+			continue
+		}
+
+		q.doAllDeleteTasks()
 	}
 }
 
-// Push pushes task to queue.
-func (q *DeleteURLQueue) Push(task *domain.DeleteURLsTask) {
-	q.ch <- task
+// Done returns channel that is closed when queue is stopped and drained.
+func (q *DeleteURLQueue) Done() <-chan struct{} {
+	return q.done
 }
 
-func (q *DeleteURLQueue) doDeleteTasks() error {
-	if len(q.tasks) == 0 {
-		return nil
-	}
+// Push persists task in storage and schedules it. Task gets id, which can be used to check its status.
+func (q *DeleteURLQueue) Push(ctx context.Context, task *domain.DeleteURLsTask) error {
+	task.ID = uuid.NewString()
+	task.Status = domain.DeleteTaskPending
+	task.CreatedAt = q.now().UTC()
 
-	if err := q.urlStorage.DoDeleteURLTasks(context.Background(), q.tasks); err != nil {
+	if err := q.urlStorage.SaveDeleteTask(ctx, *task); err != nil {
 		return err
 	}
 
-	q.logger.Info(fmt.Sprintf("Successfully did %d delete url tasks", len(q.tasks)))
-	q.tasks = q.tasks[0:]
+	if atomic.AddInt64(&q.pushed, 1) >= int64(q.batchSize) {
+		select {
+		case q.wakeup <- struct{}{}:
+		default:
+		}
+	}
+
 	return nil
 }
+
+func (q *DeleteURLQueue) drain() {
+	q.doAllDeleteTasks()
+
+	if q.failures > 0 {
+		q.logger.Warn("delete url queue stopped with pending tasks, they will be done after restart")
+	}
+}
+
+func (q *DeleteURLQueue) doAllDeleteTasks() {
+	atomic.StoreInt64(&q.pushed, 0)
+
+	for {
+		count, err := q.doDeleteTasks()
+
+		if err != nil {
+			q.failures++
+			q.retryAt = q.now().Add(q.retryInterval())
+			q.logger.Error("do delete url tasks", zap.Int("attempt", q.failures), zap.Error(err))
+			return
+		}
+
+		q.failures = 0
+
+		if count < q.batchSize {
+			return
+		}
+	}
+}
+
+func (q *DeleteURLQueue) doDeleteTasks() (int, error) {
+	ctx := context.Background()
+
+	tasks, err := q.urlStorage.GetPendingDeleteTasks(ctx, q.batchSize)
+	if err != nil {
+		return 0, err
+	}
+
+	if len(tasks) == 0 {
+		return 0, nil
+	}
+
+	if err := q.urlStorage.DoDeleteURLTasks(ctx, tasks); err != nil {
+		taskIDs := make([]string, 0, len(tasks))
+
+		for _, task := range tasks {
+			taskIDs = append(taskIDs, task.ID)
+		}
+
+		if failErr := q.urlStorage.FailDeleteTasks(ctx, taskIDs, err.Error()); failErr != nil {
+			q.logger.Error("fail delete url tasks", zap.Error(failErr))
+		}
+
+		return 0, err
+	}
+
+	q.logger.Info("delete url tasks done", zap.Int("count", len(tasks)))
+
+	for _, task := range tasks {
+		deletedLinks := make([]domain.LinkEventData, 0, len(task.ShortURLs))
+
+		for _, shortURL := range task.ShortURLs {
+			deletedLinks = append(deletedLinks, domain.LinkEventData{ShortURL: shortURL})
+		}
+
+		q.eventEmitter.Emit(ctx, domain.EventLinkDeleted, task.UserID, deletedLinks)
+	}
+
+	return len(tasks), nil
+}
+
+func (q *DeleteURLQueue) retryInterval() time.Duration {
+	interval := q.flushInterval
+
+	for i := 1; i < q.failures; i++ {
+		interval *= 2
+
+		if interval >= deleteQueueMaxRetryInterval {
+			return deleteQueueMaxRetryInterval
+		}
+	}
+
+	return interval
+}
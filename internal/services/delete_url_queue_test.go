@@ -1,8 +1,10 @@
 package services
 
 import (
+	"context"
 	"errors"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -16,12 +18,23 @@ func TestNewDeleteURLQueue(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	urlStorageInstance := servicesmocks.NewMockurlStorage(ctrl)
 	loggerInstance := servicesmocks.NewMocklogger(ctrl)
-	maxWorker := 10
+	eventEmitter := servicesmocks.NewMocklinkEventEmitter(ctrl)
 
 	t.Run("new", func(t *testing.T) {
-		queue := NewDeleteURLQueue(urlStorageInstance, loggerInstance, maxWorker)
+		queue := NewDeleteURLQueue(urlStorageInstance, loggerInstance, eventEmitter, DeleteURLQueueOptions{
+			FlushInterval: time.Second,
+			BatchSize:     10,
+		})
 		require.NotNil(t, queue)
-		assert.Equal(t, cap(queue.ch), maxWorker)
+		assert.Equal(t, time.Second, queue.flushInterval)
+		assert.Equal(t, 10, queue.batchSize)
+	})
+
+	t.Run("default options", func(t *testing.T) {
+		queue := NewDeleteURLQueue(urlStorageInstance, loggerInstance, eventEmitter, DeleteURLQueueOptions{})
+		require.NotNil(t, queue)
+		assert.Equal(t, DefaultDeleteFlushInterval, queue.flushInterval)
+		assert.Equal(t, DefaultDeleteBatchSize, queue.batchSize)
 	})
 }
 
@@ -29,15 +42,38 @@ func TestDeleteURLQueue_Push(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	urlStorageInstance := servicesmocks.NewMockurlStorage(ctrl)
 	loggerInstance := servicesmocks.NewMocklogger(ctrl)
-	maxWorker := 10
-	queue := NewDeleteURLQueue(urlStorageInstance, loggerInstance, maxWorker)
+	eventEmitter := servicesmocks.NewMocklinkEventEmitter(ctrl)
+	queue := NewDeleteURLQueue(urlStorageInstance, loggerInstance, eventEmitter, DeleteURLQueueOptions{
+		BatchSize: 2,
+	})
 
 	t.Run("valid", func(t *testing.T) {
-		queue.Push(&domain.DeleteURLsTask{})
-		assert.Equal(t, len(queue.ch), 1)
+		urlStorageInstance.
+			EXPECT().
+			SaveDeleteTask(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(ctx context.Context, task domain.DeleteURLsTask) error {
+				assert.NotEmpty(t, task.ID)
+				assert.Equal(t, domain.DeleteTaskPending, task.Status)
+				return nil
+			}).
+			Times(2)
+
+		task := &domain.DeleteURLsTask{UserID: "1"}
+		require.NoError(t, queue.Push(context.Background(), task))
+		assert.NotEmpty(t, task.ID)
+		assert.Len(t, queue.wakeup, 0)
+
+		require.NoError(t, queue.Push(context.Background(), &domain.DeleteURLsTask{UserID: "1"}))
+		assert.Len(t, queue.wakeup, 1)
+	})
+
+	t.Run("storage error", func(t *testing.T) {
+		urlStorageInstance.
+			EXPECT().
+			SaveDeleteTask(gomock.Any(), gomock.Any()).
+			Return(errors.New("undefined behavior"))
 
-		queue.Push(&domain.DeleteURLsTask{})
-		assert.Equal(t, len(queue.ch), 2)
+		require.Error(t, queue.Push(context.Background(), &domain.DeleteURLsTask{UserID: "1"}))
 	})
 }
 
@@ -45,13 +81,24 @@ func TestDeleteURLQueue_doDeleteTasks(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	urlStorageInstance := servicesmocks.NewMockurlStorage(ctrl)
 	loggerInstance := servicesmocks.NewMocklogger(ctrl)
-	maxWorker := 10
-	queue := NewDeleteURLQueue(urlStorageInstance, loggerInstance, maxWorker)
+	eventEmitter := servicesmocks.NewMocklinkEventEmitter(ctrl)
+	queue := NewDeleteURLQueue(urlStorageInstance, loggerInstance, eventEmitter, DeleteURLQueueOptions{})
+
+	tasks := []domain.DeleteURLsTask{
+		{
+			ID:     "1",
+			UserID: "1",
+		},
+		{
+			ID:     "2",
+			UserID: "2",
+		},
+	}
 
 	type TestCase struct {
 		PrepareServiceFunc func()
 		Name               string
-		Tasks              []domain.DeleteURLsTask
+		ExpectedCount      int
 		IsError            bool
 	}
 
@@ -62,25 +109,34 @@ func TestDeleteURLQueue_doDeleteTasks(t *testing.T) {
 			PrepareServiceFunc: func() {
 				urlStorageInstance.
 					EXPECT().
-					DoDeleteURLTasks(gomock.Any(), gomock.Any()).
+					GetPendingDeleteTasks(gomock.Any(), DefaultDeleteBatchSize).
+					Return(tasks, nil)
+
+				urlStorageInstance.
+					EXPECT().
+					DoDeleteURLTasks(gomock.Any(), tasks).
 					Return(nil)
 
 				loggerInstance.
 					EXPECT().
-					Info(gomock.Any())
-			},
-			Tasks: []domain.DeleteURLsTask{
-				{
-					UserID: "1",
-				},
-				{
-					UserID: "2",
-				},
+					Info(gomock.Any(), gomock.Any())
+
+				eventEmitter.
+					EXPECT().
+					Emit(gomock.Any(), domain.EventLinkDeleted, gomock.Any(), gomock.Any()).
+					Times(2)
 			},
+			ExpectedCount: 2,
 		},
 		{
 			Name:    "valid (no tasks)",
 			IsError: false,
+			PrepareServiceFunc: func() {
+				urlStorageInstance.
+					EXPECT().
+					GetPendingDeleteTasks(gomock.Any(), DefaultDeleteBatchSize).
+					Return([]domain.DeleteURLsTask{}, nil)
+			},
 		},
 		{
 			Name:    "invalid",
@@ -88,35 +144,123 @@ func TestDeleteURLQueue_doDeleteTasks(t *testing.T) {
 			PrepareServiceFunc: func() {
 				urlStorageInstance.
 					EXPECT().
-					DoDeleteURLTasks(gomock.Any(), gomock.Any()).
+					GetPendingDeleteTasks(gomock.Any(), DefaultDeleteBatchSize).
+					Return(tasks, nil)
+
+				urlStorageInstance.
+					EXPECT().
+					DoDeleteURLTasks(gomock.Any(), tasks).
 					Return(errors.New("undefined behavior"))
-			},
-			Tasks: []domain.DeleteURLsTask{
-				{
-					UserID: "1",
-				},
-				{
-					UserID: "2",
-				},
+
+				urlStorageInstance.
+					EXPECT().
+					FailDeleteTasks(gomock.Any(), []string{"1", "2"}, "undefined behavior").
+					Return(nil)
 			},
 		},
 	}
 
 	for _, tc := range testCases {
 		t.Run(tc.Name, func(t *testing.T) {
-			queue.tasks = tc.Tasks
-
 			if tc.PrepareServiceFunc != nil {
 				tc.PrepareServiceFunc()
 			}
 
-			err := queue.doDeleteTasks()
+			count, err := queue.doDeleteTasks()
 
 			if tc.IsError {
 				require.Error(t, err)
 			} else {
 				require.NoError(t, err)
+				assert.Equal(t, tc.ExpectedCount, count)
 			}
 		})
 	}
 }
+
+func TestDeleteURLQueue_doAllDeleteTasks(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	urlStorageInstance := servicesmocks.NewMockurlStorage(ctrl)
+	loggerInstance := servicesmocks.NewMocklogger(ctrl)
+	eventEmitter := servicesmocks.NewMocklinkEventEmitter(ctrl)
+	queue := NewDeleteURLQueue(urlStorageInstance, loggerInstance, eventEmitter, DeleteURLQueueOptions{
+		FlushInterval: time.Second,
+	})
+
+	now := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+	queue.now = func() time.Time {
+		return now
+	}
+
+	t.Run("retry with growing interval", func(t *testing.T) {
+		urlStorageInstance.
+			EXPECT().
+			GetPendingDeleteTasks(gomock.Any(), gomock.Any()).
+			Return(nil, errors.New("undefined behavior")).
+			Times(2)
+
+		loggerInstance.
+			EXPECT().
+			Error(gomock.Any(), gomock.Any(), gomock.Any()).
+			Times(2)
+
+		queue.doAllDeleteTasks()
+		assert.Equal(t, now.Add(time.Second), queue.retryAt)
+
+		queue.doAllDeleteTasks()
+		assert.Equal(t, now.Add(time.Second*2), queue.retryAt)
+	})
+
+	t.Run("recovered", func(t *testing.T) {
+		urlStorageInstance.
+			EXPECT().
+			GetPendingDeleteTasks(gomock.Any(), gomock.Any()).
+			Return([]domain.DeleteURLsTask{}, nil)
+
+		queue.doAllDeleteTasks()
+		assert.Equal(t, 0, queue.failures)
+	})
+}
+
+func TestDeleteURLQueue_Start(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	urlStorageInstance := servicesmocks.NewMockurlStorage(ctrl)
+	loggerInstance := servicesmocks.NewMocklogger(ctrl)
+	eventEmitter := servicesmocks.NewMocklinkEventEmitter(ctrl)
+	queue := NewDeleteURLQueue(urlStorageInstance, loggerInstance, eventEmitter, DeleteURLQueueOptions{
+		FlushInterval: time.Hour,
+	})
+
+	t.Run("drain on stop", func(t *testing.T) {
+		tasks := []domain.DeleteURLsTask{{ID: "1", UserID: "1", ShortURLs: []string{"abc"}}}
+
+		urlStorageInstance.
+			EXPECT().
+			GetPendingDeleteTasks(gomock.Any(), gomock.Any()).
+			Return(tasks, nil)
+
+		urlStorageInstance.
+			EXPECT().
+			DoDeleteURLTasks(gomock.Any(), tasks).
+			Return(nil)
+
+		loggerInstance.
+			EXPECT().
+			Info(gomock.Any(), gomock.Any())
+
+		eventEmitter.
+			EXPECT().
+			Emit(gomock.Any(), domain.EventLinkDeleted, "1", []domain.LinkEventData{{ShortURL: "abc"}})
+
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		queue.Start(ctx)
+
+		select {
+		case <-queue.Done():
+		default:
+			t.Fatal("queue is not done after stop")
+		}
+	})
+}
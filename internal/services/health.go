@@ -0,0 +1,160 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/MowlCoder/go-url-shortener/internal/domain"
+)
+
+// Names of components in readiness report.
+const (
+	HealthComponentLifecycle      = "lifecycle"
+	HealthComponentStorage        = "storage"
+	HealthComponentMigrations     = "migrations"
+	HealthComponentDeleteURLQueue = "delete_url_queue"
+)
+
+// DefaultMaxDeleteBacklog is default count of pending delete tasks, after which application is not ready.
+const DefaultMaxDeleteBacklog = 10000
+
+// Reasons of components being down in readiness report.
+const (
+	HealthReasonShuttingDown       = "application is shutting down"
+	HealthReasonStorageUnreachable = "storage is unreachable"
+	HealthReasonMigrationsPending  = "migrations are not applied"
+	HealthReasonDeleteBacklog      = "delete url queue is backlogged"
+	HealthReasonCheckFailed        = "check failed"
+)
+
+const healthCheckTimeout = time.Second * 2
+
+var (
+	errShuttingDown          = errors.New("application is shutting down")
+	errDeleteQueueBacklogged = errors.New("delete url queue is backlogged")
+)
+
+type healthStorage interface {
+	Ping(ctx context.Context) error
+	CheckMigrations(ctx context.Context) error
+	CountPendingDeleteTasks(ctx context.Context) (int, error)
+}
+
+type readinessProvider interface {
+	IsReady() bool
+}
+
+// HealthService is service to check liveness and readiness of application.
+type HealthService struct {
+	storage          healthStorage
+	lifecycle        readinessProvider
+	logger           logger
+	maxDeleteBacklog int
+}
+
+// NewHealthService is constructor function to create HealthService.
+// If maxDeleteBacklog is not positive, DefaultMaxDeleteBacklog is used. Failed checks are logged with details.
+func NewHealthService(storage healthStorage, lifecycle readinessProvider, logger logger, maxDeleteBacklog int) *HealthService {
+	if maxDeleteBacklog <= 0 {
+		maxDeleteBacklog = DefaultMaxDeleteBacklog
+	}
+
+	return &HealthService{
+		storage:          storage,
+		lifecycle:        lifecycle,
+		logger:           logger,
+		maxDeleteBacklog: maxDeleteBacklog,
+	}
+}
+
+// Liveness reports if process is alive. It does not depend on external components.
+func (s *HealthService) Liveness(ctx context.Context) domain.HealthReport {
+	return domain.HealthReport{
+		Status: domain.HealthStatusUp,
+	}
+}
+
+// Readiness reports if application is ready to accept traffic:
+// it is not shutting down, storage is reachable, migrations are applied and delete url queue is not backlogged.
+func (s *HealthService) Readiness(ctx context.Context) domain.HealthReport {
+	ctx, cancel := context.WithTimeout(ctx, healthCheckTimeout)
+	defer cancel()
+
+	report := domain.HealthReport{
+		Status: domain.HealthStatusUp,
+		Components: map[string]domain.HealthComponent{
+			HealthComponentLifecycle:      s.makeHealthComponent(HealthComponentLifecycle, s.checkLifecycle()),
+			HealthComponentStorage:        s.makeHealthComponent(HealthComponentStorage, s.storage.Ping(ctx)),
+			HealthComponentMigrations:     s.makeHealthComponent(HealthComponentMigrations, s.storage.CheckMigrations(ctx)),
+			HealthComponentDeleteURLQueue: s.makeHealthComponent(HealthComponentDeleteURLQueue, s.checkDeleteURLQueue(ctx)),
+		},
+	}
+
+	for _, component := range report.Components {
+		if component.Status != domain.HealthStatusUp {
+			report.Status = domain.HealthStatusDown
+			break
+		}
+	}
+
+	return report
+}
+
+func (s *HealthService) checkLifecycle() error {
+	if !s.lifecycle.IsReady() {
+		return errShuttingDown
+	}
+
+	return nil
+}
+
+func (s *HealthService) checkDeleteURLQueue(ctx context.Context) error {
+	count, err := s.storage.CountPendingDeleteTasks(ctx)
+	if err != nil {
+		return err
+	}
+
+	if count > s.maxDeleteBacklog {
+		return fmt.Errorf("%w: %d pending tasks, max backlog is %d", errDeleteQueueBacklogged, count, s.maxDeleteBacklog)
+	}
+
+	return nil
+}
+
+// makeHealthComponent returns health of component. Error is logged, only fixed reason is put to report,
+// so details of infrastructure are not exposed to clients.
+func (s *HealthService) makeHealthComponent(name string, err error) domain.HealthComponent {
+	if err == nil {
+		return domain.HealthComponent{
+			Status: domain.HealthStatusUp,
+		}
+	}
+
+	if !errors.Is(err, errShuttingDown) {
+		s.logger.Warn("health check failed", zap.String("component", name), zap.Error(err))
+	}
+
+	return domain.HealthComponent{
+		Status: domain.HealthStatusDown,
+		Reason: healthReason(name, err),
+	}
+}
+
+func healthReason(name string, err error) string {
+	switch {
+	case errors.Is(err, errShuttingDown):
+		return HealthReasonShuttingDown
+	case errors.Is(err, domain.ErrMigrationsPending):
+		return HealthReasonMigrationsPending
+	case errors.Is(err, errDeleteQueueBacklogged):
+		return HealthReasonDeleteBacklog
+	case name == HealthComponentStorage:
+		return HealthReasonStorageUnreachable
+	default:
+		return HealthReasonCheckFailed
+	}
+}
@@ -0,0 +1,102 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+
+	"github.com/MowlCoder/go-url-shortener/internal/domain"
+	servicesmocks "github.com/MowlCoder/go-url-shortener/internal/services/mocks"
+)
+
+func TestHealthService_Liveness(t *testing.T) {
+	service := NewHealthService(nil, nil, nil, 0)
+
+	report := service.Liveness(context.Background())
+	assert.True(t, report.IsUp())
+}
+
+func TestHealthService_Readiness(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	storage := servicesmocks.NewMockhealthStorage(ctrl)
+	lifecycle := servicesmocks.NewMockreadinessProvider(ctrl)
+	loggerInstance := servicesmocks.NewMocklogger(ctrl)
+	service := NewHealthService(storage, lifecycle, loggerInstance, 10)
+
+	type TestCase struct {
+		PingErr            error
+		MigrationsErr      error
+		Name               string
+		DownComponent      string
+		ExpectedReason     string
+		PendingDeleteTasks int
+		IsShuttingDown     bool
+	}
+
+	testCases := []TestCase{
+		{
+			Name: "ready",
+		},
+		{
+			Name:           "shutting down",
+			IsShuttingDown: true,
+			DownComponent:  HealthComponentLifecycle,
+			ExpectedReason: HealthReasonShuttingDown,
+		},
+		{
+			Name:           "storage unreachable",
+			PingErr:        errors.New("dial tcp 10.0.0.5:5432: connection refused"),
+			DownComponent:  HealthComponentStorage,
+			ExpectedReason: HealthReasonStorageUnreachable,
+		},
+		{
+			Name:           "migrations pending",
+			MigrationsErr:  fmt.Errorf("%w: current version 3, expected 8", domain.ErrMigrationsPending),
+			DownComponent:  HealthComponentMigrations,
+			ExpectedReason: HealthReasonMigrationsPending,
+		},
+		{
+			Name:               "delete url queue backlogged",
+			PendingDeleteTasks: 11,
+			DownComponent:      HealthComponentDeleteURLQueue,
+			ExpectedReason:     HealthReasonDeleteBacklog,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.Name, func(t *testing.T) {
+			lifecycle.EXPECT().IsReady().Return(!tc.IsShuttingDown)
+			storage.EXPECT().Ping(gomock.Any()).Return(tc.PingErr)
+			storage.EXPECT().CheckMigrations(gomock.Any()).Return(tc.MigrationsErr)
+			storage.EXPECT().CountPendingDeleteTasks(gomock.Any()).Return(tc.PendingDeleteTasks, nil)
+
+			if tc.DownComponent != "" && !tc.IsShuttingDown {
+				loggerInstance.EXPECT().Warn("health check failed", gomock.Any())
+			}
+
+			report := service.Readiness(context.Background())
+
+			assert.Len(t, report.Components, 4)
+
+			if tc.DownComponent == "" {
+				assert.True(t, report.IsUp())
+				return
+			}
+
+			assert.False(t, report.IsUp())
+
+			for name, component := range report.Components {
+				if name == tc.DownComponent {
+					assert.Equal(t, domain.HealthStatusDown, component.Status)
+					assert.Equal(t, tc.ExpectedReason, component.Reason)
+				} else {
+					assert.Equal(t, domain.HealthStatusUp, component.Status)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,807 @@
+package services
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+
+	"github.com/MowlCoder/go-url-shortener/internal/domain"
+)
+
+// Formats of links import and export files.
+const (
+	LinksFormatCSV       = "csv"
+	LinksFormatJSONLines = "jsonl"
+)
+
+// MaxImportRows is max count of rows in one import file.
+const MaxImportRows = 50000
+
+const (
+	importChunkSize     = 100
+	exportPageSize      = 500
+	importJobRetention  = time.Hour * 24
+	maxAliasLength      = 20
+	csvTagsSeparator    = ";"
+	csvOriginalURLField = "original_url"
+	csvAliasField       = "alias"
+	csvTagsField        = "tags"
+	csvExpiresAtField   = "expires_at"
+	csvRedirectType     = "redirect_type"
+	csvUTMSourceField   = "utm_source"
+	csvUTMMediumField   = "utm_medium"
+	csvUTMCampaignField = "utm_campaign"
+	csvUTMTermField     = "utm_term"
+	csvUTMContentField  = "utm_content"
+	csvPassthroughField = "query_passthrough"
+	csvShortURLField    = "short_url"
+	csvClicksField      = "clicks"
+)
+
+var aliasRegexp = regexp.MustCompile(`^[A-Za-z0-9_-]+$`)
+
+var (
+	errInvalidOriginalURL = errors.New("original url must be absolute http or https url")
+	errInvalidAlias       = fmt.Errorf("alias must contain only latin letters, digits, '-' and '_' and be up to %d characters", maxAliasLength)
+	errInvalidExpiresAt   = errors.New("expires_at must be in RFC 3339 format")
+	errInvalidPassthrough = errors.New("query_passthrough must be true or false")
+	errLinkExpired        = errors.New("expires_at is in the past")
+	errDuplicateAlias     = errors.New("alias is used in previous row")
+	errDuplicateURL       = errors.New("url is used in previous row")
+	errURLExists          = errors.New("url is already shortened")
+	errLinkNotSaved       = errors.New("url is not saved")
+	errImportFailed       = errors.New("can not save url, try again later")
+)
+
+type linkShortener interface {
+	ShortBatchURL(ctx context.Context, urls []domain.ShortBatchURL, userID string) ([]domain.ShortBatchURL, error)
+	GetByShortURL(ctx context.Context, url string) (*domain.ShortenedURL, error)
+	GetUserURLsPage(ctx context.Context, userID string, afterShortURL string, limit int) ([]domain.ShortenedURL, error)
+}
+
+// linkRecord is one link in import or export file. ShortURL and Clicks are only exported.
+// RedirectRules are kept only in JSON lines files, CSV has no columns for them.
+type linkRecord struct {
+	ExpiresAt        *time.Time            `json:"expires_at,omitempty"`
+	UTM              *domain.UTMParams     `json:"utm,omitempty"`
+	OriginalURL      string                `json:"original_url"`
+	Alias            string                `json:"alias,omitempty"`
+	ShortURL         string                `json:"short_url,omitempty"`
+	RedirectType     string                `json:"redirect_type,omitempty"`
+	Tags             []string              `json:"tags,omitempty"`
+	RedirectRules    []domain.RedirectRule `json:"redirect_rules,omitempty"`
+	Clicks           int                   `json:"clicks,omitempty"`
+	QueryPassthrough bool                  `json:"query_passthrough,omitempty"`
+}
+
+type importRow struct {
+	err    error
+	record linkRecord
+	line   int
+}
+
+// importReader reads rows of import file one by one. Next returns io.EOF after the last row.
+type importReader interface {
+	Next() (importRow, error)
+}
+
+// linksWriter writes links of export file page by page.
+type linksWriter interface {
+	Write(records []linkRecord) error
+}
+
+// flusher is implemented by writers, that buffer data, like http.ResponseWriter.
+type flusher interface {
+	Flush()
+}
+
+// LinkImportService is service to import user links from CSV or JSON lines files in background
+// and export them to the same formats. Import jobs are kept in memory.
+type LinkImportService struct {
+	shortener        linkShortener
+	logger           logger
+	jobs             map[string]*domain.ImportJob
+	now              func() time.Time
+	baseShortURLAddr string
+	wg               sync.WaitGroup
+	mx               sync.RWMutex
+}
+
+// NewLinkImportService is constructor function to create LinkImportService.
+func NewLinkImportService(shortener linkShortener, logger logger, baseShortURLAddr string) *LinkImportService {
+	return &LinkImportService{
+		shortener:        shortener,
+		logger:           logger,
+		jobs:             make(map[string]*domain.ImportJob),
+		now:              time.Now,
+		baseShortURLAddr: baseShortURLAddr,
+	}
+}
+
+// IsSupportedLinksFormat reports if links can be imported and exported in given format.
+func IsSupportedLinksFormat(format string) bool {
+	return format == LinksFormatCSV || format == LinksFormatJSONLines
+}
+
+// StartImport copy body to temporary file, checking its structure and counting rows on the way,
+// and start background job, that reads rows from the file and shorts them in chunks.
+// Rows are never held in memory all at once. Rows, that can not be parsed, are reported as failed in job results.
+func (s *LinkImportService) StartImport(ctx context.Context, userID string, format string, body io.Reader) (*domain.ImportJob, error) {
+	if !IsSupportedLinksFormat(format) {
+		return nil, domain.ErrUnsupportedFormat
+	}
+
+	file, err := os.CreateTemp("", "shortener-import-*")
+	if err != nil {
+		return nil, err
+	}
+
+	total, err := countImportRows(format, io.TeeReader(body, file))
+	if err == nil {
+		_, err = file.Seek(0, io.SeekStart)
+	}
+
+	if err != nil {
+		file.Close()
+		os.Remove(file.Name())
+		return nil, err
+	}
+
+	job := &domain.ImportJob{
+		ID:        uuid.NewString(),
+		UserID:    userID,
+		Status:    domain.ImportJobPending,
+		CreatedAt: s.now().UTC(),
+		Total:     total,
+		Rows:      make([]domain.ImportRowResult, 0, total),
+	}
+
+	s.mx.Lock()
+	s.removeOutdatedJobs()
+	s.jobs[job.ID] = job
+	jobCopy := copyImportJob(job)
+	s.mx.Unlock()
+
+	s.wg.Add(1)
+	go s.runImport(job.ID, userID, format, file)
+
+	return jobCopy, nil
+}
+
+// GetImportJob return import job of user.
+func (s *LinkImportService) GetImportJob(ctx context.Context, id string, userID string) (*domain.ImportJob, error) {
+	s.mx.RLock()
+	defer s.mx.RUnlock()
+
+	job, ok := s.jobs[id]
+	if !ok || job.UserID != userID {
+		return nil, domain.ErrImportJobNotFound
+	}
+
+	return copyImportJob(job), nil
+}
+
+// Export write all not deleted user links to w in given format, ordered by short url.
+// Links are read from storage page by page, every page is written and flushed before the next one is read.
+func (s *LinkImportService) Export(ctx context.Context, userID string, format string, w io.Writer) error {
+	var writer linksWriter
+
+	switch format {
+	case LinksFormatCSV:
+		csvWriter, err := newCSVLinksWriter(w)
+		if err != nil {
+			return err
+		}
+
+		writer = csvWriter
+	case LinksFormatJSONLines:
+		writer = newJSONLinksWriter(w)
+	default:
+		return domain.ErrUnsupportedFormat
+	}
+
+	lastShortURL := ""
+
+	for {
+		urls, err := s.shortener.GetUserURLsPage(ctx, userID, lastShortURL, exportPageSize)
+		if err != nil {
+			return err
+		}
+
+		records := make([]linkRecord, 0, len(urls))
+
+		for _, url := range urls {
+			if url.IsDeleted {
+				continue
+			}
+
+			records = append(records, linkRecord{
+				OriginalURL:      url.OriginalURL,
+				Alias:            url.ShortURL,
+				ShortURL:         s.makeShortURL(url.ShortURL),
+				Tags:             url.Tags,
+				ExpiresAt:        url.ExpiresAt,
+				RedirectType:     url.RedirectType,
+				UTM:              url.UTM,
+				QueryPassthrough: url.QueryPassthrough,
+				RedirectRules:    url.RedirectRules,
+				Clicks:           url.Clicks,
+			})
+		}
+
+		if err := writer.Write(records); err != nil {
+			return err
+		}
+
+		if f, ok := w.(flusher); ok {
+			f.Flush()
+		}
+
+		if len(urls) < exportPageSize {
+			return nil
+		}
+
+		lastShortURL = urls[len(urls)-1].ShortURL
+	}
+}
+
+// Shutdown waits for running import jobs to finish.
+func (s *LinkImportService) Shutdown(ctx context.Context) error {
+	done := make(chan struct{})
+
+	go func() {
+		s.wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (s *LinkImportService) runImport(jobID string, userID string, format string, file *os.File) {
+	defer s.wg.Done()
+	defer os.Remove(file.Name())
+	defer file.Close()
+
+	ctx := context.Background()
+	seen := newImportSeen()
+
+	s.updateJob(jobID, func(job *domain.ImportJob) {
+		job.Status = domain.ImportJobRunning
+	})
+
+	reader, err := newImportReader(format, file)
+
+	for err == nil {
+		var rows []importRow
+
+		rows, err = readImportChunk(reader)
+		if len(rows) == 0 {
+			break
+		}
+
+		results := s.importChunk(ctx, userID, rows, seen)
+
+		s.updateJob(jobID, func(job *domain.ImportJob) {
+			for _, result := range results {
+				if result.Error == "" {
+					job.Succeeded++
+				} else {
+					job.Failed++
+				}
+			}
+
+			job.Rows = append(job.Rows, results...)
+		})
+	}
+
+	// file is already checked by StartImport, so it can only fail to be read again
+	if err != nil && !errors.Is(err, io.EOF) {
+		s.logger.Error("read import file", zap.Error(err))
+	}
+
+	s.updateJob(jobID, func(job *domain.ImportJob) {
+		finishedAt := s.now().UTC()
+		job.Status = domain.ImportJobCompleted
+		job.FinishedAt = &finishedAt
+	})
+}
+
+// readImportChunk reads up to importChunkSize rows. Error is returned together with rows read before it.
+func readImportChunk(reader importReader) ([]importRow, error) {
+	rows := make([]importRow, 0, importChunkSize)
+
+	for len(rows) < importChunkSize {
+		row, err := reader.Next()
+		if err != nil {
+			return rows, err
+		}
+
+		rows = append(rows, row)
+	}
+
+	return rows, nil
+}
+
+// importSeen are aliases and original urls of rows, that are already passed to saving in import job.
+type importSeen struct {
+	aliases      map[string]struct{}
+	originalURLs map[string]struct{}
+}
+
+func newImportSeen() *importSeen {
+	return &importSeen{
+		aliases:      make(map[string]struct{}),
+		originalURLs: make(map[string]struct{}),
+	}
+}
+
+// importChunk saves valid rows in one batch. Results are matched to rows by their index in chunk,
+// url, that was already shortened (maybe by another user), is reported as failed row.
+func (s *LinkImportService) importChunk(
+	ctx context.Context,
+	userID string,
+	rows []importRow,
+	seen *importSeen,
+) []domain.ImportRowResult {
+	now := s.now()
+	results := make([]domain.ImportRowResult, len(rows))
+	batch := make([]domain.ShortBatchURL, 0, len(rows))
+	batchRows := make(map[int]struct{}, len(rows))
+
+	for i, row := range rows {
+		results[i] = domain.ImportRowResult{
+			Row:         row.line,
+			OriginalURL: row.record.OriginalURL,
+		}
+
+		err := row.err
+
+		if err == nil {
+			err = validateLinkRecord(row.record, now)
+		}
+
+		if _, ok := seen.originalURLs[row.record.OriginalURL]; err == nil && ok {
+			err = errDuplicateURL
+		}
+
+		if err == nil && row.record.Alias != "" {
+			err = s.reserveAlias(ctx, row.record.Alias, seen.aliases)
+		}
+
+		if err != nil {
+			results[i].Error = err.Error()
+			continue
+		}
+
+		seen.originalURLs[row.record.OriginalURL] = struct{}{}
+		batch = append(batch, domain.ShortBatchURL{
+			CorrelationID:    strconv.Itoa(i),
+			OriginalURL:      row.record.OriginalURL,
+			ShortURL:         row.record.Alias,
+			Tags:             row.record.Tags,
+			ExpiresAt:        row.record.ExpiresAt,
+			RedirectType:     row.record.RedirectType,
+			UTM:              row.record.UTM,
+			QueryPassthrough: row.record.QueryPassthrough,
+			RedirectRules:    row.record.RedirectRules,
+		})
+		batchRows[i] = struct{}{}
+	}
+
+	if len(batch) == 0 {
+		return results
+	}
+
+	savedURLs, err := s.shortener.ShortBatchURL(ctx, batch, userID)
+	if err != nil {
+		s.logger.Error("import links", zap.Error(err))
+
+		for i := range batchRows {
+			results[i].Error = errImportFailed.Error()
+		}
+
+		return results
+	}
+
+	for _, savedURL := range savedURLs {
+		i, err := strconv.Atoi(savedURL.CorrelationID)
+		if _, ok := batchRows[i]; err != nil || !ok {
+			continue
+		}
+
+		if savedURL.IsExisting {
+			results[i].Error = errURLExists.Error()
+		} else {
+			results[i].ShortURL = s.makeShortURL(savedURL.ShortURL)
+		}
+
+		delete(batchRows, i)
+	}
+
+	for i := range batchRows {
+		results[i].Error = errLinkNotSaved.Error()
+	}
+
+	return results
+}
+
+func (s *LinkImportService) reserveAlias(ctx context.Context, alias string, aliases map[string]struct{}) error {
+	if _, ok := aliases[alias]; ok {
+		return errDuplicateAlias
+	}
+
+	_, err := s.shortener.GetByShortURL(ctx, alias)
+
+	if err == nil {
+		return domain.ErrShortURLConflict
+	}
+
+	if !errors.Is(err, domain.ErrURLNotFound) {
+		s.logger.Error("import links", zap.Error(err))
+		return errImportFailed
+	}
+
+	aliases[alias] = struct{}{}
+
+	return nil
+}
+
+func (s *LinkImportService) updateJob(id string, update func(job *domain.ImportJob)) {
+	s.mx.Lock()
+	defer s.mx.Unlock()
+
+	if job, ok := s.jobs[id]; ok {
+		update(job)
+	}
+}
+
+func (s *LinkImportService) removeOutdatedJobs() {
+	now := s.now()
+
+	for id, job := range s.jobs {
+		if job.FinishedAt != nil && now.Sub(*job.FinishedAt) > importJobRetention {
+			delete(s.jobs, id)
+		}
+	}
+}
+
+func (s *LinkImportService) makeShortURL(shortURL string) string {
+	return fmt.Sprintf("%s/%s", s.baseShortURLAddr, shortURL)
+}
+
+func copyImportJob(job *domain.ImportJob) *domain.ImportJob {
+	jobCopy := *job
+	jobCopy.Rows = make([]domain.ImportRowResult, len(job.Rows))
+	copy(jobCopy.Rows, job.Rows)
+
+	return &jobCopy
+}
+
+func validateLinkRecord(record linkRecord, now time.Time) error {
+	originalURL, err := url.ParseRequestURI(record.OriginalURL)
+	if err != nil || (originalURL.Scheme != "http" && originalURL.Scheme != "https") || originalURL.Host == "" {
+		return errInvalidOriginalURL
+	}
+
+	if record.Alias != "" && (len(record.Alias) > maxAliasLength || !aliasRegexp.MatchString(record.Alias)) {
+		return errInvalidAlias
+	}
+
+	if record.ExpiresAt != nil && !record.ExpiresAt.After(now) {
+		return errLinkExpired
+	}
+
+	if !domain.IsValidRedirectType(record.RedirectType) {
+		return domain.ErrInvalidRedirectType
+	}
+
+	return domain.ValidateRedirectRules(record.RedirectRules)
+}
+
+// countImportRows reads whole import file and returns count of its rows. Error is returned,
+// when file structure is invalid or it has more than MaxImportRows rows.
+func countImportRows(format string, body io.Reader) (int, error) {
+	reader, err := newImportReader(format, body)
+	if err != nil {
+		return 0, err
+	}
+
+	count := 0
+
+	for {
+		if _, err := reader.Next(); errors.Is(err, io.EOF) {
+			return count, nil
+		} else if err != nil {
+			return 0, err
+		}
+
+		count++
+
+		if count > MaxImportRows {
+			return 0, domain.ErrTooManyImportRows
+		}
+	}
+}
+
+func newImportReader(format string, body io.Reader) (importReader, error) {
+	switch format {
+	case LinksFormatCSV:
+		return newCSVLinksReader(body)
+	case LinksFormatJSONLines:
+		return newJSONLinksReader(body), nil
+	default:
+		return nil, domain.ErrUnsupportedFormat
+	}
+}
+
+// csvLinksReader reads CSV file with header. Only original_url column is required,
+// tags are separated by ';', expires_at is in RFC 3339 format, redirect_type is one of 301, 302, 307, 308 or preview,
+// UTM parameters are in utm_* columns and query_passthrough is true or false. Redirect rules can not be set in CSV.
+type csvLinksReader struct {
+	reader  *csv.Reader
+	columns map[string]int
+}
+
+func newCSVLinksReader(body io.Reader) (*csvLinksReader, error) {
+	reader := csv.NewReader(body)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if errors.Is(err, io.EOF) {
+		return nil, fmt.Errorf("%w: file is empty", domain.ErrInvalidImportFile)
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", domain.ErrInvalidImportFile, err)
+	}
+
+	columns := make(map[string]int, len(header))
+	for i, name := range header {
+		columns[strings.ToLower(strings.TrimSpace(name))] = i
+	}
+
+	if _, ok := columns[csvOriginalURLField]; !ok {
+		return nil, fmt.Errorf("%w: header must contain %s column", domain.ErrInvalidImportFile, csvOriginalURLField)
+	}
+
+	return &csvLinksReader{
+		reader:  reader,
+		columns: columns,
+	}, nil
+}
+
+// Next returns next row of file. Rows with invalid CSV syntax are returned with error inside of row.
+func (r *csvLinksReader) Next() (importRow, error) {
+	fields, err := r.reader.Read()
+
+	var parseErr *csv.ParseError
+
+	if errors.As(err, &parseErr) {
+		return importRow{line: parseErr.StartLine, err: parseErr.Err}, nil
+	}
+
+	if err != nil {
+		return importRow{}, err
+	}
+
+	line, _ := r.reader.FieldPos(0)
+
+	return parseCSVRecord(r.columns, fields, line), nil
+}
+
+func parseCSVRecord(columns map[string]int, fields []string, line int) importRow {
+	field := func(name string) string {
+		i, ok := columns[name]
+		if !ok || i >= len(fields) {
+			return ""
+		}
+
+		return strings.TrimSpace(fields[i])
+	}
+
+	row := importRow{
+		line: line,
+		record: linkRecord{
+			OriginalURL:  field(csvOriginalURLField),
+			Alias:        field(csvAliasField),
+			RedirectType: field(csvRedirectType),
+		},
+	}
+
+	if tags := field(csvTagsField); tags != "" {
+		for _, tag := range strings.Split(tags, csvTagsSeparator) {
+			if tag = strings.TrimSpace(tag); tag != "" {
+				row.record.Tags = append(row.record.Tags, tag)
+			}
+		}
+	}
+
+	utm := &domain.UTMParams{
+		Source:   field(csvUTMSourceField),
+		Medium:   field(csvUTMMediumField),
+		Campaign: field(csvUTMCampaignField),
+		Term:     field(csvUTMTermField),
+		Content:  field(csvUTMContentField),
+	}
+	if !utm.IsEmpty() {
+		row.record.UTM = utm
+	}
+
+	if expiresAt := field(csvExpiresAtField); expiresAt != "" {
+		parsedExpiresAt, err := time.Parse(time.RFC3339, expiresAt)
+		if err != nil {
+			row.err = errInvalidExpiresAt
+			return row
+		}
+
+		row.record.ExpiresAt = &parsedExpiresAt
+	}
+
+	if passthrough := field(csvPassthroughField); passthrough != "" {
+		parsedPassthrough, err := strconv.ParseBool(passthrough)
+		if err != nil {
+			row.err = errInvalidPassthrough
+			return row
+		}
+
+		row.record.QueryPassthrough = parsedPassthrough
+	}
+
+	return row
+}
+
+// jsonLinksReader reads file, where every not empty line is JSON object of link.
+// Row of link is number of its line in file.
+type jsonLinksReader struct {
+	reader *bufio.Reader
+	line   int
+}
+
+func newJSONLinksReader(body io.Reader) *jsonLinksReader {
+	return &jsonLinksReader{
+		reader: bufio.NewReader(body),
+	}
+}
+
+// Next returns next row of file, empty lines are skipped. Line with invalid JSON makes whole file invalid,
+// objects, that do not match link, are returned with error inside of row.
+func (r *jsonLinksReader) Next() (importRow, error) {
+	var line []byte
+
+	for len(line) == 0 {
+		var err error
+
+		line, err = r.reader.ReadBytes('\n')
+		if len(line) == 0 && err != nil {
+			return importRow{}, err
+		}
+
+		if err != nil && !errors.Is(err, io.EOF) {
+			return importRow{}, err
+		}
+
+		r.line++
+		line = bytes.TrimSpace(line)
+	}
+
+	if !json.Valid(line) {
+		return importRow{}, fmt.Errorf("%w: row %d: invalid JSON", domain.ErrInvalidImportFile, r.line)
+	}
+
+	rawRecord := json.RawMessage(line)
+	row := importRow{line: r.line}
+
+	if err := json.Unmarshal(rawRecord, &row.record); err != nil {
+		row.err = fmt.Errorf("invalid link object: %w", err)
+	}
+
+	row.record.ShortURL = ""
+
+	return row, nil
+}
+
+// csvLinksWriter writes header of CSV file once and then links of every page.
+type csvLinksWriter struct {
+	writer *csv.Writer
+}
+
+func newCSVLinksWriter(w io.Writer) (*csvLinksWriter, error) {
+	writer := csv.NewWriter(w)
+
+	if err := writer.Write([]string{
+		csvOriginalURLField,
+		csvAliasField,
+		csvTagsField,
+		csvExpiresAtField,
+		csvRedirectType,
+		csvUTMSourceField,
+		csvUTMMediumField,
+		csvUTMCampaignField,
+		csvUTMTermField,
+		csvUTMContentField,
+		csvPassthroughField,
+		csvShortURLField,
+		csvClicksField,
+	}); err != nil {
+		return nil, err
+	}
+
+	return &csvLinksWriter{writer: writer}, nil
+}
+
+// Write writes records and flushes them to underlying writer.
+func (w *csvLinksWriter) Write(records []linkRecord) error {
+	for _, record := range records {
+		expiresAt := ""
+		if record.ExpiresAt != nil {
+			expiresAt = record.ExpiresAt.Format(time.RFC3339)
+		}
+
+		utm := record.UTM
+		if utm == nil {
+			utm = &domain.UTMParams{}
+		}
+
+		if err := w.writer.Write([]string{
+			record.OriginalURL,
+			record.Alias,
+			strings.Join(record.Tags, csvTagsSeparator),
+			expiresAt,
+			record.RedirectType,
+			utm.Source,
+			utm.Medium,
+			utm.Campaign,
+			utm.Term,
+			utm.Content,
+			strconv.FormatBool(record.QueryPassthrough),
+			record.ShortURL,
+			strconv.Itoa(record.Clicks),
+		}); err != nil {
+			return err
+		}
+	}
+
+	w.writer.Flush()
+
+	return w.writer.Error()
+}
+
+// jsonLinksWriter writes every link as JSON object on separate line.
+type jsonLinksWriter struct {
+	encoder *json.Encoder
+}
+
+func newJSONLinksWriter(w io.Writer) *jsonLinksWriter {
+	return &jsonLinksWriter{encoder: json.NewEncoder(w)}
+}
+
+func (w *jsonLinksWriter) Write(records []linkRecord) error {
+	for _, record := range records {
+		if err := w.encoder.Encode(record); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,495 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/MowlCoder/go-url-shortener/internal/domain"
+	servicesmocks "github.com/MowlCoder/go-url-shortener/internal/services/mocks"
+)
+
+func readImportRows(format string, body string) ([]importRow, error) {
+	reader, err := newImportReader(format, strings.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+
+	rows := make([]importRow, 0)
+
+	for {
+		row, err := reader.Next()
+		if errors.Is(err, io.EOF) {
+			return rows, nil
+		}
+
+		if err != nil {
+			return nil, err
+		}
+
+		rows = append(rows, row)
+	}
+}
+
+func TestCSVLinksReader(t *testing.T) {
+	type TestCase struct {
+		ExpectedErr error
+		Name        string
+		File        string
+		Expected    []importRow
+	}
+
+	expiresAt := time.Date(2030, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	testCases := []TestCase{
+		{
+			Name: "all columns",
+			File: "original_url,alias,tags,expires_at,redirect_type\n" +
+				"https://example.com,ex,news; tech,2030-01-02T03:04:05Z,308\n" +
+				"https://example.org,,,,\n",
+			Expected: []importRow{
+				{line: 2, record: linkRecord{
+					OriginalURL:  "https://example.com",
+					Alias:        "ex",
+					Tags:         []string{"news", "tech"},
+					ExpiresAt:    &expiresAt,
+					RedirectType: domain.RedirectPermanent,
+				}},
+				{line: 3, record: linkRecord{OriginalURL: "https://example.org"}},
+			},
+		},
+		{
+			Name: "utm and query passthrough",
+			File: "original_url,utm_source,utm_campaign,query_passthrough\n" +
+				"https://example.com,mail,spring,true\n" +
+				"https://example.org,,,false\n",
+			Expected: []importRow{
+				{line: 2, record: linkRecord{
+					OriginalURL:      "https://example.com",
+					UTM:              &domain.UTMParams{Source: "mail", Campaign: "spring"},
+					QueryPassthrough: true,
+				}},
+				{line: 3, record: linkRecord{OriginalURL: "https://example.org"}},
+			},
+		},
+		{
+			Name: "invalid query passthrough",
+			File: "original_url,query_passthrough\nhttps://example.com,sometimes\n",
+			Expected: []importRow{{
+				line:   2,
+				record: linkRecord{OriginalURL: "https://example.com"},
+				err:    errInvalidPassthrough,
+			}},
+		},
+		{
+			Name:     "only original url",
+			File:     "original_url\nhttps://example.com\n",
+			Expected: []importRow{{line: 2, record: linkRecord{OriginalURL: "https://example.com"}}},
+		},
+		{
+			Name: "invalid expires at",
+			File: "original_url,expires_at\nhttps://example.com,tomorrow\n",
+			Expected: []importRow{{
+				line:   2,
+				record: linkRecord{OriginalURL: "https://example.com"},
+				err:    errInvalidExpiresAt,
+			}},
+		},
+		{
+			Name:        "empty file",
+			File:        "",
+			ExpectedErr: domain.ErrInvalidImportFile,
+		},
+		{
+			Name:        "no original url column",
+			File:        "url\nhttps://example.com\n",
+			ExpectedErr: domain.ErrInvalidImportFile,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			rows, err := readImportRows(LinksFormatCSV, testCase.File)
+
+			if testCase.ExpectedErr != nil {
+				assert.ErrorIs(t, err, testCase.ExpectedErr)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, testCase.Expected, rows)
+		})
+	}
+}
+
+func TestJSONLinksReader(t *testing.T) {
+	rows, err := readImportRows(LinksFormatJSONLines,
+		`{"original_url":"https://example.com","alias":"ex","tags":["news"],"utm":{"utm_source":"mail"},"query_passthrough":true,`+
+			`"redirect_rules":[{"destination":"https://example.com/ios","devices":["ios"]}]}`+"\n"+
+			"\n"+
+			`{"original_url":1}`+"\n",
+	)
+	require.NoError(t, err)
+	require.Len(t, rows, 2)
+
+	assert.Equal(t, linkRecord{
+		OriginalURL:      "https://example.com",
+		Alias:            "ex",
+		Tags:             []string{"news"},
+		UTM:              &domain.UTMParams{Source: "mail"},
+		QueryPassthrough: true,
+		RedirectRules:    []domain.RedirectRule{{Destination: "https://example.com/ios", Devices: []string{"ios"}}},
+	}, rows[0].record)
+	assert.NoError(t, rows[0].err)
+	// row is number of line in file, empty lines are skipped
+	assert.Equal(t, 3, rows[1].line)
+	assert.Error(t, rows[1].err)
+
+	_, err = readImportRows(LinksFormatJSONLines, `{"original_url":"https://example.com"}`+"\n{not json}\n")
+	assert.ErrorIs(t, err, domain.ErrInvalidImportFile)
+	assert.ErrorContains(t, err, "row 2")
+}
+
+func TestValidateLinkRecord(t *testing.T) {
+	now := time.Now()
+	past := now.Add(-time.Hour)
+
+	testCases := []struct {
+		ExpectedErr error
+		Name        string
+		Record      linkRecord
+	}{
+		{Name: "valid", Record: linkRecord{OriginalURL: "https://example.com", Alias: "my_link-1"}},
+		{Name: "relative url", Record: linkRecord{OriginalURL: "/path"}, ExpectedErr: errInvalidOriginalURL},
+		{Name: "ftp url", Record: linkRecord{OriginalURL: "ftp://example.com"}, ExpectedErr: errInvalidOriginalURL},
+		{Name: "invalid alias", Record: linkRecord{OriginalURL: "https://example.com", Alias: "a/b"}, ExpectedErr: errInvalidAlias},
+		{Name: "long alias", Record: linkRecord{OriginalURL: "https://example.com", Alias: strings.Repeat("a", 21)}, ExpectedErr: errInvalidAlias},
+		{Name: "expired", Record: linkRecord{OriginalURL: "https://example.com", ExpiresAt: &past}, ExpectedErr: errLinkExpired},
+		{Name: "invalid redirect type", Record: linkRecord{OriginalURL: "https://example.com", RedirectType: "303"}, ExpectedErr: domain.ErrInvalidRedirectType},
+		{
+			Name: "invalid redirect rules",
+			Record: linkRecord{
+				OriginalURL:   "https://example.com",
+				RedirectRules: []domain.RedirectRule{{Destination: "https://example.com/ios"}},
+			},
+			ExpectedErr: domain.ErrInvalidRedirectRules,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			err := validateLinkRecord(testCase.Record, now)
+			if testCase.ExpectedErr == nil {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorIs(t, err, testCase.ExpectedErr)
+			}
+		})
+	}
+}
+
+func TestLinkImportService_StartImport(t *testing.T) {
+	tmpDir := t.TempDir()
+	t.Setenv("TMPDIR", tmpDir)
+
+	ctrl := gomock.NewController(t)
+	shortener := servicesmocks.NewMocklinkShortener(ctrl)
+	loggerInstance := servicesmocks.NewMocklogger(ctrl)
+	service := NewLinkImportService(shortener, loggerInstance, "http://localhost:8080")
+
+	shortener.
+		EXPECT().
+		GetByShortURL(gomock.Any(), "free").
+		Return(nil, domain.ErrURLNotFound)
+	shortener.
+		EXPECT().
+		GetByShortURL(gomock.Any(), "taken").
+		Return(&domain.ShortenedURL{ShortURL: "taken"}, nil)
+	shortener.
+		EXPECT().
+		ShortBatchURL(gomock.Any(), gomock.Len(3), "user").
+		Return([]domain.ShortBatchURL{
+			{CorrelationID: "0", OriginalURL: "https://a.example.com", ShortURL: "free"},
+			{CorrelationID: "1", OriginalURL: "https://b.example.com", ShortURL: "gen"},
+			{CorrelationID: "6", OriginalURL: "https://e.example.com", ShortURL: "other", IsExisting: true},
+		}, nil)
+
+	job, err := service.StartImport(context.Background(), "user", LinksFormatCSV, strings.NewReader(
+		"original_url,alias\n"+
+			"https://a.example.com,free\n"+
+			"https://b.example.com,\n"+
+			"https://c.example.com,taken\n"+
+			"https://d.example.com,free\n"+
+			"not url,\n"+
+			"https://b.example.com,\n"+
+			"https://e.example.com,\n",
+	))
+	require.NoError(t, err)
+	assert.Equal(t, 7, job.Total)
+
+	require.NoError(t, service.Shutdown(context.Background()))
+
+	job, err = service.GetImportJob(context.Background(), job.ID, "user")
+	require.NoError(t, err)
+
+	assert.Equal(t, domain.ImportJobCompleted, job.Status)
+	assert.NotNil(t, job.FinishedAt)
+	assert.Equal(t, 2, job.Succeeded)
+	assert.Equal(t, 5, job.Failed)
+	require.Len(t, job.Rows, 7)
+	assert.Equal(t, "http://localhost:8080/free", job.Rows[0].ShortURL)
+	assert.Equal(t, "http://localhost:8080/gen", job.Rows[1].ShortURL)
+	assert.Equal(t, domain.ErrShortURLConflict.Error(), job.Rows[2].Error)
+	assert.Equal(t, errDuplicateAlias.Error(), job.Rows[3].Error)
+	assert.Equal(t, errInvalidOriginalURL.Error(), job.Rows[4].Error)
+	assert.Equal(t, 6, job.Rows[4].Row)
+	assert.Equal(t, errDuplicateURL.Error(), job.Rows[5].Error)
+	// url, that is shortened before, is not reported as imported
+	assert.Equal(t, errURLExists.Error(), job.Rows[6].Error)
+	assert.Empty(t, job.Rows[6].ShortURL)
+
+	_, err = service.GetImportJob(context.Background(), job.ID, "other user")
+	assert.ErrorIs(t, err, domain.ErrImportJobNotFound)
+
+	// uploaded file is removed after import
+	tmpFiles, err := os.ReadDir(tmpDir)
+	require.NoError(t, err)
+	assert.Empty(t, tmpFiles)
+}
+
+func TestLinkImportService_StartImport_Chunks(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	shortener := servicesmocks.NewMocklinkShortener(ctrl)
+	service := NewLinkImportService(shortener, servicesmocks.NewMocklogger(ctrl), "http://localhost:8080")
+
+	const rowsCount = importChunkSize*2 + 5
+
+	file := strings.Builder{}
+	file.WriteString("original_url\n")
+
+	for i := 0; i < rowsCount; i++ {
+		fmt.Fprintf(&file, "https://example.com/%d\n", i)
+	}
+
+	chunkSizes := make([]int, 0)
+
+	shortener.
+		EXPECT().
+		ShortBatchURL(gomock.Any(), gomock.Any(), "user").
+		DoAndReturn(func(ctx context.Context, urls []domain.ShortBatchURL, userID string) ([]domain.ShortBatchURL, error) {
+			chunkSizes = append(chunkSizes, len(urls))
+			return urls, nil
+		}).
+		Times(3)
+
+	job, err := service.StartImport(context.Background(), "user", LinksFormatCSV, strings.NewReader(file.String()))
+	require.NoError(t, err)
+	assert.Equal(t, rowsCount, job.Total)
+
+	require.NoError(t, service.Shutdown(context.Background()))
+
+	job, err = service.GetImportJob(context.Background(), job.ID, "user")
+	require.NoError(t, err)
+	assert.Equal(t, rowsCount, job.Succeeded)
+	assert.Equal(t, []int{importChunkSize, importChunkSize, 5}, chunkSizes)
+}
+
+func TestLinkImportService_StartImport_RedirectOptions(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	shortener := servicesmocks.NewMocklinkShortener(ctrl)
+	service := NewLinkImportService(shortener, servicesmocks.NewMocklogger(ctrl), "http://localhost:8080")
+
+	shortener.
+		EXPECT().
+		ShortBatchURL(gomock.Any(), gomock.Any(), "user").
+		DoAndReturn(func(ctx context.Context, urls []domain.ShortBatchURL, userID string) ([]domain.ShortBatchURL, error) {
+			require.Len(t, urls, 1)
+			assert.Equal(t, &domain.UTMParams{Source: "mail", Term: "shoes"}, urls[0].UTM)
+			assert.True(t, urls[0].QueryPassthrough)
+
+			return urls, nil
+		})
+
+	_, err := service.StartImport(context.Background(), "user", LinksFormatCSV, strings.NewReader(
+		"original_url,utm_source,utm_term,query_passthrough\n"+
+			"https://example.com,mail,shoes,true\n",
+	))
+	require.NoError(t, err)
+	require.NoError(t, service.Shutdown(context.Background()))
+}
+
+func TestLinkImportService_StartImport_RedirectRules(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	shortener := servicesmocks.NewMocklinkShortener(ctrl)
+	service := NewLinkImportService(shortener, servicesmocks.NewMocklogger(ctrl), "http://localhost:8080")
+
+	shortener.
+		EXPECT().
+		ShortBatchURL(gomock.Any(), gomock.Any(), "user").
+		DoAndReturn(func(ctx context.Context, urls []domain.ShortBatchURL, userID string) ([]domain.ShortBatchURL, error) {
+			require.Len(t, urls, 1)
+			assert.Equal(t, []domain.RedirectRule{{Destination: "https://example.com/en", Languages: []string{"en"}}}, urls[0].RedirectRules)
+
+			return urls, nil
+		})
+
+	job, err := service.StartImport(context.Background(), "user", LinksFormatJSONLines, strings.NewReader(
+		`{"original_url":"https://example.com","redirect_rules":[{"destination":"https://example.com/en","languages":["en"]}]}`+"\n"+
+			`{"original_url":"https://example.org","redirect_rules":[{"destination":"https://example.org/en"}]}`+"\n",
+	))
+	require.NoError(t, err)
+	require.NoError(t, service.Shutdown(context.Background()))
+
+	job, err = service.GetImportJob(context.Background(), job.ID, "user")
+	require.NoError(t, err)
+	assert.Equal(t, 1, job.Succeeded)
+	require.Len(t, job.Rows, 2)
+	assert.Contains(t, job.Rows[1].Error, domain.ErrInvalidRedirectRules.Error())
+}
+
+func TestLinkImportService_StartImport_SaveError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	shortener := servicesmocks.NewMocklinkShortener(ctrl)
+	loggerInstance := servicesmocks.NewMocklogger(ctrl)
+	service := NewLinkImportService(shortener, loggerInstance, "http://localhost:8080")
+
+	shortener.
+		EXPECT().
+		ShortBatchURL(gomock.Any(), gomock.Any(), "user").
+		Return(nil, errors.New("undefined behavior"))
+	loggerInstance.
+		EXPECT().
+		Error(gomock.Any(), gomock.Any())
+
+	job, err := service.StartImport(context.Background(), "user", LinksFormatJSONLines, strings.NewReader(
+		`{"original_url":"https://example.com"}`,
+	))
+	require.NoError(t, err)
+	require.NoError(t, service.Shutdown(context.Background()))
+
+	job, err = service.GetImportJob(context.Background(), job.ID, "user")
+	require.NoError(t, err)
+	assert.Equal(t, 1, job.Failed)
+	assert.Equal(t, errImportFailed.Error(), job.Rows[0].Error)
+}
+
+func TestLinkImportService_StartImport_InvalidFile(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	service := NewLinkImportService(
+		servicesmocks.NewMocklinkShortener(ctrl),
+		servicesmocks.NewMocklogger(ctrl),
+		"http://localhost:8080",
+	)
+
+	_, err := service.StartImport(context.Background(), "user", "xml", strings.NewReader(""))
+	assert.ErrorIs(t, err, domain.ErrUnsupportedFormat)
+
+	_, err = service.StartImport(context.Background(), "user", LinksFormatCSV, strings.NewReader(
+		"original_url\n"+strings.Repeat("https://example.com\n", MaxImportRows+1),
+	))
+	assert.ErrorIs(t, err, domain.ErrTooManyImportRows)
+}
+
+func TestLinkImportService_Export(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	shortener := servicesmocks.NewMocklinkShortener(ctrl)
+	service := NewLinkImportService(shortener, servicesmocks.NewMocklogger(ctrl), "http://localhost:8080")
+	expiresAt := time.Date(2030, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	shortener.
+		EXPECT().
+		GetUserURLsPage(gomock.Any(), "user", "", exportPageSize).
+		Return([]domain.ShortenedURL{
+			{
+				ID:            1,
+				ShortURL:      "a",
+				OriginalURL:   "https://a.example.com",
+				Tags:          []string{"x", "y"},
+				ExpiresAt:     &expiresAt,
+				RedirectRules: []domain.RedirectRule{{Destination: "https://a.example.com/de", Countries: []string{"DE"}}},
+			},
+			{
+				ID:               2,
+				ShortURL:         "b",
+				OriginalURL:      "https://b.example.com",
+				Clicks:           3,
+				RedirectType:     domain.RedirectPreview,
+				UTM:              &domain.UTMParams{Source: "mail", Medium: "email"},
+				QueryPassthrough: true,
+			},
+			{ID: 3, ShortURL: "c", OriginalURL: "https://c.example.com", IsDeleted: true},
+		}, nil).
+		Times(2)
+
+	// redirect rules are exported only to JSON lines file
+	var csvFile bytes.Buffer
+	require.NoError(t, service.Export(context.Background(), "user", LinksFormatCSV, &csvFile))
+	assert.Equal(t,
+		"original_url,alias,tags,expires_at,redirect_type,utm_source,utm_medium,utm_campaign,utm_term,utm_content,"+
+			"query_passthrough,short_url,clicks\n"+
+			"https://a.example.com,a,x;y,2030-01-02T03:04:05Z,,,,,,,false,http://localhost:8080/a,0\n"+
+			"https://b.example.com,b,,,preview,mail,email,,,,true,http://localhost:8080/b,3\n",
+		csvFile.String(),
+	)
+
+	var jsonFile bytes.Buffer
+	require.NoError(t, service.Export(context.Background(), "user", LinksFormatJSONLines, &jsonFile))
+	assert.Equal(t,
+		`{"expires_at":"2030-01-02T03:04:05Z","original_url":"https://a.example.com","alias":"a","short_url":"http://localhost:8080/a","tags":["x","y"],`+
+			`"redirect_rules":[{"destination":"https://a.example.com/de","countries":["DE"]}]}`+"\n"+
+			`{"utm":{"utm_source":"mail","utm_medium":"email"},"original_url":"https://b.example.com","alias":"b",`+
+			`"short_url":"http://localhost:8080/b","redirect_type":"preview","clicks":3,"query_passthrough":true}`+"\n",
+		jsonFile.String(),
+	)
+
+	assert.ErrorIs(t, service.Export(context.Background(), "user", "xml", &jsonFile), domain.ErrUnsupportedFormat)
+}
+
+type flushedBuffer struct {
+	bytes.Buffer
+	flushes int
+}
+
+func (b *flushedBuffer) Flush() {
+	b.flushes++
+}
+
+func TestLinkImportService_Export_Pages(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	shortener := servicesmocks.NewMocklinkShortener(ctrl)
+	service := NewLinkImportService(shortener, servicesmocks.NewMocklogger(ctrl), "http://localhost:8080")
+
+	firstPage := make([]domain.ShortenedURL, 0, exportPageSize)
+	for i := 0; i < exportPageSize; i++ {
+		firstPage = append(firstPage, domain.ShortenedURL{
+			ShortURL:    fmt.Sprintf("a%04d", i),
+			OriginalURL: fmt.Sprintf("https://example.com/%d", i),
+		})
+	}
+
+	gomock.InOrder(
+		shortener.
+			EXPECT().
+			GetUserURLsPage(gomock.Any(), "user", "", exportPageSize).
+			Return(firstPage, nil),
+		shortener.
+			EXPECT().
+			GetUserURLsPage(gomock.Any(), "user", firstPage[exportPageSize-1].ShortURL, exportPageSize).
+			Return([]domain.ShortenedURL{{ShortURL: "b", OriginalURL: "https://example.org"}}, nil),
+	)
+
+	var file flushedBuffer
+	require.NoError(t, service.Export(context.Background(), "user", LinksFormatJSONLines, &file))
+	assert.Equal(t, 2, file.flushes)
+	assert.Equal(t, exportPageSize+1, strings.Count(file.String(), "\n"))
+}
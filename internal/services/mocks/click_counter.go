@@ -0,0 +1,54 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: click_counter.go
+//
+// Generated by this command:
+//
+//	mockgen -source=click_counter.go -destination=./mocks/click_counter.go -package=servicesmocks
+//
+// Package servicesmocks is a generated GoMock package.
+package servicesmocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockclickStorage is a mock of clickStorage interface.
+type MockclickStorage struct {
+	ctrl     *gomock.Controller
+	recorder *MockclickStorageMockRecorder
+}
+
+// MockclickStorageMockRecorder is the mock recorder for MockclickStorage.
+type MockclickStorageMockRecorder struct {
+	mock *MockclickStorage
+}
+
+// NewMockclickStorage creates a new mock instance.
+func NewMockclickStorage(ctrl *gomock.Controller) *MockclickStorage {
+	mock := &MockclickStorage{ctrl: ctrl}
+	mock.recorder = &MockclickStorageMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockclickStorage) EXPECT() *MockclickStorageMockRecorder {
+	return m.recorder
+}
+
+// IncrementClicks mocks base method.
+func (m *MockclickStorage) IncrementClicks(ctx context.Context, shortURL string, count int) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IncrementClicks", ctx, shortURL, count)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// IncrementClicks indicates an expected call of IncrementClicks.
+func (mr *MockclickStorageMockRecorder) IncrementClicks(ctx, shortURL, count any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IncrementClicks", reflect.TypeOf((*MockclickStorage)(nil).IncrementClicks), ctx, shortURL, count)
+}
@@ -0,0 +1,122 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: config_reloader.go
+//
+// Generated by this command:
+//
+//	mockgen -source=config_reloader.go -destination=./mocks/config_reloader.go -package=servicesmocks
+//
+// Package servicesmocks is a generated GoMock package.
+package servicesmocks
+
+import (
+	net "net"
+	reflect "reflect"
+
+	gomock "go.uber.org/mock/gomock"
+	zap "go.uber.org/zap"
+	zapcore "go.uber.org/zap/zapcore"
+)
+
+// MocktrustedSubnetUpdater is a mock of trustedSubnetUpdater interface.
+type MocktrustedSubnetUpdater struct {
+	ctrl     *gomock.Controller
+	recorder *MocktrustedSubnetUpdaterMockRecorder
+}
+
+// MocktrustedSubnetUpdaterMockRecorder is the mock recorder for MocktrustedSubnetUpdater.
+type MocktrustedSubnetUpdaterMockRecorder struct {
+	mock *MocktrustedSubnetUpdater
+}
+
+// NewMocktrustedSubnetUpdater creates a new mock instance.
+func NewMocktrustedSubnetUpdater(ctrl *gomock.Controller) *MocktrustedSubnetUpdater {
+	mock := &MocktrustedSubnetUpdater{ctrl: ctrl}
+	mock.recorder = &MocktrustedSubnetUpdaterMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MocktrustedSubnetUpdater) EXPECT() *MocktrustedSubnetUpdaterMockRecorder {
+	return m.recorder
+}
+
+// Set mocks base method.
+func (m *MocktrustedSubnetUpdater) Set(ipNets []*net.IPNet) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Set", ipNets)
+}
+
+// Set indicates an expected call of Set.
+func (mr *MocktrustedSubnetUpdaterMockRecorder) Set(ipNets any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Set", reflect.TypeOf((*MocktrustedSubnetUpdater)(nil).Set), ipNets)
+}
+
+// MockconfigLogger is a mock of configLogger interface.
+type MockconfigLogger struct {
+	ctrl     *gomock.Controller
+	recorder *MockconfigLoggerMockRecorder
+}
+
+// MockconfigLoggerMockRecorder is the mock recorder for MockconfigLogger.
+type MockconfigLoggerMockRecorder struct {
+	mock *MockconfigLogger
+}
+
+// NewMockconfigLogger creates a new mock instance.
+func NewMockconfigLogger(ctrl *gomock.Controller) *MockconfigLogger {
+	mock := &MockconfigLogger{ctrl: ctrl}
+	mock.recorder = &MockconfigLoggerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockconfigLogger) EXPECT() *MockconfigLoggerMockRecorder {
+	return m.recorder
+}
+
+// Info mocks base method.
+func (m *MockconfigLogger) Info(msg string, fields ...zap.Field) {
+	m.ctrl.T.Helper()
+	varargs := []any{msg}
+	for _, a := range fields {
+		varargs = append(varargs, a)
+	}
+	m.ctrl.Call(m, "Info", varargs...)
+}
+
+// Info indicates an expected call of Info.
+func (mr *MockconfigLoggerMockRecorder) Info(msg any, fields ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{msg}, fields...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Info", reflect.TypeOf((*MockconfigLogger)(nil).Info), varargs...)
+}
+
+// SetLevel mocks base method.
+func (m *MockconfigLogger) SetLevel(level zapcore.Level) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "SetLevel", level)
+}
+
+// SetLevel indicates an expected call of SetLevel.
+func (mr *MockconfigLoggerMockRecorder) SetLevel(level any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetLevel", reflect.TypeOf((*MockconfigLogger)(nil).SetLevel), level)
+}
+
+// Warn mocks base method.
+func (m *MockconfigLogger) Warn(msg string, fields ...zap.Field) {
+	m.ctrl.T.Helper()
+	varargs := []any{msg}
+	for _, a := range fields {
+		varargs = append(varargs, a)
+	}
+	m.ctrl.Call(m, "Warn", varargs...)
+}
+
+// Warn indicates an expected call of Warn.
+func (mr *MockconfigLoggerMockRecorder) Warn(msg any, fields ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{msg}, fields...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Warn", reflect.TypeOf((*MockconfigLogger)(nil).Warn), varargs...)
+}
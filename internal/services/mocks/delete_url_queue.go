@@ -1,9 +1,9 @@
 // Code generated by MockGen. DO NOT EDIT.
-// Source: ./internal/services/delete_url_queue.go
+// Source: delete_url_queue.go
 //
 // Generated by this command:
 //
-//	mockgen.exe -source=./internal/services/delete_url_queue.go -package=servicesmocks -destination=./internal/services/mocks/delete_url_queue.go
+//	mockgen -source=delete_url_queue.go -destination=./mocks/delete_url_queue.go -package=servicesmocks
 //
 // Package servicesmocks is a generated GoMock package.
 package servicesmocks
@@ -14,6 +14,7 @@ import (
 
 	domain "github.com/MowlCoder/go-url-shortener/internal/domain"
 	gomock "go.uber.org/mock/gomock"
+	zap "go.uber.org/zap"
 )
 
 // MockurlStorage is a mock of urlStorage interface.
@@ -53,6 +54,49 @@ func (mr *MockurlStorageMockRecorder) DoDeleteURLTasks(ctx, tasks any) *gomock.C
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DoDeleteURLTasks", reflect.TypeOf((*MockurlStorage)(nil).DoDeleteURLTasks), ctx, tasks)
 }
 
+// FailDeleteTasks mocks base method.
+func (m *MockurlStorage) FailDeleteTasks(ctx context.Context, ids []string, reason string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FailDeleteTasks", ctx, ids, reason)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// FailDeleteTasks indicates an expected call of FailDeleteTasks.
+func (mr *MockurlStorageMockRecorder) FailDeleteTasks(ctx, ids, reason any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FailDeleteTasks", reflect.TypeOf((*MockurlStorage)(nil).FailDeleteTasks), ctx, ids, reason)
+}
+
+// GetPendingDeleteTasks mocks base method.
+func (m *MockurlStorage) GetPendingDeleteTasks(ctx context.Context, limit int) ([]domain.DeleteURLsTask, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPendingDeleteTasks", ctx, limit)
+	ret0, _ := ret[0].([]domain.DeleteURLsTask)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPendingDeleteTasks indicates an expected call of GetPendingDeleteTasks.
+func (mr *MockurlStorageMockRecorder) GetPendingDeleteTasks(ctx, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPendingDeleteTasks", reflect.TypeOf((*MockurlStorage)(nil).GetPendingDeleteTasks), ctx, limit)
+}
+
+// SaveDeleteTask mocks base method.
+func (m *MockurlStorage) SaveDeleteTask(ctx context.Context, task domain.DeleteURLsTask) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveDeleteTask", ctx, task)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SaveDeleteTask indicates an expected call of SaveDeleteTask.
+func (mr *MockurlStorageMockRecorder) SaveDeleteTask(ctx, task any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveDeleteTask", reflect.TypeOf((*MockurlStorage)(nil).SaveDeleteTask), ctx, task)
+}
+
 // Mocklogger is a mock of logger interface.
 type Mocklogger struct {
 	ctrl     *gomock.Controller
@@ -76,14 +120,53 @@ func (m *Mocklogger) EXPECT() *MockloggerMockRecorder {
 	return m.recorder
 }
 
+// Error mocks base method.
+func (m *Mocklogger) Error(msg string, fields ...zap.Field) {
+	m.ctrl.T.Helper()
+	varargs := []any{msg}
+	for _, a := range fields {
+		varargs = append(varargs, a)
+	}
+	m.ctrl.Call(m, "Error", varargs...)
+}
+
+// Error indicates an expected call of Error.
+func (mr *MockloggerMockRecorder) Error(msg any, fields ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{msg}, fields...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Error", reflect.TypeOf((*Mocklogger)(nil).Error), varargs...)
+}
+
 // Info mocks base method.
-func (m *Mocklogger) Info(msg string) {
+func (m *Mocklogger) Info(msg string, fields ...zap.Field) {
 	m.ctrl.T.Helper()
-	m.ctrl.Call(m, "Info", msg)
+	varargs := []any{msg}
+	for _, a := range fields {
+		varargs = append(varargs, a)
+	}
+	m.ctrl.Call(m, "Info", varargs...)
 }
 
 // Info indicates an expected call of Info.
-func (mr *MockloggerMockRecorder) Info(msg any) *gomock.Call {
+func (mr *MockloggerMockRecorder) Info(msg any, fields ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{msg}, fields...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Info", reflect.TypeOf((*Mocklogger)(nil).Info), varargs...)
+}
+
+// Warn mocks base method.
+func (m *Mocklogger) Warn(msg string, fields ...zap.Field) {
+	m.ctrl.T.Helper()
+	varargs := []any{msg}
+	for _, a := range fields {
+		varargs = append(varargs, a)
+	}
+	m.ctrl.Call(m, "Warn", varargs...)
+}
+
+// Warn indicates an expected call of Warn.
+func (mr *MockloggerMockRecorder) Warn(msg any, fields ...any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Info", reflect.TypeOf((*Mocklogger)(nil).Info), msg)
+	varargs := append([]any{msg}, fields...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Warn", reflect.TypeOf((*Mocklogger)(nil).Warn), varargs...)
 }
@@ -0,0 +1,119 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: health.go
+//
+// Generated by this command:
+//
+//	mockgen -source=health.go -destination=./mocks/health.go -package=servicesmocks
+//
+// Package servicesmocks is a generated GoMock package.
+package servicesmocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockhealthStorage is a mock of healthStorage interface.
+type MockhealthStorage struct {
+	ctrl     *gomock.Controller
+	recorder *MockhealthStorageMockRecorder
+}
+
+// MockhealthStorageMockRecorder is the mock recorder for MockhealthStorage.
+type MockhealthStorageMockRecorder struct {
+	mock *MockhealthStorage
+}
+
+// NewMockhealthStorage creates a new mock instance.
+func NewMockhealthStorage(ctrl *gomock.Controller) *MockhealthStorage {
+	mock := &MockhealthStorage{ctrl: ctrl}
+	mock.recorder = &MockhealthStorageMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockhealthStorage) EXPECT() *MockhealthStorageMockRecorder {
+	return m.recorder
+}
+
+// CheckMigrations mocks base method.
+func (m *MockhealthStorage) CheckMigrations(ctx context.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CheckMigrations", ctx)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CheckMigrations indicates an expected call of CheckMigrations.
+func (mr *MockhealthStorageMockRecorder) CheckMigrations(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CheckMigrations", reflect.TypeOf((*MockhealthStorage)(nil).CheckMigrations), ctx)
+}
+
+// CountPendingDeleteTasks mocks base method.
+func (m *MockhealthStorage) CountPendingDeleteTasks(ctx context.Context) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountPendingDeleteTasks", ctx)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountPendingDeleteTasks indicates an expected call of CountPendingDeleteTasks.
+func (mr *MockhealthStorageMockRecorder) CountPendingDeleteTasks(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountPendingDeleteTasks", reflect.TypeOf((*MockhealthStorage)(nil).CountPendingDeleteTasks), ctx)
+}
+
+// Ping mocks base method.
+func (m *MockhealthStorage) Ping(ctx context.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Ping", ctx)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Ping indicates an expected call of Ping.
+func (mr *MockhealthStorageMockRecorder) Ping(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Ping", reflect.TypeOf((*MockhealthStorage)(nil).Ping), ctx)
+}
+
+// MockreadinessProvider is a mock of readinessProvider interface.
+type MockreadinessProvider struct {
+	ctrl     *gomock.Controller
+	recorder *MockreadinessProviderMockRecorder
+}
+
+// MockreadinessProviderMockRecorder is the mock recorder for MockreadinessProvider.
+type MockreadinessProviderMockRecorder struct {
+	mock *MockreadinessProvider
+}
+
+// NewMockreadinessProvider creates a new mock instance.
+func NewMockreadinessProvider(ctrl *gomock.Controller) *MockreadinessProvider {
+	mock := &MockreadinessProvider{ctrl: ctrl}
+	mock.recorder = &MockreadinessProviderMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockreadinessProvider) EXPECT() *MockreadinessProviderMockRecorder {
+	return m.recorder
+}
+
+// IsReady mocks base method.
+func (m *MockreadinessProvider) IsReady() bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IsReady")
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// IsReady indicates an expected call of IsReady.
+func (mr *MockreadinessProviderMockRecorder) IsReady() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsReady", reflect.TypeOf((*MockreadinessProvider)(nil).IsReady))
+}
@@ -0,0 +1,85 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: link_import.go
+//
+// Generated by this command:
+//
+//	mockgen -source=link_import.go -destination=./mocks/link_import.go -package=servicesmocks
+//
+// Package servicesmocks is a generated GoMock package.
+package servicesmocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	domain "github.com/MowlCoder/go-url-shortener/internal/domain"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MocklinkShortener is a mock of linkShortener interface.
+type MocklinkShortener struct {
+	ctrl     *gomock.Controller
+	recorder *MocklinkShortenerMockRecorder
+}
+
+// MocklinkShortenerMockRecorder is the mock recorder for MocklinkShortener.
+type MocklinkShortenerMockRecorder struct {
+	mock *MocklinkShortener
+}
+
+// NewMocklinkShortener creates a new mock instance.
+func NewMocklinkShortener(ctrl *gomock.Controller) *MocklinkShortener {
+	mock := &MocklinkShortener{ctrl: ctrl}
+	mock.recorder = &MocklinkShortenerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MocklinkShortener) EXPECT() *MocklinkShortenerMockRecorder {
+	return m.recorder
+}
+
+// GetByShortURL mocks base method.
+func (m *MocklinkShortener) GetByShortURL(ctx context.Context, url string) (*domain.ShortenedURL, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByShortURL", ctx, url)
+	ret0, _ := ret[0].(*domain.ShortenedURL)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByShortURL indicates an expected call of GetByShortURL.
+func (mr *MocklinkShortenerMockRecorder) GetByShortURL(ctx, url any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByShortURL", reflect.TypeOf((*MocklinkShortener)(nil).GetByShortURL), ctx, url)
+}
+
+// GetUserURLsPage mocks base method.
+func (m *MocklinkShortener) GetUserURLsPage(ctx context.Context, userID, afterShortURL string, limit int) ([]domain.ShortenedURL, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserURLsPage", ctx, userID, afterShortURL, limit)
+	ret0, _ := ret[0].([]domain.ShortenedURL)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserURLsPage indicates an expected call of GetUserURLsPage.
+func (mr *MocklinkShortenerMockRecorder) GetUserURLsPage(ctx, userID, afterShortURL, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserURLsPage", reflect.TypeOf((*MocklinkShortener)(nil).GetUserURLsPage), ctx, userID, afterShortURL, limit)
+}
+
+// ShortBatchURL mocks base method.
+func (m *MocklinkShortener) ShortBatchURL(ctx context.Context, urls []domain.ShortBatchURL, userID string) ([]domain.ShortBatchURL, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ShortBatchURL", ctx, urls, userID)
+	ret0, _ := ret[0].([]domain.ShortBatchURL)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ShortBatchURL indicates an expected call of ShortBatchURL.
+func (mr *MocklinkShortenerMockRecorder) ShortBatchURL(ctx, urls, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ShortBatchURL", reflect.TypeOf((*MocklinkShortener)(nil).ShortBatchURL), ctx, urls, userID)
+}
@@ -68,6 +68,21 @@ func (mr *MockurlStorageForServiceMockRecorder) GetByShortURL(ctx, shortURL any)
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByShortURL", reflect.TypeOf((*MockurlStorageForService)(nil).GetByShortURL), ctx, shortURL)
 }
 
+// GetDeleteTaskByID mocks base method.
+func (m *MockurlStorageForService) GetDeleteTaskByID(ctx context.Context, id string) (*domain.DeleteURLsTask, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDeleteTaskByID", ctx, id)
+	ret0, _ := ret[0].(*domain.DeleteURLsTask)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDeleteTaskByID indicates an expected call of GetDeleteTaskByID.
+func (mr *MockurlStorageForServiceMockRecorder) GetDeleteTaskByID(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDeleteTaskByID", reflect.TypeOf((*MockurlStorageForService)(nil).GetDeleteTaskByID), ctx, id)
+}
+
 // GetInternalStats mocks base method.
 func (m *MockurlStorageForService) GetInternalStats(ctx context.Context) (*domain.InternalStats, error) {
 	m.ctrl.T.Helper()
@@ -98,6 +113,21 @@ func (mr *MockurlStorageForServiceMockRecorder) GetURLsByUserID(ctx, userID any)
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetURLsByUserID", reflect.TypeOf((*MockurlStorageForService)(nil).GetURLsByUserID), ctx, userID)
 }
 
+// GetURLsByUserIDAfter mocks base method.
+func (m *MockurlStorageForService) GetURLsByUserIDAfter(ctx context.Context, userID, afterShortURL string, limit int) ([]domain.ShortenedURL, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetURLsByUserIDAfter", ctx, userID, afterShortURL, limit)
+	ret0, _ := ret[0].([]domain.ShortenedURL)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetURLsByUserIDAfter indicates an expected call of GetURLsByUserIDAfter.
+func (mr *MockurlStorageForServiceMockRecorder) GetURLsByUserIDAfter(ctx, userID, afterShortURL, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetURLsByUserIDAfter", reflect.TypeOf((*MockurlStorageForService)(nil).GetURLsByUserIDAfter), ctx, userID, afterShortURL, limit)
+}
+
 // Ping mocks base method.
 func (m *MockurlStorageForService) Ping(ctx context.Context) error {
 	m.ctrl.T.Helper()
@@ -142,6 +172,20 @@ func (mr *MockurlStorageForServiceMockRecorder) SaveURL(ctx, dto any) *gomock.Ca
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveURL", reflect.TypeOf((*MockurlStorageForService)(nil).SaveURL), ctx, dto)
 }
 
+// SetRedirectRules mocks base method.
+func (m *MockurlStorageForService) SetRedirectRules(ctx context.Context, shortURL, userID string, rules []domain.RedirectRule) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetRedirectRules", ctx, shortURL, userID, rules)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetRedirectRules indicates an expected call of SetRedirectRules.
+func (mr *MockurlStorageForServiceMockRecorder) SetRedirectRules(ctx, shortURL, userID, rules any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetRedirectRules", reflect.TypeOf((*MockurlStorageForService)(nil).SetRedirectRules), ctx, shortURL, userID, rules)
+}
+
 // MockstringGeneratorService is a mock of stringGeneratorService interface.
 type MockstringGeneratorService struct {
 	ctrl     *gomock.Controller
@@ -203,13 +247,87 @@ func (m *MockdeleteURLQueue) EXPECT() *MockdeleteURLQueueMockRecorder {
 }
 
 // Push mocks base method.
-func (m *MockdeleteURLQueue) Push(task *domain.DeleteURLsTask) {
+func (m *MockdeleteURLQueue) Push(ctx context.Context, task *domain.DeleteURLsTask) error {
 	m.ctrl.T.Helper()
-	m.ctrl.Call(m, "Push", task)
+	ret := m.ctrl.Call(m, "Push", ctx, task)
+	ret0, _ := ret[0].(error)
+	return ret0
 }
 
 // Push indicates an expected call of Push.
-func (mr *MockdeleteURLQueueMockRecorder) Push(task any) *gomock.Call {
+func (mr *MockdeleteURLQueueMockRecorder) Push(ctx, task any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Push", reflect.TypeOf((*MockdeleteURLQueue)(nil).Push), ctx, task)
+}
+
+// MockclickCounter is a mock of clickCounter interface.
+type MockclickCounter struct {
+	ctrl     *gomock.Controller
+	recorder *MockclickCounterMockRecorder
+}
+
+// MockclickCounterMockRecorder is the mock recorder for MockclickCounter.
+type MockclickCounterMockRecorder struct {
+	mock *MockclickCounter
+}
+
+// NewMockclickCounter creates a new mock instance.
+func NewMockclickCounter(ctrl *gomock.Controller) *MockclickCounter {
+	mock := &MockclickCounter{ctrl: ctrl}
+	mock.recorder = &MockclickCounterMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockclickCounter) EXPECT() *MockclickCounterMockRecorder {
+	return m.recorder
+}
+
+// Register mocks base method.
+func (m *MockclickCounter) Register(url *domain.ShortenedURL) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Register", url)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Register indicates an expected call of Register.
+func (mr *MockclickCounterMockRecorder) Register(url any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Register", reflect.TypeOf((*MockclickCounter)(nil).Register), url)
+}
+
+// MocklinkEventEmitter is a mock of linkEventEmitter interface.
+type MocklinkEventEmitter struct {
+	ctrl     *gomock.Controller
+	recorder *MocklinkEventEmitterMockRecorder
+}
+
+// MocklinkEventEmitterMockRecorder is the mock recorder for MocklinkEventEmitter.
+type MocklinkEventEmitterMockRecorder struct {
+	mock *MocklinkEventEmitter
+}
+
+// NewMocklinkEventEmitter creates a new mock instance.
+func NewMocklinkEventEmitter(ctrl *gomock.Controller) *MocklinkEventEmitter {
+	mock := &MocklinkEventEmitter{ctrl: ctrl}
+	mock.recorder = &MocklinkEventEmitterMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MocklinkEventEmitter) EXPECT() *MocklinkEventEmitterMockRecorder {
+	return m.recorder
+}
+
+// Emit mocks base method.
+func (m *MocklinkEventEmitter) Emit(ctx context.Context, eventType, userID string, links []domain.LinkEventData) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Emit", ctx, eventType, userID, links)
+}
+
+// Emit indicates an expected call of Emit.
+func (mr *MocklinkEventEmitterMockRecorder) Emit(ctx, eventType, userID, links any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Push", reflect.TypeOf((*MockdeleteURLQueue)(nil).Push), task)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Emit", reflect.TypeOf((*MocklinkEventEmitter)(nil).Emit), ctx, eventType, userID, links)
 }
@@ -0,0 +1,171 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: webhook.go
+//
+// Generated by this command:
+//
+//	mockgen -source=webhook.go -destination=./mocks/webhook.go -package=servicesmocks
+//
+// Package servicesmocks is a generated GoMock package.
+package servicesmocks
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	domain "github.com/MowlCoder/go-url-shortener/internal/domain"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockwebhookStorage is a mock of webhookStorage interface.
+type MockwebhookStorage struct {
+	ctrl     *gomock.Controller
+	recorder *MockwebhookStorageMockRecorder
+}
+
+// MockwebhookStorageMockRecorder is the mock recorder for MockwebhookStorage.
+type MockwebhookStorageMockRecorder struct {
+	mock *MockwebhookStorage
+}
+
+// NewMockwebhookStorage creates a new mock instance.
+func NewMockwebhookStorage(ctrl *gomock.Controller) *MockwebhookStorage {
+	mock := &MockwebhookStorage{ctrl: ctrl}
+	mock.recorder = &MockwebhookStorageMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockwebhookStorage) EXPECT() *MockwebhookStorageMockRecorder {
+	return m.recorder
+}
+
+// DeleteWebhook mocks base method.
+func (m *MockwebhookStorage) DeleteWebhook(ctx context.Context, id, userID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteWebhook", ctx, id, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteWebhook indicates an expected call of DeleteWebhook.
+func (mr *MockwebhookStorageMockRecorder) DeleteWebhook(ctx, id, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteWebhook", reflect.TypeOf((*MockwebhookStorage)(nil).DeleteWebhook), ctx, id, userID)
+}
+
+// GetPendingWebhookEvents mocks base method.
+func (m *MockwebhookStorage) GetPendingWebhookEvents(ctx context.Context, now time.Time, limit int) ([]domain.WebhookEvent, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPendingWebhookEvents", ctx, now, limit)
+	ret0, _ := ret[0].([]domain.WebhookEvent)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPendingWebhookEvents indicates an expected call of GetPendingWebhookEvents.
+func (mr *MockwebhookStorageMockRecorder) GetPendingWebhookEvents(ctx, now, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPendingWebhookEvents", reflect.TypeOf((*MockwebhookStorage)(nil).GetPendingWebhookEvents), ctx, now, limit)
+}
+
+// GetWebhookByID mocks base method.
+func (m *MockwebhookStorage) GetWebhookByID(ctx context.Context, id string) (*domain.Webhook, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetWebhookByID", ctx, id)
+	ret0, _ := ret[0].(*domain.Webhook)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetWebhookByID indicates an expected call of GetWebhookByID.
+func (mr *MockwebhookStorageMockRecorder) GetWebhookByID(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetWebhookByID", reflect.TypeOf((*MockwebhookStorage)(nil).GetWebhookByID), ctx, id)
+}
+
+// GetWebhookDeliveries mocks base method.
+func (m *MockwebhookStorage) GetWebhookDeliveries(ctx context.Context, webhookID string) ([]domain.WebhookDelivery, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetWebhookDeliveries", ctx, webhookID)
+	ret0, _ := ret[0].([]domain.WebhookDelivery)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetWebhookDeliveries indicates an expected call of GetWebhookDeliveries.
+func (mr *MockwebhookStorageMockRecorder) GetWebhookDeliveries(ctx, webhookID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetWebhookDeliveries", reflect.TypeOf((*MockwebhookStorage)(nil).GetWebhookDeliveries), ctx, webhookID)
+}
+
+// GetWebhooksByUserID mocks base method.
+func (m *MockwebhookStorage) GetWebhooksByUserID(ctx context.Context, userID string) ([]domain.Webhook, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetWebhooksByUserID", ctx, userID)
+	ret0, _ := ret[0].([]domain.Webhook)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetWebhooksByUserID indicates an expected call of GetWebhooksByUserID.
+func (mr *MockwebhookStorageMockRecorder) GetWebhooksByUserID(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetWebhooksByUserID", reflect.TypeOf((*MockwebhookStorage)(nil).GetWebhooksByUserID), ctx, userID)
+}
+
+// SaveWebhook mocks base method.
+func (m *MockwebhookStorage) SaveWebhook(ctx context.Context, webhook domain.Webhook) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveWebhook", ctx, webhook)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SaveWebhook indicates an expected call of SaveWebhook.
+func (mr *MockwebhookStorageMockRecorder) SaveWebhook(ctx, webhook any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveWebhook", reflect.TypeOf((*MockwebhookStorage)(nil).SaveWebhook), ctx, webhook)
+}
+
+// SaveWebhookDelivery mocks base method.
+func (m *MockwebhookStorage) SaveWebhookDelivery(ctx context.Context, delivery domain.WebhookDelivery) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveWebhookDelivery", ctx, delivery)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SaveWebhookDelivery indicates an expected call of SaveWebhookDelivery.
+func (mr *MockwebhookStorageMockRecorder) SaveWebhookDelivery(ctx, delivery any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveWebhookDelivery", reflect.TypeOf((*MockwebhookStorage)(nil).SaveWebhookDelivery), ctx, delivery)
+}
+
+// SaveWebhookEvents mocks base method.
+func (m *MockwebhookStorage) SaveWebhookEvents(ctx context.Context, events []domain.WebhookEvent) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveWebhookEvents", ctx, events)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SaveWebhookEvents indicates an expected call of SaveWebhookEvents.
+func (mr *MockwebhookStorageMockRecorder) SaveWebhookEvents(ctx, events any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveWebhookEvents", reflect.TypeOf((*MockwebhookStorage)(nil).SaveWebhookEvents), ctx, events)
+}
+
+// UpdateWebhookEvent mocks base method.
+func (m *MockwebhookStorage) UpdateWebhookEvent(ctx context.Context, event domain.WebhookEvent) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateWebhookEvent", ctx, event)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateWebhookEvent indicates an expected call of UpdateWebhookEvent.
+func (mr *MockwebhookStorageMockRecorder) UpdateWebhookEvent(ctx, event any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateWebhookEvent", reflect.TypeOf((*MockwebhookStorage)(nil).UpdateWebhookEvent), ctx, event)
+}
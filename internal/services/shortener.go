@@ -2,6 +2,7 @@ package services
 
 import (
 	"context"
+	"errors"
 
 	"github.com/MowlCoder/go-url-shortener/internal/domain"
 )
@@ -11,7 +12,10 @@ type urlStorageForService interface {
 	SaveURL(ctx context.Context, dto domain.SaveShortURLDto) (*domain.ShortenedURL, error)
 	GetByShortURL(ctx context.Context, shortURL string) (*domain.ShortenedURL, error)
 	GetURLsByUserID(ctx context.Context, userID string) ([]domain.ShortenedURL, error)
+	GetURLsByUserIDAfter(ctx context.Context, userID string, afterShortURL string, limit int) ([]domain.ShortenedURL, error)
 	DeleteByShortURLs(ctx context.Context, shortURLs []string, userID string) error
+	SetRedirectRules(ctx context.Context, shortURL string, userID string, rules []domain.RedirectRule) error
+	GetDeleteTaskByID(ctx context.Context, id string) (*domain.DeleteURLsTask, error)
 	GetInternalStats(ctx context.Context) (*domain.InternalStats, error)
 	Ping(ctx context.Context) error
 }
@@ -21,48 +25,129 @@ type stringGeneratorService interface {
 }
 
 type deleteURLQueue interface {
-	Push(task *domain.DeleteURLsTask)
+	Push(ctx context.Context, task *domain.DeleteURLsTask) error
+}
+
+type clickCounter interface {
+	Register(url *domain.ShortenedURL) error
+}
+
+type linkEventEmitter interface {
+	Emit(ctx context.Context, eventType string, userID string, links []domain.LinkEventData)
 }
 
 type ShortenerService struct {
 	urlStorage      urlStorageForService
 	stringGenerator stringGeneratorService
 	deleteURLQueue  deleteURLQueue
+	eventEmitter    linkEventEmitter
+	clickCounter    clickCounter
 }
 
 func NewShortenerService(
 	urlStorage urlStorageForService,
 	stringGenerator stringGeneratorService,
 	deleteURLQueue deleteURLQueue,
+	eventEmitter linkEventEmitter,
+	clickCounter clickCounter,
 ) *ShortenerService {
 	return &ShortenerService{
 		urlStorage:      urlStorage,
 		stringGenerator: stringGenerator,
 		deleteURLQueue:  deleteURLQueue,
+		eventEmitter:    eventEmitter,
+		clickCounter:    clickCounter,
 	}
 }
 
-func (s *ShortenerService) ShortURL(ctx context.Context, url string, userID string) (*domain.ShortenedURL, error) {
+// ShortURL short url with given options. If url is already shortened, existing url is returned with domain.ErrURLConflict.
+func (s *ShortenerService) ShortURL(ctx context.Context, url string, userID string, options domain.ShortURLOptions) (*domain.ShortenedURL, error) {
+	if !domain.IsValidRedirectType(options.RedirectType) {
+		return nil, domain.ErrInvalidRedirectType
+	}
+
 	shortURL := s.stringGenerator.GenerateRandom()
 
-	return s.urlStorage.SaveURL(ctx, domain.SaveShortURLDto{
-		OriginalURL: url,
-		ShortURL:    shortURL,
-		UserID:      userID,
+	shortenedURL, err := s.urlStorage.SaveURL(ctx, domain.SaveShortURLDto{
+		OriginalURL:      url,
+		ShortURL:         shortURL,
+		UserID:           userID,
+		RedirectType:     options.RedirectType,
+		UTM:              normalizeUTM(options.UTM),
+		QueryPassthrough: options.QueryPassthrough,
+	})
+	if err != nil {
+		return shortenedURL, err
+	}
+
+	s.eventEmitter.Emit(ctx, domain.EventLinkCreated, userID, []domain.LinkEventData{
+		{
+			ShortURL:    shortenedURL.ShortURL,
+			OriginalURL: shortenedURL.OriginalURL,
+		},
 	})
+
+	return shortenedURL, nil
 }
 
+// ShortBatchURL short several urls at once. If url has ShortURL, it is used as alias instead of generated one.
+// Urls with alias, that is already taken, are not saved and are missing in result.
 func (s *ShortenerService) ShortBatchURL(ctx context.Context, urls []domain.ShortBatchURL, userID string) ([]domain.ShortBatchURL, error) {
 	correlations := make(map[string]string)
+	generatedShortURLs := make(map[string]string)
 	saveDtos := make([]domain.SaveShortURLDto, 0, len(urls))
+	aliases := make(map[string]struct{})
+
+	for _, url := range urls {
+		if !domain.IsValidRedirectType(url.RedirectType) {
+			return nil, domain.ErrInvalidRedirectType
+		}
+
+		if err := domain.ValidateRedirectRules(url.RedirectRules); err != nil {
+			return nil, err
+		}
+	}
 
 	for _, url := range urls {
+		shortURL := url.ShortURL
+
+		if shortURL == "" {
+			shortURL = s.stringGenerator.GenerateRandom()
+		} else {
+			if _, ok := aliases[shortURL]; ok {
+				continue
+			}
+
+			_, err := s.urlStorage.GetByShortURL(ctx, shortURL)
+
+			if err == nil {
+				continue
+			}
+
+			if !errors.Is(err, domain.ErrURLNotFound) {
+				return nil, err
+			}
+
+			aliases[shortURL] = struct{}{}
+		}
+
 		saveDtos = append(saveDtos, domain.SaveShortURLDto{
-			OriginalURL: url.OriginalURL,
-			ShortURL:    s.stringGenerator.GenerateRandom(),
-			UserID:      userID,
+			OriginalURL:      url.OriginalURL,
+			ShortURL:         shortURL,
+			UserID:           userID,
+			Tags:             url.Tags,
+			ExpiresAt:        url.ExpiresAt,
+			RedirectType:     url.RedirectType,
+			UTM:              normalizeUTM(url.UTM),
+			QueryPassthrough: url.QueryPassthrough,
+			RedirectRules:    url.RedirectRules,
 		})
 		correlations[url.OriginalURL] = url.CorrelationID
+		generatedShortURLs[url.OriginalURL] = shortURL
+	}
+
+	if len(saveDtos) == 0 {
+		return []domain.ShortBatchURL{}, nil
 	}
 
 	shortenedURLs, err := s.urlStorage.SaveSeveralURL(ctx, saveDtos)
@@ -72,33 +157,124 @@ func (s *ShortenerService) ShortBatchURL(ctx context.Context, urls []domain.Shor
 	}
 
 	result := make([]domain.ShortBatchURL, 0)
+	createdLinks := make([]domain.LinkEventData, 0)
 
 	for _, url := range shortenedURLs {
+		// Storage returns already existing urls too, only urls with generated short url are new.
+		isExisting := generatedShortURLs[url.OriginalURL] != url.ShortURL
+
 		result = append(result, domain.ShortBatchURL{
-			ShortURL:      url.ShortURL,
-			OriginalURL:   url.OriginalURL,
-			CorrelationID: correlations[url.OriginalURL],
+			ShortURL:         url.ShortURL,
+			OriginalURL:      url.OriginalURL,
+			CorrelationID:    correlations[url.OriginalURL],
+			Tags:             url.Tags,
+			ExpiresAt:        url.ExpiresAt,
+			RedirectType:     url.RedirectType,
+			UTM:              url.UTM,
+			QueryPassthrough: url.QueryPassthrough,
+			RedirectRules:    url.RedirectRules,
+			IsExisting:       isExisting,
 		})
+
+		if !isExisting {
+			createdLinks = append(createdLinks, domain.LinkEventData{
+				ShortURL:    url.ShortURL,
+				OriginalURL: url.OriginalURL,
+			})
+		}
 	}
 
+	s.eventEmitter.Emit(ctx, domain.EventLinkCreated, userID, createdLinks)
+
 	return result, nil
 }
 
+// normalizeUTM returns nil for empty params, so urls without UTM parameters are stored without them.
+func normalizeUTM(params *domain.UTMParams) *domain.UTMParams {
+	if params.IsEmpty() {
+		return nil
+	}
+
+	return params
+}
+
 func (s *ShortenerService) GetByShortURL(ctx context.Context, url string) (*domain.ShortenedURL, error) {
 	return s.urlStorage.GetByShortURL(ctx, url)
 }
 
+// RegisterClick pass click of url to click counter, that saves it in background.
+func (s *ShortenerService) RegisterClick(ctx context.Context, url *domain.ShortenedURL) error {
+	return s.clickCounter.Register(url)
+}
+
 func (s *ShortenerService) GetUserURLs(ctx context.Context, userID string) ([]domain.ShortenedURL, error) {
 	return s.urlStorage.GetURLsByUserID(ctx, userID)
 }
 
-func (s *ShortenerService) DeleteURLs(ctx context.Context, urls []string, userID string) error {
-	go s.deleteURLQueue.Push(&domain.DeleteURLsTask{
+// GetUserURLsPage return up to limit urls of user with short url greater than afterShortURL, ordered by short url.
+// Use empty afterShortURL to get first page.
+func (s *ShortenerService) GetUserURLsPage(
+	ctx context.Context,
+	userID string,
+	afterShortURL string,
+	limit int,
+) ([]domain.ShortenedURL, error) {
+	return s.urlStorage.GetURLsByUserIDAfter(ctx, userID, afterShortURL, limit)
+}
+
+// GetRedirectRules return redirect rules of user url.
+func (s *ShortenerService) GetRedirectRules(ctx context.Context, shortURL string, userID string) ([]domain.RedirectRule, error) {
+	url, err := s.urlStorage.GetByShortURL(ctx, shortURL)
+	if err != nil {
+		return nil, err
+	}
+
+	if url.UserID != userID {
+		return nil, domain.ErrURLNotFound
+	}
+
+	return url.RedirectRules, nil
+}
+
+// SetRedirectRules validate and replace redirect rules of user url. Empty rules remove all rules.
+func (s *ShortenerService) SetRedirectRules(ctx context.Context, shortURL string, userID string, rules []domain.RedirectRule) error {
+	if err := domain.ValidateRedirectRules(rules); err != nil {
+		return err
+	}
+
+	if len(rules) == 0 {
+		rules = nil
+	}
+
+	return s.urlStorage.SetRedirectRules(ctx, shortURL, userID, rules)
+}
+
+// DeleteURLs schedules deletion of user urls. Returned task can be used to poll deletion status.
+func (s *ShortenerService) DeleteURLs(ctx context.Context, urls []string, userID string) (*domain.DeleteURLsTask, error) {
+	task := &domain.DeleteURLsTask{
 		ShortURLs: urls,
 		UserID:    userID,
-	})
+	}
+
+	if err := s.deleteURLQueue.Push(ctx, task); err != nil {
+		return nil, err
+	}
+
+	return task, nil
+}
+
+// GetDeleteTask return delete task of user.
+func (s *ShortenerService) GetDeleteTask(ctx context.Context, id string, userID string) (*domain.DeleteURLsTask, error) {
+	task, err := s.urlStorage.GetDeleteTaskByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if task.UserID != userID {
+		return nil, domain.ErrDeleteTaskNotFound
+	}
 
-	return nil
+	return task, nil
 }
 
 func (s *ShortenerService) GetInternalStats(ctx context.Context) (*domain.InternalStats, error) {
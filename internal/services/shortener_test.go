@@ -22,11 +22,14 @@ func TestShortenerService_ShortURL(t *testing.T) {
 	storage := servicesmocks.NewMockurlStorageForService(ctrl)
 	stringsGenerator := servicesmocks.NewMockstringGeneratorService(ctrl)
 	deleteQueue := servicesmocks.NewMockdeleteURLQueue(ctrl)
+	eventEmitter := servicesmocks.NewMocklinkEventEmitter(ctrl)
 
 	service := NewShortenerService(
 		storage,
 		stringsGenerator,
 		deleteQueue,
+		eventEmitter,
+		nil,
 	)
 
 	type TestCase struct {
@@ -34,9 +37,11 @@ func TestShortenerService_ShortURL(t *testing.T) {
 			ctx context.Context,
 			body string,
 		)
-		Name    string
-		URL     string
-		IsError bool
+		Options     domain.ShortURLOptions
+		ExpectedErr error
+		Name        string
+		URL         string
+		IsError     bool
 	}
 
 	testCases := []TestCase{
@@ -58,9 +63,76 @@ func TestShortenerService_ShortURL(t *testing.T) {
 					Return(&domain.ShortenedURL{
 						OriginalURL: body,
 					}, nil)
+				eventEmitter.
+					EXPECT().
+					Emit(ctx, domain.EventLinkCreated, "1", gomock.Any())
+			},
+			IsError: false,
+		},
+		{
+			Name:    "valid with redirect type",
+			URL:     "https://url.com",
+			Options: domain.ShortURLOptions{RedirectType: domain.RedirectPermanent},
+			PrepareServiceFunc: func(ctx context.Context, body string) {
+				stringsGenerator.
+					EXPECT().
+					GenerateRandom().
+					Return("1234")
+				storage.
+					EXPECT().
+					SaveURL(ctx, domain.SaveShortURLDto{
+						OriginalURL:  body,
+						ShortURL:     "1234",
+						UserID:       "1",
+						RedirectType: domain.RedirectPermanent,
+					}).
+					Return(&domain.ShortenedURL{
+						OriginalURL:  body,
+						RedirectType: domain.RedirectPermanent,
+					}, nil)
+				eventEmitter.
+					EXPECT().
+					Emit(ctx, domain.EventLinkCreated, "1", gomock.Any())
+			},
+			IsError: false,
+		},
+		{
+			Name: "empty utm params are not stored",
+			URL:  "https://url.com",
+			Options: domain.ShortURLOptions{
+				UTM:              &domain.UTMParams{},
+				QueryPassthrough: true,
+			},
+			PrepareServiceFunc: func(ctx context.Context, body string) {
+				stringsGenerator.
+					EXPECT().
+					GenerateRandom().
+					Return("1234")
+				storage.
+					EXPECT().
+					SaveURL(ctx, domain.SaveShortURLDto{
+						OriginalURL:      body,
+						ShortURL:         "1234",
+						UserID:           "1",
+						QueryPassthrough: true,
+					}).
+					Return(&domain.ShortenedURL{
+						OriginalURL:      body,
+						QueryPassthrough: true,
+					}, nil)
+				eventEmitter.
+					EXPECT().
+					Emit(ctx, domain.EventLinkCreated, "1", gomock.Any())
 			},
 			IsError: false,
 		},
+		{
+			Name:        "invalid redirect type",
+			URL:         "https://url.com",
+			Options:     domain.ShortURLOptions{RedirectType: "303"},
+			ExpectedErr: domain.ErrInvalidRedirectType,
+			IsError:     true,
+		},
 		{
 			Name: "err row conflict",
 			URL:  "https://url.com",
@@ -111,10 +183,14 @@ func TestShortenerService_ShortURL(t *testing.T) {
 				testCase.PrepareServiceFunc(ctx, testCase.URL)
 			}
 
-			url, err := service.ShortURL(ctx, testCase.URL, "1")
+			url, err := service.ShortURL(ctx, testCase.URL, "1", testCase.Options)
 
 			if testCase.IsError {
 				require.Error(t, err)
+
+				if testCase.ExpectedErr != nil {
+					assert.ErrorIs(t, err, testCase.ExpectedErr)
+				}
 			} else {
 				assert.Equal(t, url.OriginalURL, testCase.URL)
 			}
@@ -127,11 +203,14 @@ func TestShortenerService_ShortBatchURL(t *testing.T) {
 	storage := servicesmocks.NewMockurlStorageForService(ctrl)
 	stringsGenerator := servicesmocks.NewMockstringGeneratorService(ctrl)
 	deleteQueue := servicesmocks.NewMockdeleteURLQueue(ctrl)
+	eventEmitter := servicesmocks.NewMocklinkEventEmitter(ctrl)
 
 	service := NewShortenerService(
 		storage,
 		stringsGenerator,
 		deleteQueue,
+		eventEmitter,
+		nil,
 	)
 
 	type TestCase struct {
@@ -176,6 +255,10 @@ func TestShortenerService_ShortBatchURL(t *testing.T) {
 					EXPECT().
 					SaveSeveralURL(ctx, gomock.Any()).
 					Return(shortenedUrls, nil)
+
+				eventEmitter.
+					EXPECT().
+					Emit(ctx, domain.EventLinkCreated, "1", gomock.Len(len(body)))
 			},
 			IsError: false,
 		},
@@ -206,6 +289,17 @@ func TestShortenerService_ShortBatchURL(t *testing.T) {
 			},
 			IsError: true,
 		},
+		{
+			Name: "invalid redirect rules",
+			Body: []domain.ShortBatchURL{
+				{
+					OriginalURL:   "https://url.com",
+					CorrelationID: "1",
+					RedirectRules: []domain.RedirectRule{{Destination: "https://url.com/ios"}},
+				},
+			},
+			IsError: true,
+		},
 	}
 
 	for _, testCase := range testCases {
@@ -247,16 +341,85 @@ func TestShortenerService_ShortBatchURL(t *testing.T) {
 	}
 }
 
+func TestShortenerService_ShortBatchURL_Alias(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	storage := servicesmocks.NewMockurlStorageForService(ctrl)
+	stringsGenerator := servicesmocks.NewMockstringGeneratorService(ctrl)
+	deleteQueue := servicesmocks.NewMockdeleteURLQueue(ctrl)
+	eventEmitter := servicesmocks.NewMocklinkEventEmitter(ctrl)
+
+	service := NewShortenerService(
+		storage,
+		stringsGenerator,
+		deleteQueue,
+		eventEmitter,
+		nil,
+	)
+
+	ctx := context.Background()
+	body := []domain.ShortBatchURL{
+		{OriginalURL: "https://url.com/free", ShortURL: "free", CorrelationID: "1", Tags: []string{"promo"}},
+		{OriginalURL: "https://url.com/taken", ShortURL: "taken", CorrelationID: "2"},
+		{OriginalURL: "https://url.com/again", ShortURL: "free", CorrelationID: "3"},
+	}
+
+	storage.EXPECT().GetByShortURL(ctx, "free").Return(nil, domain.ErrURLNotFound)
+	storage.EXPECT().GetByShortURL(ctx, "taken").Return(&domain.ShortenedURL{ShortURL: "taken"}, nil)
+	storage.
+		EXPECT().
+		SaveSeveralURL(ctx, []domain.SaveShortURLDto{
+			{OriginalURL: "https://url.com/free", ShortURL: "free", UserID: "1", Tags: []string{"promo"}},
+		}).
+		Return([]domain.ShortenedURL{{OriginalURL: "https://url.com/free", ShortURL: "free"}}, nil)
+	eventEmitter.EXPECT().Emit(ctx, domain.EventLinkCreated, "1", gomock.Len(1))
+
+	result, err := service.ShortBatchURL(ctx, body, "1")
+	require.NoError(t, err)
+	require.Len(t, result, 1)
+	assert.Equal(t, "free", result[0].ShortURL)
+	assert.Equal(t, "1", result[0].CorrelationID)
+}
+
+func TestShortenerService_RegisterClick(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	clicks := servicesmocks.NewMockclickCounter(ctrl)
+
+	service := NewShortenerService(
+		servicesmocks.NewMockurlStorageForService(ctrl),
+		servicesmocks.NewMockstringGeneratorService(ctrl),
+		servicesmocks.NewMockdeleteURLQueue(ctrl),
+		servicesmocks.NewMocklinkEventEmitter(ctrl),
+		clicks,
+	)
+
+	url := &domain.ShortenedURL{ShortURL: "1234", OriginalURL: "https://url.com", UserID: "1"}
+
+	t.Run("registered", func(t *testing.T) {
+		clicks.EXPECT().Register(url).Return(nil)
+
+		assert.NoError(t, service.RegisterClick(context.Background(), url))
+	})
+
+	t.Run("buffer is full", func(t *testing.T) {
+		clicks.EXPECT().Register(url).Return(errClickBufferFull)
+
+		assert.ErrorIs(t, service.RegisterClick(context.Background(), url), errClickBufferFull)
+	})
+}
+
 func TestShortenerService_GetUserURLs(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	storage := servicesmocks.NewMockurlStorageForService(ctrl)
 	stringsGenerator := servicesmocks.NewMockstringGeneratorService(ctrl)
 	deleteQueue := servicesmocks.NewMockdeleteURLQueue(ctrl)
+	eventEmitter := servicesmocks.NewMocklinkEventEmitter(ctrl)
 
 	service := NewShortenerService(
 		storage,
 		stringsGenerator,
 		deleteQueue,
+		eventEmitter,
+		nil,
 	)
 
 	type TestCase struct {
@@ -326,11 +489,14 @@ func TestShortenerService_DeleteURLs(t *testing.T) {
 	storage := servicesmocks.NewMockurlStorageForService(ctrl)
 	stringsGenerator := servicesmocks.NewMockstringGeneratorService(ctrl)
 	deleteQueue := servicesmocks.NewMockdeleteURLQueue(ctrl)
+	eventEmitter := servicesmocks.NewMocklinkEventEmitter(ctrl)
 
 	service := NewShortenerService(
 		storage,
 		stringsGenerator,
 		deleteQueue,
+		eventEmitter,
+		nil,
 	)
 
 	type TestCase struct {
@@ -349,10 +515,22 @@ func TestShortenerService_DeleteURLs(t *testing.T) {
 			PrepareServiceFunc: func(ctx context.Context) {
 				deleteQueue.
 					EXPECT().
-					Push(gomock.Any()).AnyTimes()
+					Push(ctx, gomock.Any()).
+					Return(nil)
 			},
 			IsError: false,
 		},
+		{
+			Name: "queue error",
+			Body: dtos.DeleteURLsRequest{"123", "1234"},
+			PrepareServiceFunc: func(ctx context.Context) {
+				deleteQueue.
+					EXPECT().
+					Push(ctx, gomock.Any()).
+					Return(errors.New("undefined behavior"))
+			},
+			IsError: true,
+		},
 	}
 
 	for _, testCase := range testCases {
@@ -363,10 +541,214 @@ func TestShortenerService_DeleteURLs(t *testing.T) {
 				testCase.PrepareServiceFunc(ctx)
 			}
 
-			err := service.DeleteURLs(ctx, testCase.Body, "1")
+			task, err := service.DeleteURLs(ctx, testCase.Body, "1")
 
 			if testCase.IsError {
 				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, "1", task.UserID)
+				assert.Equal(t, []string(testCase.Body), task.ShortURLs)
+			}
+		})
+	}
+}
+
+func TestShortenerService_GetDeleteTask(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	storage := servicesmocks.NewMockurlStorageForService(ctrl)
+	stringsGenerator := servicesmocks.NewMockstringGeneratorService(ctrl)
+	deleteQueue := servicesmocks.NewMockdeleteURLQueue(ctrl)
+	eventEmitter := servicesmocks.NewMocklinkEventEmitter(ctrl)
+
+	service := NewShortenerService(
+		storage,
+		stringsGenerator,
+		deleteQueue,
+		eventEmitter,
+		nil,
+	)
+
+	type TestCase struct {
+		StorageTask *domain.DeleteURLsTask
+		StorageErr  error
+		ExpectedErr error
+		Name        string
+	}
+
+	testCases := []TestCase{
+		{
+			Name:        "valid",
+			StorageTask: &domain.DeleteURLsTask{ID: "task", UserID: "1"},
+		},
+		{
+			Name:        "another user",
+			StorageTask: &domain.DeleteURLsTask{ID: "task", UserID: "2"},
+			ExpectedErr: domain.ErrDeleteTaskNotFound,
+		},
+		{
+			Name:        "not found",
+			StorageErr:  domain.ErrDeleteTaskNotFound,
+			ExpectedErr: domain.ErrDeleteTaskNotFound,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			ctx := context.Background()
+
+			storage.
+				EXPECT().
+				GetDeleteTaskByID(ctx, "task").
+				Return(testCase.StorageTask, testCase.StorageErr)
+
+			task, err := service.GetDeleteTask(ctx, "task", "1")
+
+			if testCase.ExpectedErr != nil {
+				assert.ErrorIs(t, err, testCase.ExpectedErr)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, "task", task.ID)
+			}
+		})
+	}
+}
+
+func TestShortenerService_GetRedirectRules(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	storage := servicesmocks.NewMockurlStorageForService(ctrl)
+	stringsGenerator := servicesmocks.NewMockstringGeneratorService(ctrl)
+	deleteQueue := servicesmocks.NewMockdeleteURLQueue(ctrl)
+	eventEmitter := servicesmocks.NewMocklinkEventEmitter(ctrl)
+
+	service := NewShortenerService(
+		storage,
+		stringsGenerator,
+		deleteQueue,
+		eventEmitter,
+		nil,
+	)
+
+	rules := []domain.RedirectRule{{Destination: "https://test.de", Countries: []string{"DE"}}}
+
+	type TestCase struct {
+		StorageURL  *domain.ShortenedURL
+		StorageErr  error
+		ExpectedErr error
+		Name        string
+	}
+
+	testCases := []TestCase{
+		{
+			Name:       "valid",
+			StorageURL: &domain.ShortenedURL{ShortURL: "short", UserID: "1", RedirectRules: rules},
+		},
+		{
+			Name:        "another user",
+			StorageURL:  &domain.ShortenedURL{ShortURL: "short", UserID: "2", RedirectRules: rules},
+			ExpectedErr: domain.ErrURLNotFound,
+		},
+		{
+			Name:        "not found",
+			StorageErr:  domain.ErrURLNotFound,
+			ExpectedErr: domain.ErrURLNotFound,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			ctx := context.Background()
+
+			storage.
+				EXPECT().
+				GetByShortURL(ctx, "short").
+				Return(testCase.StorageURL, testCase.StorageErr)
+
+			result, err := service.GetRedirectRules(ctx, "short", "1")
+
+			if testCase.ExpectedErr != nil {
+				assert.ErrorIs(t, err, testCase.ExpectedErr)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, rules, result)
+			}
+		})
+	}
+}
+
+func TestShortenerService_SetRedirectRules(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	storage := servicesmocks.NewMockurlStorageForService(ctrl)
+	stringsGenerator := servicesmocks.NewMockstringGeneratorService(ctrl)
+	deleteQueue := servicesmocks.NewMockdeleteURLQueue(ctrl)
+	eventEmitter := servicesmocks.NewMocklinkEventEmitter(ctrl)
+
+	service := NewShortenerService(
+		storage,
+		stringsGenerator,
+		deleteQueue,
+		eventEmitter,
+		nil,
+	)
+
+	type TestCase struct {
+		PrepareServiceFunc func(ctx context.Context)
+		ExpectedErr        error
+		Name               string
+		Rules              []domain.RedirectRule
+	}
+
+	testCases := []TestCase{
+		{
+			Name:  "valid",
+			Rules: []domain.RedirectRule{{Destination: "https://test.de", Countries: []string{"DE"}}},
+			PrepareServiceFunc: func(ctx context.Context) {
+				storage.
+					EXPECT().
+					SetRedirectRules(ctx, "short", "1", []domain.RedirectRule{{Destination: "https://test.de", Countries: []string{"DE"}}}).
+					Return(nil)
+			},
+		},
+		{
+			Name:  "empty rules are removed",
+			Rules: []domain.RedirectRule{},
+			PrepareServiceFunc: func(ctx context.Context) {
+				storage.
+					EXPECT().
+					SetRedirectRules(ctx, "short", "1", nil).
+					Return(nil)
+			},
+		},
+		{
+			Name:        "invalid rules",
+			Rules:       []domain.RedirectRule{{Destination: "https://test.de"}},
+			ExpectedErr: domain.ErrInvalidRedirectRules,
+		},
+		{
+			Name:  "not found",
+			Rules: nil,
+			PrepareServiceFunc: func(ctx context.Context) {
+				storage.
+					EXPECT().
+					SetRedirectRules(ctx, "short", "1", nil).
+					Return(domain.ErrURLNotFound)
+			},
+			ExpectedErr: domain.ErrURLNotFound,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			ctx := context.Background()
+
+			if testCase.PrepareServiceFunc != nil {
+				testCase.PrepareServiceFunc(ctx)
+			}
+
+			err := service.SetRedirectRules(ctx, "short", "1", testCase.Rules)
+
+			if testCase.ExpectedErr != nil {
+				assert.ErrorIs(t, err, testCase.ExpectedErr)
 			} else {
 				assert.NoError(t, err)
 			}
@@ -379,11 +761,14 @@ func TestShortenerService_Ping(t *testing.T) {
 	storage := servicesmocks.NewMockurlStorageForService(ctrl)
 	stringsGenerator := servicesmocks.NewMockstringGeneratorService(ctrl)
 	deleteQueue := servicesmocks.NewMockdeleteURLQueue(ctrl)
+	eventEmitter := servicesmocks.NewMocklinkEventEmitter(ctrl)
 
 	service := NewShortenerService(
 		storage,
 		stringsGenerator,
 		deleteQueue,
+		eventEmitter,
+		nil,
 	)
 
 	type TestCase struct {
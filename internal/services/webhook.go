@@ -0,0 +1,366 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+
+	"github.com/MowlCoder/go-url-shortener/internal/domain"
+)
+
+// Headers that sends with every webhook request.
+const (
+	WebhookSignatureHeader = "X-Webhook-Signature"
+	WebhookTimestampHeader = "X-Webhook-Timestamp"
+	WebhookEventHeader     = "X-Webhook-Event"
+	WebhookDeliveryHeader  = "X-Webhook-Delivery"
+)
+
+const (
+	webhookDispatchInterval = time.Second * 5
+	webhookBatchSize        = 100
+	webhookMaxAttempts      = 8
+	webhookBaseBackoff      = time.Second * 10
+	webhookMaxBackoff       = time.Hour
+	webhookRequestTimeout   = time.Second * 10
+)
+
+type webhookStorage interface {
+	SaveWebhook(ctx context.Context, webhook domain.Webhook) error
+	GetWebhookByID(ctx context.Context, id string) (*domain.Webhook, error)
+	GetWebhooksByUserID(ctx context.Context, userID string) ([]domain.Webhook, error)
+	DeleteWebhook(ctx context.Context, id string, userID string) error
+	SaveWebhookEvents(ctx context.Context, events []domain.WebhookEvent) error
+	GetPendingWebhookEvents(ctx context.Context, now time.Time, limit int) ([]domain.WebhookEvent, error)
+	UpdateWebhookEvent(ctx context.Context, event domain.WebhookEvent) error
+	SaveWebhookDelivery(ctx context.Context, delivery domain.WebhookDelivery) error
+	GetWebhookDeliveries(ctx context.Context, webhookID string) ([]domain.WebhookDelivery, error)
+}
+
+// WebhookService manage user webhook subscriptions, write link lifecycle events to outbox
+// and deliver them to subscribers with retries and exponential backoff.
+type WebhookService struct {
+	storage     webhookStorage
+	logger      logger
+	httpClient  *http.Client
+	now         func() time.Time
+	done        chan struct{}
+	interval    time.Duration
+	baseBackoff time.Duration
+	maxBackoff  time.Duration
+	maxAttempts int
+	batchSize   int
+}
+
+// NewWebhookService is constructor function to create WebhookService.
+func NewWebhookService(storage webhookStorage, logger logger) *WebhookService {
+	return &WebhookService{
+		storage:     storage,
+		logger:      logger,
+		httpClient:  newWebhookHTTPClient(),
+		now:         time.Now,
+		done:        make(chan struct{}),
+		interval:    webhookDispatchInterval,
+		baseBackoff: webhookBaseBackoff,
+		maxBackoff:  webhookMaxBackoff,
+		maxAttempts: webhookMaxAttempts,
+		batchSize:   webhookBatchSize,
+	}
+}
+
+// Subscribe create webhook for user. If events are empty, webhook subscribes to all link events.
+// Webhooks to loopback, private and link-local addresses are rejected.
+func (s *WebhookService) Subscribe(ctx context.Context, userID string, webhookURL string, events []string) (*domain.Webhook, error) {
+	parsedURL, err := url.Parse(webhookURL)
+	if err != nil || (parsedURL.Scheme != "http" && parsedURL.Scheme != "https") || parsedURL.Hostname() == "" {
+		return nil, domain.ErrInvalidWebhookURL
+	}
+
+	if isForbiddenWebhookHost(parsedURL.Hostname()) {
+		return nil, domain.ErrInvalidWebhookURL
+	}
+
+	if len(events) == 0 {
+		events = domain.LinkEvents
+	}
+
+	for _, event := range events {
+		if !isLinkEvent(event) {
+			return nil, domain.ErrInvalidWebhookEvent
+		}
+	}
+
+	secret, err := generateWebhookSecret()
+	if err != nil {
+		return nil, err
+	}
+
+	webhook := domain.Webhook{
+		ID:        uuid.NewString(),
+		UserID:    userID,
+		URL:       webhookURL,
+		Secret:    secret,
+		Events:    events,
+		CreatedAt: s.now().UTC(),
+	}
+
+	if err := s.storage.SaveWebhook(ctx, webhook); err != nil {
+		return nil, err
+	}
+
+	return &webhook, nil
+}
+
+// GetUserWebhooks return all webhooks of user.
+func (s *WebhookService) GetUserWebhooks(ctx context.Context, userID string) ([]domain.Webhook, error) {
+	return s.storage.GetWebhooksByUserID(ctx, userID)
+}
+
+// DeleteWebhook delete webhook of user.
+func (s *WebhookService) DeleteWebhook(ctx context.Context, id string, userID string) error {
+	return s.storage.DeleteWebhook(ctx, id, userID)
+}
+
+// GetDeliveries return delivery log of user webhook.
+func (s *WebhookService) GetDeliveries(ctx context.Context, id string, userID string) ([]domain.WebhookDelivery, error) {
+	webhook, err := s.storage.GetWebhookByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if webhook.UserID != userID {
+		return nil, domain.ErrWebhookNotFound
+	}
+
+	return s.storage.GetWebhookDeliveries(ctx, id)
+}
+
+// Emit write event about every given link to outbox of each user webhook subscribed to event type.
+// Errors are logged and not returned, because event emitting must not break main flow.
+func (s *WebhookService) Emit(ctx context.Context, eventType string, userID string, links []domain.LinkEventData) {
+	if userID == "" || len(links) == 0 {
+		return
+	}
+
+	webhooks, err := s.storage.GetWebhooksByUserID(ctx, userID)
+	if err != nil {
+		s.logger.Error("emit event", zap.String("event_type", eventType), zap.Error(err))
+		return
+	}
+
+	now := s.now().UTC()
+	events := make([]domain.WebhookEvent, 0)
+
+	for _, webhook := range webhooks {
+		if !webhook.IsSubscribedTo(eventType) {
+			continue
+		}
+
+		for _, link := range links {
+			eventID := uuid.NewString()
+			payload, err := json.Marshal(domain.WebhookPayload{
+				ID:         eventID,
+				Type:       eventType,
+				OccurredAt: now,
+				Data:       link,
+			})
+			if err != nil {
+				s.logger.Error("emit event", zap.String("event_type", eventType), zap.Error(err))
+				continue
+			}
+
+			events = append(events, domain.WebhookEvent{
+				ID:            eventID,
+				WebhookID:     webhook.ID,
+				EventType:     eventType,
+				Status:        domain.WebhookEventPending,
+				Payload:       payload,
+				CreatedAt:     now,
+				NextAttemptAt: now,
+			})
+		}
+	}
+
+	if len(events) == 0 {
+		return
+	}
+
+	if err := s.storage.SaveWebhookEvents(ctx, events); err != nil {
+		s.logger.Error("emit event", zap.String("event_type", eventType), zap.Error(err))
+	}
+}
+
+// Start starts delivery job. Every interval pending events from outbox are sent to webhooks.
+// Job stops when given context is done, after that Done channel is closed.
+func (s *WebhookService) Start(ctx context.Context) {
+	defer close(s.done)
+
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := s.deliverPending(ctx); err != nil {
+				s.logger.Error("deliver webhook events", zap.Error(err))
+			}
+		}
+	}
+}
+
+// Done returns channel that is closed when delivery job is stopped.
+func (s *WebhookService) Done() <-chan struct{} {
+	return s.done
+}
+
+func (s *WebhookService) deliverPending(ctx context.Context) error {
+	events, err := s.storage.GetPendingWebhookEvents(ctx, s.now().UTC(), s.batchSize)
+	if err != nil {
+		return err
+	}
+
+	for _, event := range events {
+		if err := s.deliver(ctx, event); err != nil {
+			s.logger.Error("deliver webhook event", zap.String("event_id", event.ID), zap.Error(err))
+		}
+	}
+
+	return nil
+}
+
+func (s *WebhookService) deliver(ctx context.Context, event domain.WebhookEvent) error {
+	webhook, err := s.storage.GetWebhookByID(ctx, event.WebhookID)
+	if err != nil {
+		if errors.Is(err, domain.ErrWebhookNotFound) {
+			event.Status = domain.WebhookEventFailed
+			return s.storage.UpdateWebhookEvent(ctx, event)
+		}
+
+		return err
+	}
+
+	event.Attempts++
+	start := s.now()
+	statusCode, sendErr := s.send(ctx, webhook, event)
+
+	delivery := domain.WebhookDelivery{
+		ID:          uuid.NewString(),
+		WebhookID:   webhook.ID,
+		EventID:     event.ID,
+		EventType:   event.EventType,
+		StatusCode:  statusCode,
+		Attempt:     event.Attempts,
+		AttemptedAt: start.UTC(),
+		DurationMs:  s.now().Sub(start).Milliseconds(),
+		Success:     sendErr == nil,
+	}
+
+	if sendErr != nil {
+		delivery.Error = sendErr.Error()
+	}
+
+	if err := s.storage.SaveWebhookDelivery(ctx, delivery); err != nil {
+		return err
+	}
+
+	switch {
+	case sendErr == nil:
+		event.Status = domain.WebhookEventDelivered
+	case event.Attempts >= s.maxAttempts:
+		event.Status = domain.WebhookEventFailed
+	default:
+		event.NextAttemptAt = s.now().UTC().Add(s.backoff(event.Attempts))
+	}
+
+	return s.storage.UpdateWebhookEvent(ctx, event)
+}
+
+func (s *WebhookService) send(ctx context.Context, webhook *domain.Webhook, event domain.WebhookEvent) (int, error) {
+	timestamp := strconv.FormatInt(s.now().Unix(), 10)
+
+	request, err := http.NewRequestWithContext(ctx, http.MethodPost, webhook.URL, bytes.NewReader(event.Payload))
+	if err != nil {
+		return 0, err
+	}
+
+	request.Header.Set("Content-Type", "application/json")
+	request.Header.Set(WebhookEventHeader, event.EventType)
+	request.Header.Set(WebhookDeliveryHeader, event.ID)
+	request.Header.Set(WebhookTimestampHeader, timestamp)
+	request.Header.Set(WebhookSignatureHeader, SignWebhookPayload(webhook.Secret, timestamp, event.Payload))
+
+	response, err := s.httpClient.Do(request)
+	if err != nil {
+		return 0, err
+	}
+
+	defer response.Body.Close()
+	io.Copy(io.Discard, response.Body)
+
+	if response.StatusCode < 200 || response.StatusCode >= 300 {
+		return response.StatusCode, fmt.Errorf("webhook responded with status code %d", response.StatusCode)
+	}
+
+	return response.StatusCode, nil
+}
+
+func (s *WebhookService) backoff(attempts int) time.Duration {
+	delay := s.baseBackoff
+
+	for i := 1; i < attempts; i++ {
+		delay *= 2
+
+		if delay >= s.maxBackoff {
+			return s.maxBackoff
+		}
+	}
+
+	return delay
+}
+
+// SignWebhookPayload return signature of payload in format "sha256=<hex>".
+// Signature is HMAC-SHA256 of "<timestamp>.<payload>" with webhook secret as key.
+func SignWebhookPayload(secret string, timestamp string, payload []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp))
+	mac.Write([]byte("."))
+	mac.Write(payload)
+
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+func generateWebhookSecret() (string, error) {
+	secret := make([]byte, 32)
+
+	if _, err := rand.Read(secret); err != nil {
+		return "", err
+	}
+
+	return hex.EncodeToString(secret), nil
+}
+
+func isLinkEvent(eventType string) bool {
+	for _, event := range domain.LinkEvents {
+		if event == eventType {
+			return true
+		}
+	}
+
+	return false
+}
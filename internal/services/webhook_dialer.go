@@ -0,0 +1,88 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"strings"
+	"syscall"
+	"time"
+)
+
+// errForbiddenWebhookAddress is returned, when webhook destination is address of internal network.
+var errForbiddenWebhookAddress = errors.New("webhook destination address is not allowed")
+
+// forbiddenWebhookNets are internal networks, that are not reported by methods of net.IP:
+// shared address space of carrier-grade NAT and "this network".
+var forbiddenWebhookNets = []*net.IPNet{
+	{IP: net.IPv4(100, 64, 0, 0), Mask: net.CIDRMask(10, 32)},
+	{IP: net.IPv4(0, 0, 0, 0), Mask: net.CIDRMask(8, 32)},
+}
+
+// newWebhookHTTPClient returns http client for webhook delivery, that refuses to connect to loopback,
+// private, link-local, unspecified and carrier-grade NAT addresses. Address is checked at dial time, after host is resolved,
+// so neither DNS rebinding nor redirects can reach internal network. Proxy from environment is not used,
+// because it would be dialed instead of webhook destination.
+func newWebhookHTTPClient() *http.Client {
+	dialer := &net.Dialer{
+		Timeout: webhookRequestTimeout,
+		Control: controlWebhookDial,
+	}
+
+	return &http.Client{
+		Timeout: webhookRequestTimeout,
+		Transport: &http.Transport{
+			DialContext:         dialer.DialContext,
+			TLSHandshakeTimeout: webhookRequestTimeout,
+			MaxIdleConns:        10,
+			IdleConnTimeout:     time.Minute,
+		},
+	}
+}
+
+// controlWebhookDial is net.Dialer.Control, that rejects connections to internal addresses.
+func controlWebhookDial(network string, address string, conn syscall.RawConn) error {
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return err
+	}
+
+	ip := net.ParseIP(host)
+	if ip == nil || !isPublicWebhookIP(ip) {
+		return fmt.Errorf("%w: %s", errForbiddenWebhookAddress, host)
+	}
+
+	return nil
+}
+
+// isPublicWebhookIP reports if webhook can be delivered to ip.
+func isPublicWebhookIP(ip net.IP) bool {
+	for _, ipNet := range forbiddenWebhookNets {
+		if ipNet.Contains(ip) {
+			return false
+		}
+	}
+
+	return !ip.IsLoopback() &&
+		!ip.IsPrivate() &&
+		!ip.IsLinkLocalUnicast() &&
+		!ip.IsLinkLocalMulticast() &&
+		!ip.IsInterfaceLocalMulticast() &&
+		!ip.IsMulticast() &&
+		!ip.IsUnspecified()
+}
+
+// isForbiddenWebhookHost reports if host of webhook url is obviously internal. Hosts, that resolve
+// to internal addresses, are rejected at dial time.
+func isForbiddenWebhookHost(host string) bool {
+	host = strings.TrimSuffix(strings.ToLower(host), ".")
+
+	if host == "localhost" || strings.HasSuffix(host, ".localhost") {
+		return true
+	}
+
+	ip := net.ParseIP(host)
+
+	return ip != nil && !isPublicWebhookIP(ip)
+}
@@ -0,0 +1,473 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/MowlCoder/go-url-shortener/internal/domain"
+	servicesmocks "github.com/MowlCoder/go-url-shortener/internal/services/mocks"
+)
+
+func TestWebhookService_Subscribe(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	storage := servicesmocks.NewMockwebhookStorage(ctrl)
+	loggerInstance := servicesmocks.NewMocklogger(ctrl)
+	service := NewWebhookService(storage, loggerInstance)
+
+	type TestCase struct {
+		PrepareServiceFunc func()
+		ExpectedErr        error
+		Name               string
+		URL                string
+		Events             []string
+		ExpectedEvents     []string
+		IsError            bool
+	}
+
+	testCases := []TestCase{
+		{
+			Name:   "valid",
+			URL:    "https://hooks.example.com/shortener",
+			Events: []string{domain.EventLinkCreated},
+			PrepareServiceFunc: func() {
+				storage.
+					EXPECT().
+					SaveWebhook(gomock.Any(), gomock.Any()).
+					Return(nil)
+			},
+			ExpectedEvents: []string{domain.EventLinkCreated},
+		},
+		{
+			Name: "valid (all events)",
+			URL:  "http://hooks.example.com",
+			PrepareServiceFunc: func() {
+				storage.
+					EXPECT().
+					SaveWebhook(gomock.Any(), gomock.Any()).
+					Return(nil)
+			},
+			ExpectedEvents: domain.LinkEvents,
+		},
+		{
+			Name:        "invalid url",
+			URL:         "ftp://hooks.example.com",
+			IsError:     true,
+			ExpectedErr: domain.ErrInvalidWebhookURL,
+		},
+		{
+			Name:        "loopback url",
+			URL:         "http://127.0.0.1:8080/hook",
+			IsError:     true,
+			ExpectedErr: domain.ErrInvalidWebhookURL,
+		},
+		{
+			Name:        "localhost url",
+			URL:         "http://localhost/hook",
+			IsError:     true,
+			ExpectedErr: domain.ErrInvalidWebhookURL,
+		},
+		{
+			Name:        "metadata service url",
+			URL:         "http://169.254.169.254/latest/meta-data",
+			IsError:     true,
+			ExpectedErr: domain.ErrInvalidWebhookURL,
+		},
+		{
+			Name:        "private network url",
+			URL:         "https://[fd00::1]/hook",
+			IsError:     true,
+			ExpectedErr: domain.ErrInvalidWebhookURL,
+		},
+		{
+			Name:        "invalid event",
+			URL:         "https://hooks.example.com",
+			Events:      []string{"link.updated"},
+			IsError:     true,
+			ExpectedErr: domain.ErrInvalidWebhookEvent,
+		},
+		{
+			Name: "storage error",
+			URL:  "https://hooks.example.com",
+			PrepareServiceFunc: func() {
+				storage.
+					EXPECT().
+					SaveWebhook(gomock.Any(), gomock.Any()).
+					Return(errors.New("undefined behavior"))
+			},
+			IsError: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.Name, func(t *testing.T) {
+			if tc.PrepareServiceFunc != nil {
+				tc.PrepareServiceFunc()
+			}
+
+			webhook, err := service.Subscribe(context.Background(), "1", tc.URL, tc.Events)
+
+			if tc.IsError {
+				require.Error(t, err)
+
+				if tc.ExpectedErr != nil {
+					assert.ErrorIs(t, err, tc.ExpectedErr)
+				}
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, "1", webhook.UserID)
+				assert.Equal(t, tc.ExpectedEvents, webhook.Events)
+				assert.Len(t, webhook.Secret, 64)
+			}
+		})
+	}
+}
+
+func TestWebhookService_GetDeliveries(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	storage := servicesmocks.NewMockwebhookStorage(ctrl)
+	loggerInstance := servicesmocks.NewMocklogger(ctrl)
+	service := NewWebhookService(storage, loggerInstance)
+
+	t.Run("valid", func(t *testing.T) {
+		storage.EXPECT().GetWebhookByID(gomock.Any(), "hook").Return(&domain.Webhook{ID: "hook", UserID: "1"}, nil)
+		storage.EXPECT().GetWebhookDeliveries(gomock.Any(), "hook").Return([]domain.WebhookDelivery{{}}, nil)
+
+		deliveries, err := service.GetDeliveries(context.Background(), "hook", "1")
+		require.NoError(t, err)
+		assert.Len(t, deliveries, 1)
+	})
+
+	t.Run("another user", func(t *testing.T) {
+		storage.EXPECT().GetWebhookByID(gomock.Any(), "hook").Return(&domain.Webhook{ID: "hook", UserID: "2"}, nil)
+
+		_, err := service.GetDeliveries(context.Background(), "hook", "1")
+		assert.ErrorIs(t, err, domain.ErrWebhookNotFound)
+	})
+}
+
+func TestWebhookService_Emit(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	storage := servicesmocks.NewMockwebhookStorage(ctrl)
+	loggerInstance := servicesmocks.NewMocklogger(ctrl)
+	service := NewWebhookService(storage, loggerInstance)
+
+	links := []domain.LinkEventData{
+		{ShortURL: "1", OriginalURL: "https://url.com/1"},
+		{ShortURL: "2", OriginalURL: "https://url.com/2"},
+	}
+
+	t.Run("only subscribed webhooks", func(t *testing.T) {
+		storage.
+			EXPECT().
+			GetWebhooksByUserID(gomock.Any(), "1").
+			Return([]domain.Webhook{
+				{ID: "created", Events: []string{domain.EventLinkCreated}},
+				{ID: "deleted", Events: []string{domain.EventLinkDeleted}},
+			}, nil)
+
+		storage.
+			EXPECT().
+			SaveWebhookEvents(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(ctx context.Context, events []domain.WebhookEvent) error {
+				require.Len(t, events, 2)
+
+				for _, event := range events {
+					assert.Equal(t, "created", event.WebhookID)
+					assert.Equal(t, domain.WebhookEventPending, event.Status)
+					assert.Contains(t, string(event.Payload), `"type":"link.created"`)
+				}
+
+				return nil
+			})
+
+		service.Emit(context.Background(), domain.EventLinkCreated, "1", links)
+	})
+
+	t.Run("no subscribed webhooks", func(t *testing.T) {
+		storage.
+			EXPECT().
+			GetWebhooksByUserID(gomock.Any(), "1").
+			Return([]domain.Webhook{}, nil)
+
+		service.Emit(context.Background(), domain.EventLinkCreated, "1", links)
+	})
+
+	t.Run("storage error", func(t *testing.T) {
+		storage.
+			EXPECT().
+			GetWebhooksByUserID(gomock.Any(), "1").
+			Return(nil, errors.New("undefined behavior"))
+
+		loggerInstance.
+			EXPECT().
+			Error(gomock.Any(), gomock.Any(), gomock.Any())
+
+		service.Emit(context.Background(), domain.EventLinkCreated, "1", links)
+	})
+
+	t.Run("no links", func(t *testing.T) {
+		service.Emit(context.Background(), domain.EventLinkCreated, "1", nil)
+	})
+}
+
+func TestWebhookService_deliver(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	storage := servicesmocks.NewMockwebhookStorage(ctrl)
+	loggerInstance := servicesmocks.NewMocklogger(ctrl)
+	service := NewWebhookService(storage, loggerInstance)
+	// test server listens on loopback, that is refused by delivery client
+	service.httpClient = &http.Client{Timeout: webhookRequestTimeout}
+
+	now := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+	service.now = func() time.Time {
+		return now
+	}
+
+	payload := []byte(`{"type":"link.created"}`)
+	secret := "secret"
+
+	type TestCase struct {
+		CheckEvent         func(t *testing.T, event domain.WebhookEvent)
+		Name               string
+		ResponseStatusCode int
+		Attempts           int
+	}
+
+	testCases := []TestCase{
+		{
+			Name:               "delivered",
+			ResponseStatusCode: http.StatusOK,
+			CheckEvent: func(t *testing.T, event domain.WebhookEvent) {
+				assert.Equal(t, domain.WebhookEventDelivered, event.Status)
+				assert.Equal(t, 1, event.Attempts)
+			},
+		},
+		{
+			Name:               "retry with backoff",
+			ResponseStatusCode: http.StatusInternalServerError,
+			Attempts:           2,
+			CheckEvent: func(t *testing.T, event domain.WebhookEvent) {
+				assert.Equal(t, domain.WebhookEventPending, event.Status)
+				assert.Equal(t, 3, event.Attempts)
+				assert.Equal(t, now.Add(webhookBaseBackoff*4), event.NextAttemptAt)
+			},
+		},
+		{
+			Name:               "failed after max attempts",
+			ResponseStatusCode: http.StatusInternalServerError,
+			Attempts:           webhookMaxAttempts - 1,
+			CheckEvent: func(t *testing.T, event domain.WebhookEvent) {
+				assert.Equal(t, domain.WebhookEventFailed, event.Status)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.Name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				body, err := io.ReadAll(r.Body)
+				require.NoError(t, err)
+
+				assert.Equal(t, payload, body)
+				assert.Equal(t, domain.EventLinkCreated, r.Header.Get(WebhookEventHeader))
+				assert.Equal(
+					t,
+					SignWebhookPayload(secret, r.Header.Get(WebhookTimestampHeader), body),
+					r.Header.Get(WebhookSignatureHeader),
+				)
+
+				w.WriteHeader(tc.ResponseStatusCode)
+			}))
+			defer server.Close()
+
+			storage.
+				EXPECT().
+				GetWebhookByID(gomock.Any(), "hook").
+				Return(&domain.Webhook{ID: "hook", URL: server.URL, Secret: secret}, nil)
+
+			storage.
+				EXPECT().
+				SaveWebhookDelivery(gomock.Any(), gomock.Any()).
+				DoAndReturn(func(ctx context.Context, delivery domain.WebhookDelivery) error {
+					assert.Equal(t, tc.ResponseStatusCode, delivery.StatusCode)
+					assert.Equal(t, tc.ResponseStatusCode == http.StatusOK, delivery.Success)
+					return nil
+				})
+
+			storage.
+				EXPECT().
+				UpdateWebhookEvent(gomock.Any(), gomock.Any()).
+				DoAndReturn(func(ctx context.Context, event domain.WebhookEvent) error {
+					tc.CheckEvent(t, event)
+					return nil
+				})
+
+			err := service.deliver(context.Background(), domain.WebhookEvent{
+				ID:        "event",
+				WebhookID: "hook",
+				EventType: domain.EventLinkCreated,
+				Status:    domain.WebhookEventPending,
+				Payload:   payload,
+				Attempts:  tc.Attempts,
+			})
+			require.NoError(t, err)
+		})
+	}
+
+	t.Run("webhook deleted", func(t *testing.T) {
+		storage.
+			EXPECT().
+			GetWebhookByID(gomock.Any(), "hook").
+			Return(nil, domain.ErrWebhookNotFound)
+
+		storage.
+			EXPECT().
+			UpdateWebhookEvent(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(ctx context.Context, event domain.WebhookEvent) error {
+				assert.Equal(t, domain.WebhookEventFailed, event.Status)
+				return nil
+			})
+
+		err := service.deliver(context.Background(), domain.WebhookEvent{ID: "event", WebhookID: "hook"})
+		require.NoError(t, err)
+	})
+}
+
+func TestWebhookService_deliverToInternalAddress(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	storage := servicesmocks.NewMockwebhookStorage(ctrl)
+	loggerInstance := servicesmocks.NewMocklogger(ctrl)
+	service := NewWebhookService(storage, loggerInstance)
+
+	isCalled := false
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		isCalled = true
+	}))
+	defer server.Close()
+
+	// webhook host could resolve to loopback after subscription, e.g. by DNS rebinding
+	storage.
+		EXPECT().
+		GetWebhookByID(gomock.Any(), "hook").
+		Return(&domain.Webhook{ID: "hook", URL: server.URL, Secret: "secret"}, nil)
+
+	storage.
+		EXPECT().
+		SaveWebhookDelivery(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, delivery domain.WebhookDelivery) error {
+			assert.False(t, delivery.Success)
+			assert.Contains(t, delivery.Error, errForbiddenWebhookAddress.Error())
+			return nil
+		})
+
+	storage.
+		EXPECT().
+		UpdateWebhookEvent(gomock.Any(), gomock.Any()).
+		Return(nil)
+
+	err := service.deliver(context.Background(), domain.WebhookEvent{ID: "event", WebhookID: "hook"})
+	require.NoError(t, err)
+	assert.False(t, isCalled)
+}
+
+func TestWebhookService_deliverPending(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	storage := servicesmocks.NewMockwebhookStorage(ctrl)
+	loggerInstance := servicesmocks.NewMocklogger(ctrl)
+	service := NewWebhookService(storage, loggerInstance)
+
+	events := []domain.WebhookEvent{
+		{ID: "1", WebhookID: "broken"},
+		{ID: "2", WebhookID: "deleted"},
+	}
+
+	storage.
+		EXPECT().
+		GetPendingWebhookEvents(gomock.Any(), gomock.Any(), webhookBatchSize).
+		Return(events, nil)
+
+	storage.
+		EXPECT().
+		GetWebhookByID(gomock.Any(), "broken").
+		Return(nil, errors.New("undefined behavior"))
+
+	loggerInstance.
+		EXPECT().
+		Error("deliver webhook event", gomock.Any())
+
+	storage.
+		EXPECT().
+		GetWebhookByID(gomock.Any(), "deleted").
+		Return(nil, domain.ErrWebhookNotFound)
+
+	storage.
+		EXPECT().
+		UpdateWebhookEvent(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, event domain.WebhookEvent) error {
+			assert.Equal(t, "2", event.ID)
+			assert.Equal(t, domain.WebhookEventFailed, event.Status)
+			return nil
+		})
+
+	require.NoError(t, service.deliverPending(context.Background()))
+}
+
+func TestIsPublicWebhookIP(t *testing.T) {
+	testCases := []struct {
+		ip       string
+		expected bool
+	}{
+		{ip: "93.184.216.34", expected: true},
+		{ip: "2606:2800:220:1::248", expected: true},
+		{ip: "127.0.0.1", expected: false},
+		{ip: "::1", expected: false},
+		{ip: "10.1.2.3", expected: false},
+		{ip: "172.16.0.1", expected: false},
+		{ip: "192.168.1.1", expected: false},
+		{ip: "169.254.169.254", expected: false},
+		{ip: "fe80::1", expected: false},
+		{ip: "fd00::1", expected: false},
+		{ip: "0.0.0.0", expected: false},
+		{ip: "::", expected: false},
+		{ip: "::ffff:127.0.0.1", expected: false},
+		{ip: "100.64.0.1", expected: false},
+		{ip: "100.127.255.254", expected: false},
+		{ip: "::ffff:100.64.0.1", expected: false},
+		{ip: "100.128.0.1", expected: true},
+		{ip: "0.1.2.3", expected: false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.ip, func(t *testing.T) {
+			assert.Equal(t, tc.expected, isPublicWebhookIP(net.ParseIP(tc.ip)))
+		})
+	}
+}
+
+func TestWebhookService_backoff(t *testing.T) {
+	service := NewWebhookService(nil, nil)
+
+	assert.Equal(t, webhookBaseBackoff, service.backoff(1))
+	assert.Equal(t, webhookBaseBackoff*2, service.backoff(2))
+	assert.Equal(t, webhookBaseBackoff*8, service.backoff(4))
+	assert.Equal(t, webhookMaxBackoff, service.backoff(100))
+}
+
+func TestSignWebhookPayload(t *testing.T) {
+	signature := SignWebhookPayload("secret", "1700000000", []byte(`{}`))
+
+	assert.Equal(t, signature, SignWebhookPayload("secret", "1700000000", []byte(`{}`)))
+	assert.NotEqual(t, signature, SignWebhookPayload("another", "1700000000", []byte(`{}`)))
+	assert.NotEqual(t, signature, SignWebhookPayload("secret", "1700000001", []byte(`{}`)))
+	assert.Contains(t, signature, "sha256=")
+}
@@ -0,0 +1,127 @@
+package storage
+
+import (
+	"context"
+	"errors"
+
+	"github.com/jackc/pgx/v5"
+
+	"github.com/MowlCoder/go-url-shortener/internal/domain"
+)
+
+// SaveDeleteTask save delete task to the database.
+func (storage *DatabaseStorage) SaveDeleteTask(ctx context.Context, task domain.DeleteURLsTask) error {
+	query := `
+		INSERT INTO delete_url_tasks (id, user_id, short_urls, status, attempts, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6)
+	`
+	_, err := storage.pool.Exec(
+		ctx,
+		query,
+		task.ID, task.UserID, task.ShortURLs, task.Status, task.Attempts, task.CreatedAt,
+	)
+
+	return err
+}
+
+// GetDeleteTaskByID return delete task with given id.
+func (storage *DatabaseStorage) GetDeleteTaskByID(ctx context.Context, id string) (*domain.DeleteURLsTask, error) {
+	query := `
+		SELECT id, user_id, short_urls, status, attempts, last_error, created_at, completed_at
+		FROM delete_url_tasks
+		WHERE id = $1
+	`
+
+	task := domain.DeleteURLsTask{}
+	err := storage.pool.QueryRow(ctx, query, id).Scan(
+		&task.ID,
+		&task.UserID,
+		&task.ShortURLs,
+		&task.Status,
+		&task.Attempts,
+		&task.LastError,
+		&task.CreatedAt,
+		&task.CompletedAt,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, domain.ErrDeleteTaskNotFound
+		}
+
+		return nil, err
+	}
+
+	return &task, nil
+}
+
+// GetPendingDeleteTasks return oldest pending delete tasks.
+func (storage *DatabaseStorage) GetPendingDeleteTasks(ctx context.Context, limit int) ([]domain.DeleteURLsTask, error) {
+	query := `
+		SELECT id, user_id, short_urls, status, attempts, last_error, created_at, completed_at
+		FROM delete_url_tasks
+		WHERE status = $1
+		ORDER BY created_at
+		LIMIT $2
+	`
+
+	rows, err := storage.pool.Query(ctx, query, domain.DeleteTaskPending, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	tasks := make([]domain.DeleteURLsTask, 0)
+
+	for rows.Next() {
+		task := domain.DeleteURLsTask{}
+
+		if err := rows.Scan(
+			&task.ID,
+			&task.UserID,
+			&task.ShortURLs,
+			&task.Status,
+			&task.Attempts,
+			&task.LastError,
+			&task.CreatedAt,
+			&task.CompletedAt,
+		); err != nil {
+			return nil, err
+		}
+
+		tasks = append(tasks, task)
+	}
+
+	if rows.Err() != nil {
+		return nil, rows.Err()
+	}
+
+	return tasks, nil
+}
+
+// CountPendingDeleteTasks return count of pending delete tasks.
+func (storage *DatabaseStorage) CountPendingDeleteTasks(ctx context.Context) (int, error) {
+	query := `SELECT COUNT(*) FROM delete_url_tasks WHERE status = $1`
+
+	var count int
+	if err := storage.pool.QueryRow(ctx, query, domain.DeleteTaskPending).Scan(&count); err != nil {
+		return 0, err
+	}
+
+	return count, nil
+}
+
+// FailDeleteTasks increment attempts of delete tasks and save reason of failure. Tasks stay pending
+// until they fail domain.DeleteTaskMaxAttempts times, after that they get failed status.
+func (storage *DatabaseStorage) FailDeleteTasks(ctx context.Context, ids []string, reason string) error {
+	query := `
+		UPDATE delete_url_tasks
+		SET attempts = attempts + 1,
+			last_error = $1,
+			status = CASE WHEN attempts + 1 >= $3 THEN $4 ELSE status END
+		WHERE id = ANY($2)
+	`
+	_, err := storage.pool.Exec(ctx, query, reason, ids, domain.DeleteTaskMaxAttempts, domain.DeleteTaskFailed)
+
+	return err
+}
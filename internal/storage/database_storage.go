@@ -5,6 +5,9 @@ import (
 	"database/sql"
 	"embed"
 	"errors"
+	"fmt"
+	"io/fs"
+	"time"
 
 	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgconn"
@@ -48,7 +51,8 @@ func NewDatabaseStorage(databaseDNS string) (*DatabaseStorage, error) {
 // GetByShortURL return model where short url equal given short url.
 func (storage *DatabaseStorage) GetByShortURL(ctx context.Context, shortURL string) (*domain.ShortenedURL, error) {
 	query := `
-		SELECT id, short_url, original_url, is_deleted
+		SELECT id, short_url, user_id, original_url, is_deleted, clicks, tags, expires_at,
+			redirect_type, created_at, utm, query_passthrough, redirect_rules
 		FROM shorten_url
 		WHERE short_url = $1
 	`
@@ -60,7 +64,25 @@ func (storage *DatabaseStorage) GetByShortURL(ctx context.Context, shortURL stri
 
 	shortenedURL := domain.ShortenedURL{}
 
-	if err := row.Scan(&shortenedURL.ID, &shortenedURL.ShortURL, &shortenedURL.OriginalURL, &shortenedURL.IsDeleted); err != nil {
+	if err := row.Scan(
+		&shortenedURL.ID,
+		&shortenedURL.ShortURL,
+		&shortenedURL.UserID,
+		&shortenedURL.OriginalURL,
+		&shortenedURL.IsDeleted,
+		&shortenedURL.Clicks,
+		&shortenedURL.Tags,
+		&shortenedURL.ExpiresAt,
+		&shortenedURL.RedirectType,
+		&shortenedURL.CreatedAt,
+		&shortenedURL.UTM,
+		&shortenedURL.QueryPassthrough,
+		&shortenedURL.RedirectRules,
+	); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, domain.ErrURLNotFound
+		}
+
 		return nil, err
 	}
 
@@ -71,7 +93,8 @@ func (storage *DatabaseStorage) GetByShortURL(ctx context.Context, shortURL stri
 func (storage *DatabaseStorage) GetURLsByUserID(ctx context.Context, userID string) ([]domain.ShortenedURL, error) {
 	urls := make([]domain.ShortenedURL, 0)
 	query := `
-		SELECT id, short_url, user_id, original_url
+		SELECT id, short_url, user_id, original_url, is_deleted, clicks, tags, expires_at,
+			redirect_type, created_at, utm, query_passthrough, redirect_rules
 		FROM shorten_url
 		WHERE user_id = $1
 	`
@@ -89,7 +112,21 @@ func (storage *DatabaseStorage) GetURLsByUserID(ctx context.Context, userID stri
 	for rows.Next() {
 		shortenedURL := domain.ShortenedURL{}
 
-		if err := rows.Scan(&shortenedURL.ID, &shortenedURL.ShortURL, &shortenedURL.UserID, &shortenedURL.OriginalURL); err != nil {
+		if err := rows.Scan(
+			&shortenedURL.ID,
+			&shortenedURL.ShortURL,
+			&shortenedURL.UserID,
+			&shortenedURL.OriginalURL,
+			&shortenedURL.IsDeleted,
+			&shortenedURL.Clicks,
+			&shortenedURL.Tags,
+			&shortenedURL.ExpiresAt,
+			&shortenedURL.RedirectType,
+			&shortenedURL.CreatedAt,
+			&shortenedURL.UTM,
+			&shortenedURL.QueryPassthrough,
+			&shortenedURL.RedirectRules,
+		); err != nil {
 			return nil, err
 		}
 
@@ -99,22 +136,194 @@ func (storage *DatabaseStorage) GetURLsByUserID(ctx context.Context, userID stri
 	return urls, nil
 }
 
+// GetURLsAfter return up to limit urls with short url greater than given one, ordered by short url.
+// Use empty afterShortURL to get first page.
+func (storage *DatabaseStorage) GetURLsAfter(ctx context.Context, afterShortURL string, limit int) ([]domain.ShortenedURL, error) {
+	query := `
+		SELECT id, short_url, user_id, original_url, is_deleted, clicks, tags, expires_at,
+			redirect_type, created_at, utm, query_passthrough, redirect_rules
+		FROM shorten_url
+		WHERE short_url > $1
+		ORDER BY short_url
+		LIMIT $2
+	`
+
+	rows, err := storage.pool.Query(ctx, query, afterShortURL, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	urls := make([]domain.ShortenedURL, 0, limit)
+
+	for rows.Next() {
+		url := domain.ShortenedURL{}
+
+		if err := rows.Scan(
+			&url.ID,
+			&url.ShortURL,
+			&url.UserID,
+			&url.OriginalURL,
+			&url.IsDeleted,
+			&url.Clicks,
+			&url.Tags,
+			&url.ExpiresAt,
+			&url.RedirectType,
+			&url.CreatedAt,
+			&url.UTM,
+			&url.QueryPassthrough,
+			&url.RedirectRules,
+		); err != nil {
+			return nil, err
+		}
+
+		urls = append(urls, url)
+	}
+
+	return urls, rows.Err()
+}
+
+// GetURLsByUserIDAfter return up to limit urls of user with short url greater than given one, ordered by short url.
+// Use empty afterShortURL to get first page.
+func (storage *DatabaseStorage) GetURLsByUserIDAfter(
+	ctx context.Context,
+	userID string,
+	afterShortURL string,
+	limit int,
+) ([]domain.ShortenedURL, error) {
+	query := `
+		SELECT id, short_url, user_id, original_url, is_deleted, clicks, tags, expires_at,
+			redirect_type, created_at, utm, query_passthrough, redirect_rules
+		FROM shorten_url
+		WHERE user_id = $1 AND short_url > $2
+		ORDER BY short_url
+		LIMIT $3
+	`
+
+	rows, err := storage.pool.Query(ctx, query, userID, afterShortURL, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	urls := make([]domain.ShortenedURL, 0, limit)
+
+	for rows.Next() {
+		url := domain.ShortenedURL{}
+
+		if err := rows.Scan(
+			&url.ID,
+			&url.ShortURL,
+			&url.UserID,
+			&url.OriginalURL,
+			&url.IsDeleted,
+			&url.Clicks,
+			&url.Tags,
+			&url.ExpiresAt,
+			&url.RedirectType,
+			&url.CreatedAt,
+			&url.UTM,
+			&url.QueryPassthrough,
+			&url.RedirectRules,
+		); err != nil {
+			return nil, err
+		}
+
+		urls = append(urls, url)
+	}
+
+	return urls, rows.Err()
+}
+
+// ImportURLs save urls as is, keeping user id, deleted flag and clicks.
+// Urls with already existing short url or original url are skipped. Return short urls of skipped urls.
+func (storage *DatabaseStorage) ImportURLs(ctx context.Context, urls []domain.ShortenedURL) ([]string, error) {
+	tx, err := storage.pool.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	defer tx.Rollback(ctx)
+
+	batch := &pgx.Batch{}
+	query := `
+		INSERT INTO shorten_url (
+			short_url, original_url, user_id, is_deleted, clicks, tags, expires_at, redirect_type, created_at, utm, query_passthrough,
+			redirect_rules
+		)
+		VALUES ($1, $2, $3, $4, $5, COALESCE($6::text[], '{}'), $7, $8, $9, $10, $11, $12)
+		ON CONFLICT DO NOTHING
+	`
+
+	for _, url := range urls {
+		batch.Queue(
+			query,
+			url.ShortURL, url.OriginalURL, url.UserID, url.IsDeleted, url.Clicks, url.Tags, url.ExpiresAt,
+			url.RedirectType, url.CreatedAt, url.UTM, url.QueryPassthrough, url.RedirectRules,
+		)
+	}
+
+	batchResult := tx.SendBatch(ctx, batch)
+	skipped := make([]string, 0)
+
+	for _, url := range urls {
+		tag, err := batchResult.Exec()
+		if err != nil {
+			batchResult.Close()
+			return nil, err
+		}
+
+		if tag.RowsAffected() == 0 {
+			skipped = append(skipped, url.ShortURL)
+		}
+	}
+
+	if err := batchResult.Close(); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, err
+	}
+
+	return skipped, nil
+}
+
 // SaveURL save short url to the database.
 func (storage *DatabaseStorage) SaveURL(ctx context.Context, dto domain.SaveShortURLDto) (*domain.ShortenedURL, error) {
 	query := `
-		INSERT INTO shorten_url (short_url, original_url, user_id) VALUES ($1, $2, $3)
+		INSERT INTO shorten_url (
+			short_url, original_url, user_id, tags, expires_at, redirect_type, utm, query_passthrough, redirect_rules
+		)
+		VALUES ($1, $2, $3, COALESCE($4::text[], '{}'), $5, $6, $7, $8, $9)
 		ON CONFLICT (original_url) DO UPDATE SET original_url = EXCLUDED.original_url
-		RETURNING id, short_url, user_id, original_url;
+		RETURNING id, short_url, user_id, original_url, tags, expires_at, redirect_type, created_at, utm,
+			query_passthrough, redirect_rules;
 	`
 	row := storage.pool.QueryRow(
 		ctx,
 		query,
-		dto.ShortURL, dto.OriginalURL, dto.UserID,
+		dto.ShortURL, dto.OriginalURL, dto.UserID, dto.Tags, dto.ExpiresAt, dto.RedirectType, dto.UTM, dto.QueryPassthrough,
+		dto.RedirectRules,
 	)
 
 	shortenedURL := domain.ShortenedURL{}
 
-	if err := row.Scan(&shortenedURL.ID, &shortenedURL.ShortURL, &shortenedURL.UserID, &shortenedURL.OriginalURL); err != nil {
+	if err := row.Scan(
+		&shortenedURL.ID,
+		&shortenedURL.ShortURL,
+		&shortenedURL.UserID,
+		&shortenedURL.OriginalURL,
+		&shortenedURL.Tags,
+		&shortenedURL.ExpiresAt,
+		&shortenedURL.RedirectType,
+		&shortenedURL.CreatedAt,
+		&shortenedURL.UTM,
+		&shortenedURL.QueryPassthrough,
+		&shortenedURL.RedirectRules,
+	); err != nil {
 		var pgErr *pgconn.PgError
 
 		if errors.As(err, &pgErr) && pgErr.Code == PgUniqueIndexErrorCode {
@@ -144,15 +353,18 @@ func (storage *DatabaseStorage) SaveSeveralURL(ctx context.Context, dtos []domai
 	batch := &pgx.Batch{}
 	originalURLs := make([]string, 0, len(dtos))
 	query := `
-		INSERT INTO shorten_url (short_url, original_url, user_id)
-		VALUES ($1, $2, $3)
+		INSERT INTO shorten_url (
+			short_url, original_url, user_id, tags, expires_at, redirect_type, utm, query_passthrough, redirect_rules
+		)
+		VALUES ($1, $2, $3, COALESCE($4::text[], '{}'), $5, $6, $7, $8, $9)
 		ON CONFLICT (original_url) DO NOTHING
 	`
 
 	for _, dto := range dtos {
 		batch.Queue(
 			query,
-			dto.ShortURL, dto.OriginalURL, dto.UserID,
+			dto.ShortURL, dto.OriginalURL, dto.UserID, dto.Tags, dto.ExpiresAt, dto.RedirectType, dto.UTM, dto.QueryPassthrough,
+			dto.RedirectRules,
 		)
 		originalURLs = append(originalURLs, dto.OriginalURL)
 	}
@@ -164,7 +376,8 @@ func (storage *DatabaseStorage) SaveSeveralURL(ctx context.Context, dtos []domai
 	}
 
 	query = `
-		SELECT id, short_url, user_id, original_url
+		SELECT id, short_url, user_id, original_url, tags, expires_at, redirect_type, created_at, utm,
+			query_passthrough, redirect_rules
 		FROM shorten_url
 		WHERE original_url = ANY($1)
 	`
@@ -187,7 +400,19 @@ func (storage *DatabaseStorage) SaveSeveralURL(ctx context.Context, dtos []domai
 	for rows.Next() {
 		shortenedURL := domain.ShortenedURL{}
 
-		if err := rows.Scan(&shortenedURL.ID, &shortenedURL.ShortURL, &shortenedURL.UserID, &shortenedURL.OriginalURL); err != nil {
+		if err := rows.Scan(
+			&shortenedURL.ID,
+			&shortenedURL.ShortURL,
+			&shortenedURL.UserID,
+			&shortenedURL.OriginalURL,
+			&shortenedURL.Tags,
+			&shortenedURL.ExpiresAt,
+			&shortenedURL.RedirectType,
+			&shortenedURL.CreatedAt,
+			&shortenedURL.UTM,
+			&shortenedURL.QueryPassthrough,
+			&shortenedURL.RedirectRules,
+		); err != nil {
 			return nil, err
 		}
 
@@ -216,9 +441,17 @@ func (storage *DatabaseStorage) DeleteByShortURLs(ctx context.Context, shortURLs
 	return err
 }
 
-// DoDeleteURLTasks execute delete tasks and save result to the database.
+// DoDeleteURLTasks execute delete tasks, save result to the database and mark tasks as completed in one transaction.
 func (storage *DatabaseStorage) DoDeleteURLTasks(ctx context.Context, tasks []domain.DeleteURLsTask) error {
+	tx, err := storage.pool.Begin(ctx)
+	if err != nil {
+		return err
+	}
+
+	defer tx.Rollback(ctx)
+
 	batch := &pgx.Batch{}
+	taskIDs := make([]string, 0, len(tasks))
 
 	query := `
 		UPDATE shorten_url
@@ -231,10 +464,71 @@ func (storage *DatabaseStorage) DoDeleteURLTasks(ctx context.Context, tasks []do
 			query,
 			task.UserID, task.ShortURLs,
 		)
+
+		if task.ID != "" {
+			taskIDs = append(taskIDs, task.ID)
+		}
+	}
+
+	batch.Queue(
+		`
+			UPDATE delete_url_tasks
+			SET status = $1, attempts = attempts + 1, last_error = '', completed_at = NOW()
+			WHERE id = ANY($2)
+		`,
+		domain.DeleteTaskCompleted, taskIDs,
+	)
+	batch.Queue(
+		`DELETE FROM delete_url_tasks WHERE status = $1 AND completed_at < $2`,
+		domain.DeleteTaskCompleted, time.Now().UTC().Add(-completedDeleteTaskRetention),
+	)
+
+	if err := tx.SendBatch(ctx, batch).Close(); err != nil {
+		return err
+	}
+
+	return tx.Commit(ctx)
+}
+
+// SetRedirectRules replace redirect rules of user url in the database.
+func (storage *DatabaseStorage) SetRedirectRules(ctx context.Context, shortURL string, userID string, rules []domain.RedirectRule) error {
+	query := `
+		UPDATE shorten_url
+		SET redirect_rules = $1
+		WHERE short_url = $2 AND user_id = $3
+	`
+
+	tag, err := storage.pool.Exec(ctx, query, rules, shortURL, userID)
+	if err != nil {
+		return err
+	}
+
+	if tag.RowsAffected() == 0 {
+		return domain.ErrURLNotFound
+	}
+
+	return nil
+}
+
+// IncrementClicks add count to clicks counter of short url and return new value.
+func (storage *DatabaseStorage) IncrementClicks(ctx context.Context, shortURL string, count int) (int, error) {
+	query := `
+		UPDATE shorten_url
+		SET clicks = clicks + $2
+		WHERE short_url = $1
+		RETURNING clicks
+	`
+
+	var clicks int
+	if err := storage.pool.QueryRow(ctx, query, shortURL, count).Scan(&clicks); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return 0, domain.ErrURLNotFound
+		}
+
+		return 0, err
 	}
 
-	batchResult := storage.pool.SendBatch(ctx, batch)
-	return batchResult.Close()
+	return clicks, nil
 }
 
 // GetInternalStats get internal stats for metrics.
@@ -258,6 +552,33 @@ func (storage *DatabaseStorage) Ping(ctx context.Context) error {
 	return storage.pool.Ping(ctx)
 }
 
+// CheckMigrations check if all embedded migrations are applied to the database.
+func (storage *DatabaseStorage) CheckMigrations(ctx context.Context) error {
+	expectedVersion, err := latestMigrationVersion()
+	if err != nil {
+		return err
+	}
+
+	var version int64
+	query := `SELECT COALESCE(MAX(version_id), 0) FROM goose_db_version WHERE is_applied`
+
+	if err := storage.pool.QueryRow(ctx, query).Scan(&version); err != nil {
+		return err
+	}
+
+	if version < expectedVersion {
+		return fmt.Errorf("%w: current version %d, expected %d", domain.ErrMigrationsPending, version, expectedVersion)
+	}
+
+	return nil
+}
+
+// Close closes all connections of pool, waiting for acquired connections to be released.
+func (storage *DatabaseStorage) Close() error {
+	storage.pool.Close()
+	return nil
+}
+
 func (storage *DatabaseStorage) runMigrations(databaseDNS string) error {
 	db, err := sql.Open("pgx", databaseDNS)
 	if err != nil {
@@ -278,3 +599,25 @@ func (storage *DatabaseStorage) runMigrations(databaseDNS string) error {
 
 	return nil
 }
+
+func latestMigrationVersion() (int64, error) {
+	entries, err := fs.ReadDir(embedMigrations, "migrations")
+	if err != nil {
+		return 0, err
+	}
+
+	var latestVersion int64
+
+	for _, entry := range entries {
+		version, err := goose.NumericComponent(entry.Name())
+		if err != nil {
+			return 0, err
+		}
+
+		if version > latestVersion {
+			latestVersion = version
+		}
+	}
+
+	return latestVersion, nil
+}
@@ -0,0 +1,265 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+
+	"github.com/MowlCoder/go-url-shortener/internal/domain"
+)
+
+// SaveWebhook save webhook subscription to the database.
+func (storage *DatabaseStorage) SaveWebhook(ctx context.Context, webhook domain.Webhook) error {
+	query := `
+		INSERT INTO webhooks (id, user_id, url, secret, events, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6)
+	`
+	_, err := storage.pool.Exec(
+		ctx,
+		query,
+		webhook.ID, webhook.UserID, webhook.URL, webhook.Secret, webhook.Events, webhook.CreatedAt,
+	)
+
+	return err
+}
+
+// GetWebhookByID return webhook with given id.
+func (storage *DatabaseStorage) GetWebhookByID(ctx context.Context, id string) (*domain.Webhook, error) {
+	query := `
+		SELECT id, user_id, url, secret, events, created_at
+		FROM webhooks
+		WHERE id = $1
+	`
+
+	webhook := domain.Webhook{}
+	err := storage.pool.QueryRow(ctx, query, id).Scan(
+		&webhook.ID,
+		&webhook.UserID,
+		&webhook.URL,
+		&webhook.Secret,
+		&webhook.Events,
+		&webhook.CreatedAt,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, domain.ErrWebhookNotFound
+		}
+
+		return nil, err
+	}
+
+	return &webhook, nil
+}
+
+// GetWebhooksByUserID return list of webhooks where user id equal given user id.
+func (storage *DatabaseStorage) GetWebhooksByUserID(ctx context.Context, userID string) ([]domain.Webhook, error) {
+	query := `
+		SELECT id, user_id, url, secret, events, created_at
+		FROM webhooks
+		WHERE user_id = $1
+		ORDER BY created_at
+	`
+
+	rows, err := storage.pool.Query(ctx, query, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	webhooks := make([]domain.Webhook, 0)
+
+	for rows.Next() {
+		webhook := domain.Webhook{}
+
+		if err := rows.Scan(
+			&webhook.ID,
+			&webhook.UserID,
+			&webhook.URL,
+			&webhook.Secret,
+			&webhook.Events,
+			&webhook.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+
+		webhooks = append(webhooks, webhook)
+	}
+
+	if rows.Err() != nil {
+		return nil, rows.Err()
+	}
+
+	return webhooks, nil
+}
+
+// DeleteWebhook delete webhook of given user. Pending events of webhook become failed.
+func (storage *DatabaseStorage) DeleteWebhook(ctx context.Context, id string, userID string) error {
+	tx, err := storage.pool.Begin(ctx)
+	if err != nil {
+		return err
+	}
+
+	defer tx.Rollback(ctx)
+
+	tag, err := tx.Exec(ctx, `DELETE FROM webhooks WHERE id = $1 AND user_id = $2`, id, userID)
+	if err != nil {
+		return err
+	}
+
+	if tag.RowsAffected() == 0 {
+		return domain.ErrWebhookNotFound
+	}
+
+	query := `
+		UPDATE webhook_events
+		SET status = $1
+		WHERE webhook_id = $2 AND status = $3
+	`
+	if _, err := tx.Exec(ctx, query, domain.WebhookEventFailed, id, domain.WebhookEventPending); err != nil {
+		return err
+	}
+
+	return tx.Commit(ctx)
+}
+
+// SaveWebhookEvents save events to the outbox table.
+func (storage *DatabaseStorage) SaveWebhookEvents(ctx context.Context, events []domain.WebhookEvent) error {
+	batch := &pgx.Batch{}
+	query := `
+		INSERT INTO webhook_events (id, webhook_id, event_type, payload, status, attempts, next_attempt_at, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+	`
+
+	for _, event := range events {
+		batch.Queue(
+			query,
+			event.ID, event.WebhookID, event.EventType, []byte(event.Payload),
+			event.Status, event.Attempts, event.NextAttemptAt, event.CreatedAt,
+		)
+	}
+
+	return storage.pool.SendBatch(ctx, batch).Close()
+}
+
+// GetPendingWebhookEvents return pending events which next attempt time is already come.
+func (storage *DatabaseStorage) GetPendingWebhookEvents(ctx context.Context, now time.Time, limit int) ([]domain.WebhookEvent, error) {
+	query := `
+		SELECT id, webhook_id, event_type, payload, status, attempts, next_attempt_at, created_at
+		FROM webhook_events
+		WHERE status = $1 AND next_attempt_at <= $2
+		ORDER BY next_attempt_at
+		LIMIT $3
+	`
+
+	rows, err := storage.pool.Query(ctx, query, domain.WebhookEventPending, now, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	events := make([]domain.WebhookEvent, 0)
+
+	for rows.Next() {
+		event := domain.WebhookEvent{}
+
+		if err := rows.Scan(
+			&event.ID,
+			&event.WebhookID,
+			&event.EventType,
+			&event.Payload,
+			&event.Status,
+			&event.Attempts,
+			&event.NextAttemptAt,
+			&event.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+
+		events = append(events, event)
+	}
+
+	if rows.Err() != nil {
+		return nil, rows.Err()
+	}
+
+	return events, nil
+}
+
+// UpdateWebhookEvent update status, attempts and next attempt time of event.
+func (storage *DatabaseStorage) UpdateWebhookEvent(ctx context.Context, event domain.WebhookEvent) error {
+	query := `
+		UPDATE webhook_events
+		SET status = $1, attempts = $2, next_attempt_at = $3
+		WHERE id = $4
+	`
+	_, err := storage.pool.Exec(ctx, query, event.Status, event.Attempts, event.NextAttemptAt, event.ID)
+
+	return err
+}
+
+// SaveWebhookDelivery save delivery attempt to the log table.
+func (storage *DatabaseStorage) SaveWebhookDelivery(ctx context.Context, delivery domain.WebhookDelivery) error {
+	query := `
+		INSERT INTO webhook_deliveries
+		(id, webhook_id, event_id, event_type, attempt, status_code, success, error, duration_ms, attempted_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+	`
+	_, err := storage.pool.Exec(
+		ctx,
+		query,
+		delivery.ID, delivery.WebhookID, delivery.EventID, delivery.EventType, delivery.Attempt,
+		delivery.StatusCode, delivery.Success, delivery.Error, delivery.DurationMs, delivery.AttemptedAt,
+	)
+
+	return err
+}
+
+// GetWebhookDeliveries return delivery log of given webhook.
+func (storage *DatabaseStorage) GetWebhookDeliveries(ctx context.Context, webhookID string) ([]domain.WebhookDelivery, error) {
+	query := `
+		SELECT id, webhook_id, event_id, event_type, attempt, status_code, success, error, duration_ms, attempted_at
+		FROM webhook_deliveries
+		WHERE webhook_id = $1
+		ORDER BY attempted_at
+	`
+
+	rows, err := storage.pool.Query(ctx, query, webhookID)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	deliveries := make([]domain.WebhookDelivery, 0)
+
+	for rows.Next() {
+		delivery := domain.WebhookDelivery{}
+
+		if err := rows.Scan(
+			&delivery.ID,
+			&delivery.WebhookID,
+			&delivery.EventID,
+			&delivery.EventType,
+			&delivery.Attempt,
+			&delivery.StatusCode,
+			&delivery.Success,
+			&delivery.Error,
+			&delivery.DurationMs,
+			&delivery.AttemptedAt,
+		); err != nil {
+			return nil, err
+		}
+
+		deliveries = append(deliveries, delivery)
+	}
+
+	if rows.Err() != nil {
+		return nil, rows.Err()
+	}
+
+	return deliveries, nil
+}
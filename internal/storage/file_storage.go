@@ -4,22 +4,31 @@ import (
 	"context"
 	"encoding/json"
 	"os"
+	"path/filepath"
+	"sync"
+	"time"
 
 	"github.com/MowlCoder/go-url-shortener/internal/domain"
 )
 
-// FileStorage is storage that store all information in file on disk.
+// FileStorage is storage that store all information in file on disk. It is safe for concurrent use.
 type FileStorage struct {
+	*memoryWebhookStorage
+	*memoryDeleteTaskStorage
+
 	structure     map[string]domain.ShortenedURL
 	file          *os.File
+	mx            sync.RWMutex
 	savingChanges bool
 }
 
 // NewFileStorage create file storage with file at given path.
 func NewFileStorage(fileStoragePath string) (*FileStorage, error) {
 	storage := FileStorage{
-		structure:     make(map[string]domain.ShortenedURL),
-		savingChanges: false,
+		memoryWebhookStorage:    newMemoryWebhookStorage(),
+		memoryDeleteTaskStorage: newMemoryDeleteTaskStorage(),
+		structure:               make(map[string]domain.ShortenedURL),
+		savingChanges:           false,
 	}
 
 	if fileStoragePath != "" {
@@ -31,6 +40,45 @@ func NewFileStorage(fileStoragePath string) (*FileStorage, error) {
 
 	if storage.savingChanges {
 		storage.parseFromFile()
+
+		webhooksPath := webhooksFilePath(fileStoragePath)
+		if rawState, err := os.ReadFile(webhooksPath); err == nil {
+			storage.memoryWebhookStorage.load(rawState)
+		}
+
+		storage.memoryWebhookStorage.onChange = func(rawState []byte) error {
+			return writeFileAtomic(webhooksPath, rawState)
+		}
+
+		deleteTasksPath := deleteTasksFilePath(fileStoragePath)
+		if rawTasks, err := os.ReadFile(deleteTasksPath); err == nil {
+			storage.memoryDeleteTaskStorage.load(rawTasks)
+		}
+
+		storage.memoryDeleteTaskStorage.onChange = func(rawTasks []byte) error {
+			return writeFileAtomic(deleteTasksPath, rawTasks)
+		}
+	}
+
+	return &storage, nil
+}
+
+// NewReadOnlyFileStorage create file storage with urls from existing file at given path.
+// Changes are kept only in memory, file is never written, even on Close.
+func NewReadOnlyFileStorage(fileStoragePath string) (*FileStorage, error) {
+	storage := FileStorage{
+		memoryWebhookStorage:    newMemoryWebhookStorage(),
+		memoryDeleteTaskStorage: newMemoryDeleteTaskStorage(),
+		structure:               make(map[string]domain.ShortenedURL),
+	}
+
+	b, err := os.ReadFile(fileStoragePath)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := json.Unmarshal(b, &storage.structure); err != nil {
+		return nil, err
 	}
 
 	return &storage, nil
@@ -38,6 +86,9 @@ func NewFileStorage(fileStoragePath string) (*FileStorage, error) {
 
 // GetByShortURL return model where short url equal given short url.
 func (storage *FileStorage) GetByShortURL(ctx context.Context, shortURL string) (*domain.ShortenedURL, error) {
+	storage.mx.RLock()
+	defer storage.mx.RUnlock()
+
 	if url, ok := storage.structure[shortURL]; ok {
 		return &url, nil
 	}
@@ -47,6 +98,9 @@ func (storage *FileStorage) GetByShortURL(ctx context.Context, shortURL string)
 
 // GetURLsByUserID return list of models where user id equal given user id.
 func (storage *FileStorage) GetURLsByUserID(ctx context.Context, userID string) ([]domain.ShortenedURL, error) {
+	storage.mx.RLock()
+	defer storage.mx.RUnlock()
+
 	urls := make([]domain.ShortenedURL, 0)
 
 	for _, value := range storage.structure {
@@ -58,8 +112,55 @@ func (storage *FileStorage) GetURLsByUserID(ctx context.Context, userID string)
 	return urls, nil
 }
 
+// GetURLsAfter return up to limit urls with short url greater than given one, ordered by short url.
+// Use empty afterShortURL to get first page.
+func (storage *FileStorage) GetURLsAfter(ctx context.Context, afterShortURL string, limit int) ([]domain.ShortenedURL, error) {
+	storage.mx.RLock()
+	defer storage.mx.RUnlock()
+
+	return urlsAfter(storage.structure, afterShortURL, limit), nil
+}
+
+// GetURLsByUserIDAfter return up to limit urls of user with short url greater than given one, ordered by short url.
+// Use empty afterShortURL to get first page.
+func (storage *FileStorage) GetURLsByUserIDAfter(
+	ctx context.Context,
+	userID string,
+	afterShortURL string,
+	limit int,
+) ([]domain.ShortenedURL, error) {
+	storage.mx.RLock()
+	defer storage.mx.RUnlock()
+
+	return userURLsAfter(storage.structure, userID, afterShortURL, limit), nil
+}
+
+// ImportURLs save urls as is, keeping user id, deleted flag and clicks.
+// Urls with already existing short url or original url are skipped. Return short urls of skipped urls.
+func (storage *FileStorage) ImportURLs(ctx context.Context, urls []domain.ShortenedURL) ([]string, error) {
+	storage.mx.Lock()
+	defer storage.mx.Unlock()
+
+	skipped := importURLs(storage.structure, urls)
+
+	if len(skipped) < len(urls) && storage.savingChanges {
+		if err := storage.saveToFile(); err != nil {
+			return nil, err
+		}
+	}
+
+	return skipped, nil
+}
+
 // FindByOriginalURL return model where original url equal given original url.
 func (storage *FileStorage) FindByOriginalURL(ctx context.Context, originalURL string) (*domain.ShortenedURL, error) {
+	storage.mx.RLock()
+	defer storage.mx.RUnlock()
+
+	return storage.findByOriginalURL(originalURL)
+}
+
+func (storage *FileStorage) findByOriginalURL(originalURL string) (*domain.ShortenedURL, error) {
 	for _, value := range storage.structure {
 		if value.OriginalURL == originalURL {
 			return &value, nil
@@ -71,21 +172,35 @@ func (storage *FileStorage) FindByOriginalURL(ctx context.Context, originalURL s
 
 // SaveURL save short url to the file on disk.
 func (storage *FileStorage) SaveURL(ctx context.Context, dto domain.SaveShortURLDto) (*domain.ShortenedURL, error) {
-	shortenedURL, err := storage.FindByOriginalURL(ctx, dto.OriginalURL)
+	storage.mx.Lock()
+	defer storage.mx.Unlock()
+
+	shortenedURL, err := storage.findByOriginalURL(dto.OriginalURL)
 
 	if err == nil {
 		return shortenedURL, domain.ErrURLConflict
 	}
 
+	createdAt := time.Now().UTC()
 	shortenedURL = &domain.ShortenedURL{
-		ID:          len(storage.structure) + 1,
-		ShortURL:    dto.ShortURL,
-		OriginalURL: dto.OriginalURL,
+		ID:               len(storage.structure) + 1,
+		ShortURL:         dto.ShortURL,
+		OriginalURL:      dto.OriginalURL,
+		UserID:           dto.UserID,
+		Tags:             dto.Tags,
+		ExpiresAt:        dto.ExpiresAt,
+		RedirectType:     dto.RedirectType,
+		UTM:              dto.UTM,
+		QueryPassthrough: dto.QueryPassthrough,
+		RedirectRules:    dto.RedirectRules,
+		CreatedAt:        &createdAt,
 	}
 	storage.structure[dto.ShortURL] = *shortenedURL
 
 	if storage.savingChanges {
-		storage.saveToFile()
+		if err := storage.saveToFile(); err != nil {
+			return nil, err
+		}
 	}
 
 	return shortenedURL, nil
@@ -93,16 +208,28 @@ func (storage *FileStorage) SaveURL(ctx context.Context, dto domain.SaveShortURL
 
 // SaveSeveralURL save several short url to the file on disk.
 func (storage *FileStorage) SaveSeveralURL(ctx context.Context, dtos []domain.SaveShortURLDto) ([]domain.ShortenedURL, error) {
+	storage.mx.Lock()
+	defer storage.mx.Unlock()
+
 	shortenedURLs := make([]domain.ShortenedURL, 0, len(dtos))
 
 	for _, dto := range dtos {
-		shortenedURL, err := storage.FindByOriginalURL(ctx, dto.OriginalURL)
+		shortenedURL, err := storage.findByOriginalURL(dto.OriginalURL)
 
 		if err != nil {
+			createdAt := time.Now().UTC()
 			shortenedURL = &domain.ShortenedURL{
-				ID:          len(storage.structure) + 1,
-				ShortURL:    dto.ShortURL,
-				OriginalURL: dto.OriginalURL,
+				ID:               len(storage.structure) + 1,
+				ShortURL:         dto.ShortURL,
+				OriginalURL:      dto.OriginalURL,
+				UserID:           dto.UserID,
+				Tags:             dto.Tags,
+				ExpiresAt:        dto.ExpiresAt,
+				RedirectType:     dto.RedirectType,
+				UTM:              dto.UTM,
+				QueryPassthrough: dto.QueryPassthrough,
+				RedirectRules:    dto.RedirectRules,
+				CreatedAt:        &createdAt,
 			}
 
 			storage.structure[dto.ShortURL] = *shortenedURL
@@ -112,7 +239,9 @@ func (storage *FileStorage) SaveSeveralURL(ctx context.Context, dtos []domain.Sa
 	}
 
 	if storage.savingChanges {
-		storage.saveToFile()
+		if err := storage.saveToFile(); err != nil {
+			return nil, err
+		}
 	}
 
 	return shortenedURLs, nil
@@ -120,6 +249,9 @@ func (storage *FileStorage) SaveSeveralURL(ctx context.Context, dtos []domain.Sa
 
 // DeleteByShortURLs delete short urls from the file on disk.
 func (storage *FileStorage) DeleteByShortURLs(ctx context.Context, shortURLs []string, userID string) error {
+	storage.mx.Lock()
+	defer storage.mx.Unlock()
+
 	for _, shortURL := range shortURLs {
 		shortenedURL := storage.structure[shortURL]
 
@@ -132,19 +264,31 @@ func (storage *FileStorage) DeleteByShortURLs(ctx context.Context, shortURLs []s
 	}
 
 	if storage.savingChanges {
-		storage.saveToFile()
+		return storage.saveToFile()
 	}
 
 	return nil
 }
 
-// DoDeleteURLTasks execute delete tasks and save result to file.
+// DoDeleteURLTasks execute delete tasks, save result to file and mark tasks as completed.
+// Tasks are marked as completed only after urls file is saved, so tasks are replayed after crash.
 func (storage *FileStorage) DoDeleteURLTasks(ctx context.Context, tasks []domain.DeleteURLsTask) error {
+	if err := storage.deleteTaskURLs(tasks); err != nil {
+		return err
+	}
+
+	return storage.completeDeleteTasks(tasks, time.Now().UTC())
+}
+
+func (storage *FileStorage) deleteTaskURLs(tasks []domain.DeleteURLsTask) error {
+	storage.mx.Lock()
+	defer storage.mx.Unlock()
+
 	for _, task := range tasks {
 		for _, shortURL := range task.ShortURLs {
-			shortenedURL := storage.structure[shortURL]
+			shortenedURL, ok := storage.structure[shortURL]
 
-			if shortenedURL.UserID != task.UserID {
+			if !ok || shortenedURL.UserID != task.UserID {
 				continue
 			}
 
@@ -154,14 +298,55 @@ func (storage *FileStorage) DoDeleteURLTasks(ctx context.Context, tasks []domain
 	}
 
 	if storage.savingChanges {
-		storage.saveToFile()
+		return storage.saveToFile()
 	}
 
 	return nil
 }
 
+// SetRedirectRules replace redirect rules of user url and save them to the file on disk.
+func (storage *FileStorage) SetRedirectRules(ctx context.Context, shortURL string, userID string, rules []domain.RedirectRule) error {
+	storage.mx.Lock()
+	defer storage.mx.Unlock()
+
+	if err := setRedirectRules(storage.structure, shortURL, userID, rules); err != nil {
+		return err
+	}
+
+	if storage.savingChanges {
+		return storage.saveToFile()
+	}
+
+	return nil
+}
+
+// IncrementClicks add count to clicks counter of short url, save it to the file on disk and return new value.
+func (storage *FileStorage) IncrementClicks(ctx context.Context, shortURL string, count int) (int, error) {
+	storage.mx.Lock()
+	defer storage.mx.Unlock()
+
+	shortenedURL, ok := storage.structure[shortURL]
+	if !ok {
+		return 0, domain.ErrURLNotFound
+	}
+
+	shortenedURL.Clicks += count
+	storage.structure[shortURL] = shortenedURL
+
+	if storage.savingChanges {
+		if err := storage.saveToFile(); err != nil {
+			return 0, err
+		}
+	}
+
+	return shortenedURL.Clicks, nil
+}
+
 // GetInternalStats get internal stats for metrics.
 func (storage *FileStorage) GetInternalStats(ctx context.Context) (*domain.InternalStats, error) {
+	storage.mx.RLock()
+	defer storage.mx.RUnlock()
+
 	stats := domain.InternalStats{}
 	uniqueUsers := make(map[string]struct{})
 
@@ -180,6 +365,29 @@ func (storage *FileStorage) Ping(ctx context.Context) error {
 	return nil
 }
 
+// CheckMigrations does nothing, file storage has no migrations.
+func (storage *FileStorage) CheckMigrations(ctx context.Context) error {
+	return nil
+}
+
+// Close save all changes to file and close it.
+func (storage *FileStorage) Close() error {
+	storage.mx.Lock()
+	defer storage.mx.Unlock()
+
+	if !storage.savingChanges {
+		return nil
+	}
+
+	if err := storage.saveToFile(); err != nil {
+		return err
+	}
+
+	storage.savingChanges = false
+
+	return storage.file.Close()
+}
+
 func (storage *FileStorage) parseFromFile() error {
 	b, err := os.ReadFile(storage.file.Name())
 
@@ -201,5 +409,55 @@ func (storage *FileStorage) saveToFile() error {
 		return err
 	}
 
-	return os.WriteFile(storage.file.Name(), b, os.ModeAppend)
+	return writeFileAtomic(storage.file.Name(), b)
+}
+
+// writeFileAtomic write data to temporary file next to path and rename it to path,
+// so file at path is never left partially written.
+func writeFileAtomic(path string, data []byte) error {
+	tmpFile, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".tmp-*")
+	if err != nil {
+		return err
+	}
+
+	tmpPath := tmpFile.Name()
+
+	if _, err := tmpFile.Write(data); err != nil {
+		tmpFile.Close()
+		os.Remove(tmpPath)
+		return err
+	}
+
+	if err := tmpFile.Sync(); err != nil {
+		tmpFile.Close()
+		os.Remove(tmpPath)
+		return err
+	}
+
+	if err := tmpFile.Close(); err != nil {
+		os.Remove(tmpPath)
+		return err
+	}
+
+	if err := os.Chmod(tmpPath, 0644); err != nil {
+		os.Remove(tmpPath)
+		return err
+	}
+
+	if err := os.Rename(tmpPath, path); err != nil {
+		os.Remove(tmpPath)
+		return err
+	}
+
+	return nil
+}
+
+// webhooksFilePath return path of file next to storage file where webhooks state is stored.
+func webhooksFilePath(fileStoragePath string) string {
+	return fileStoragePath + ".webhooks"
+}
+
+// deleteTasksFilePath return path of file next to storage file where delete tasks journal is stored.
+func deleteTasksFilePath(fileStoragePath string) string {
+	return fileStoragePath + ".delete-tasks"
 }
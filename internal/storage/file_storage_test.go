@@ -2,7 +2,10 @@ package storage
 
 import (
 	"context"
+	"os"
+	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -105,6 +108,50 @@ func TestFileStorage_GetURLsByUserID(t *testing.T) {
 	}
 }
 
+func TestFileStorage_SaveSeveralURL_KeepsOptions(t *testing.T) {
+	expiresAt := time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	storage, _ := NewFileStorage("")
+	urls, err := storage.SaveSeveralURL(context.Background(), []domain.SaveShortURLDto{
+		{
+			OriginalURL:  "https://test.com",
+			ShortURL:     "short-url",
+			UserID:       "1",
+			Tags:         []string{"news"},
+			ExpiresAt:    &expiresAt,
+			RedirectType: domain.RedirectPermanent,
+		},
+	})
+	require.NoError(t, err)
+	require.Len(t, urls, 1)
+
+	assert.Equal(t, []string{"news"}, urls[0].Tags)
+	assert.Equal(t, &expiresAt, urls[0].ExpiresAt)
+	assert.Equal(t, domain.RedirectPermanent, urls[0].RedirectType)
+	assert.NotNil(t, urls[0].CreatedAt)
+}
+
+func TestFileStorage_SetRedirectRules(t *testing.T) {
+	storagePath := filepath.Join(t.TempDir(), "storage.json")
+	storage, _ := NewFileStorage(storagePath)
+	_, err := storage.SaveURL(context.Background(), domain.SaveShortURLDto{
+		OriginalURL: "https://test.com",
+		ShortURL:    "short-url",
+		UserID:      "1",
+	})
+	require.NoError(t, err)
+
+	rules := []domain.RedirectRule{{Destination: "https://test.de", Languages: []string{"de"}}}
+	require.NoError(t, storage.SetRedirectRules(context.Background(), "short-url", "1", rules))
+	assert.ErrorIs(t, storage.SetRedirectRules(context.Background(), "short-url", "2", rules), domain.ErrURLNotFound)
+	require.NoError(t, storage.Close())
+
+	reopened, _ := NewFileStorage(storagePath)
+	url, err := reopened.GetByShortURL(context.Background(), "short-url")
+	require.NoError(t, err)
+	assert.Equal(t, rules, url.RedirectRules)
+}
+
 func TestFileStorage_SaveSeveralURL(t *testing.T) {
 	type TestCase struct {
 		Name    string
@@ -252,3 +299,102 @@ func TestFileStorage_Ping(t *testing.T) {
 		assert.NoError(t, err)
 	})
 }
+
+func TestFileStorage_DeleteTasksJournal(t *testing.T) {
+	ctx := context.Background()
+	storagePath := filepath.Join(t.TempDir(), "storage.json")
+
+	storage, err := NewFileStorage(storagePath)
+	require.NoError(t, err)
+
+	require.NoError(t, storage.SaveDeleteTask(ctx, domain.DeleteURLsTask{ID: "task", UserID: "1", Status: domain.DeleteTaskPending}))
+	require.NoError(t, storage.Close())
+
+	restoredStorage, err := NewFileStorage(storagePath)
+	require.NoError(t, err)
+
+	tasks, err := restoredStorage.GetPendingDeleteTasks(ctx, 10)
+	require.NoError(t, err)
+	require.Len(t, tasks, 1)
+	assert.Equal(t, "task", tasks[0].ID)
+
+	temporaryFiles, err := filepath.Glob(storagePath + "*.tmp-*")
+	require.NoError(t, err)
+	assert.Empty(t, temporaryFiles)
+	require.NoError(t, restoredStorage.Close())
+}
+
+func TestFileStorage_DeleteTasksJournalError(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	storagePath := filepath.Join(dir, "storage.json")
+
+	storage, err := NewFileStorage(storagePath)
+	require.NoError(t, err)
+	defer storage.Close()
+
+	// journal can not be replaced by file, when directory is at its path
+	require.NoError(t, os.Mkdir(deleteTasksFilePath(storagePath), 0755))
+
+	err = storage.SaveDeleteTask(ctx, domain.DeleteURLsTask{ID: "task", UserID: "1", Status: domain.DeleteTaskPending})
+	require.Error(t, err)
+
+	_, err = storage.GetDeleteTaskByID(ctx, "task")
+	assert.ErrorIs(t, err, domain.ErrDeleteTaskNotFound)
+}
+
+func TestFileStorage_SaveToFileError(t *testing.T) {
+	ctx := context.Background()
+	dir := filepath.Join(t.TempDir(), "storage")
+	require.NoError(t, os.Mkdir(dir, 0755))
+
+	storage, err := NewFileStorage(filepath.Join(dir, "storage.json"))
+	require.NoError(t, err)
+
+	// file can not be written, when its directory is removed
+	require.NoError(t, os.RemoveAll(dir))
+
+	_, err = storage.SaveURL(ctx, domain.SaveShortURLDto{OriginalURL: "https://a.example.com", ShortURL: "a", UserID: "1"})
+	assert.Error(t, err)
+
+	_, err = storage.SaveSeveralURL(ctx, []domain.SaveShortURLDto{{OriginalURL: "https://b.example.com", ShortURL: "b", UserID: "1"}})
+	assert.Error(t, err)
+
+	_, err = storage.IncrementClicks(ctx, "a", 1)
+	assert.Error(t, err)
+
+	assert.Error(t, storage.DeleteByShortURLs(ctx, []string{"a"}, "1"))
+}
+
+func TestFileStorage_ConcurrentAccess(t *testing.T) {
+	storage, err := NewFileStorage(filepath.Join(t.TempDir(), "storage.json"))
+	require.NoError(t, err)
+	defer storage.Close()
+
+	testConcurrentAccess(t, storage)
+}
+
+func TestNewReadOnlyFileStorage(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "storage.json")
+	rawFile := []byte(`{"a":{"id":1,"short_url":"a","original_url":"https://a.example.com"}}`)
+	require.NoError(t, os.WriteFile(path, rawFile, 0644))
+
+	storage, err := NewReadOnlyFileStorage(path)
+	require.NoError(t, err)
+
+	url, err := storage.GetByShortURL(context.Background(), "a")
+	require.NoError(t, err)
+	assert.Equal(t, "https://a.example.com", url.OriginalURL)
+
+	_, err = storage.SaveURL(context.Background(), domain.SaveShortURLDto{OriginalURL: "https://b.example.com", ShortURL: "b"})
+	require.NoError(t, err)
+	require.NoError(t, storage.Close())
+
+	// file is not rewritten by changes or by Close
+	savedFile, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, rawFile, savedFile)
+
+	_, err = NewReadOnlyFileStorage(filepath.Join(t.TempDir(), "missing.json"))
+	assert.ErrorIs(t, err, os.ErrNotExist)
+}
@@ -3,19 +3,27 @@ package storage
 import (
 	"context"
 	"errors"
+	"sync"
+	"time"
 
 	"github.com/MowlCoder/go-url-shortener/internal/domain"
 )
 
-// InMemoryStorage is storage that store all information in memory.
+// InMemoryStorage is storage that store all information in memory. It is safe for concurrent use.
 type InMemoryStorage struct {
+	*memoryWebhookStorage
+	*memoryDeleteTaskStorage
+
 	structure map[string]domain.ShortenedURL
+	mx        sync.RWMutex
 }
 
 // NewInMemoryStorage create in memory storage.
 func NewInMemoryStorage() (*InMemoryStorage, error) {
 	storage := InMemoryStorage{
-		structure: make(map[string]domain.ShortenedURL),
+		memoryWebhookStorage:    newMemoryWebhookStorage(),
+		memoryDeleteTaskStorage: newMemoryDeleteTaskStorage(),
+		structure:               make(map[string]domain.ShortenedURL),
 	}
 
 	return &storage, nil
@@ -23,6 +31,9 @@ func NewInMemoryStorage() (*InMemoryStorage, error) {
 
 // GetByShortURL return model where short url equal given short url.
 func (storage *InMemoryStorage) GetByShortURL(ctx context.Context, shortURL string) (*domain.ShortenedURL, error) {
+	storage.mx.RLock()
+	defer storage.mx.RUnlock()
+
 	if url, ok := storage.structure[shortURL]; ok {
 		return &url, nil
 	}
@@ -32,6 +43,9 @@ func (storage *InMemoryStorage) GetByShortURL(ctx context.Context, shortURL stri
 
 // GetURLsByUserID return list of models where user id equal given user id.
 func (storage *InMemoryStorage) GetURLsByUserID(ctx context.Context, userID string) ([]domain.ShortenedURL, error) {
+	storage.mx.RLock()
+	defer storage.mx.RUnlock()
+
 	urls := make([]domain.ShortenedURL, 0)
 
 	for _, value := range storage.structure {
@@ -43,8 +57,47 @@ func (storage *InMemoryStorage) GetURLsByUserID(ctx context.Context, userID stri
 	return urls, nil
 }
 
+// GetURLsAfter return up to limit urls with short url greater than given one, ordered by short url.
+// Use empty afterShortURL to get first page.
+func (storage *InMemoryStorage) GetURLsAfter(ctx context.Context, afterShortURL string, limit int) ([]domain.ShortenedURL, error) {
+	storage.mx.RLock()
+	defer storage.mx.RUnlock()
+
+	return urlsAfter(storage.structure, afterShortURL, limit), nil
+}
+
+// GetURLsByUserIDAfter return up to limit urls of user with short url greater than given one, ordered by short url.
+// Use empty afterShortURL to get first page.
+func (storage *InMemoryStorage) GetURLsByUserIDAfter(
+	ctx context.Context,
+	userID string,
+	afterShortURL string,
+	limit int,
+) ([]domain.ShortenedURL, error) {
+	storage.mx.RLock()
+	defer storage.mx.RUnlock()
+
+	return userURLsAfter(storage.structure, userID, afterShortURL, limit), nil
+}
+
+// ImportURLs save urls as is, keeping user id, deleted flag and clicks.
+// Urls with already existing short url or original url are skipped. Return short urls of skipped urls.
+func (storage *InMemoryStorage) ImportURLs(ctx context.Context, urls []domain.ShortenedURL) ([]string, error) {
+	storage.mx.Lock()
+	defer storage.mx.Unlock()
+
+	return importURLs(storage.structure, urls), nil
+}
+
 // FindByOriginalURL return model where original url equal given original url.
 func (storage *InMemoryStorage) FindByOriginalURL(ctx context.Context, originalURL string) (domain.ShortenedURL, error) {
+	storage.mx.RLock()
+	defer storage.mx.RUnlock()
+
+	return storage.findByOriginalURL(originalURL)
+}
+
+func (storage *InMemoryStorage) findByOriginalURL(originalURL string) (domain.ShortenedURL, error) {
 	for _, value := range storage.structure {
 		if value.OriginalURL == originalURL {
 			return value, nil
@@ -56,16 +109,32 @@ func (storage *InMemoryStorage) FindByOriginalURL(ctx context.Context, originalU
 
 // SaveURL save short url to the memory.
 func (storage *InMemoryStorage) SaveURL(ctx context.Context, dto domain.SaveShortURLDto) (*domain.ShortenedURL, error) {
-	shortenedURL, err := storage.FindByOriginalURL(ctx, dto.OriginalURL)
+	storage.mx.Lock()
+	defer storage.mx.Unlock()
+
+	return storage.saveURL(dto)
+}
+
+func (storage *InMemoryStorage) saveURL(dto domain.SaveShortURLDto) (*domain.ShortenedURL, error) {
+	shortenedURL, err := storage.findByOriginalURL(dto.OriginalURL)
 
 	if err == nil {
 		return &shortenedURL, domain.ErrURLConflict
 	}
 
+	createdAt := time.Now().UTC()
 	storage.structure[dto.ShortURL] = domain.ShortenedURL{
-		ID:          len(storage.structure) + 1,
-		ShortURL:    dto.ShortURL,
-		OriginalURL: dto.OriginalURL,
+		ID:               len(storage.structure) + 1,
+		ShortURL:         dto.ShortURL,
+		OriginalURL:      dto.OriginalURL,
+		UserID:           dto.UserID,
+		Tags:             dto.Tags,
+		ExpiresAt:        dto.ExpiresAt,
+		RedirectType:     dto.RedirectType,
+		UTM:              dto.UTM,
+		QueryPassthrough: dto.QueryPassthrough,
+		RedirectRules:    dto.RedirectRules,
+		CreatedAt:        &createdAt,
 	}
 
 	shortenedURL = storage.structure[dto.ShortURL]
@@ -75,10 +144,13 @@ func (storage *InMemoryStorage) SaveURL(ctx context.Context, dto domain.SaveShor
 
 // SaveSeveralURL save several short url to the memory.
 func (storage *InMemoryStorage) SaveSeveralURL(ctx context.Context, dtos []domain.SaveShortURLDto) ([]domain.ShortenedURL, error) {
+	storage.mx.Lock()
+	defer storage.mx.Unlock()
+
 	shortenedURLs := make([]domain.ShortenedURL, 0, len(dtos))
 
 	for _, dto := range dtos {
-		shortenedURL, err := storage.SaveURL(ctx, dto)
+		shortenedURL, err := storage.saveURL(dto)
 
 		if err != nil && !errors.Is(err, domain.ErrURLConflict) {
 			return nil, err
@@ -92,6 +164,9 @@ func (storage *InMemoryStorage) SaveSeveralURL(ctx context.Context, dtos []domai
 
 // DeleteByShortURLs delete short urls from the memory.
 func (storage *InMemoryStorage) DeleteByShortURLs(ctx context.Context, shortURLs []string, userID string) error {
+	storage.mx.Lock()
+	defer storage.mx.Unlock()
+
 	for _, shortURL := range shortURLs {
 		shortenedURL := storage.structure[shortURL]
 
@@ -106,13 +181,15 @@ func (storage *InMemoryStorage) DeleteByShortURLs(ctx context.Context, shortURLs
 	return nil
 }
 
-// DoDeleteURLTasks execute delete tasks and save result in the memory.
+// DoDeleteURLTasks execute delete tasks, save result in the memory and mark tasks as completed.
 func (storage *InMemoryStorage) DoDeleteURLTasks(ctx context.Context, tasks []domain.DeleteURLsTask) error {
+	storage.mx.Lock()
+
 	for _, task := range tasks {
 		for _, shortURL := range task.ShortURLs {
-			shortenedURL := storage.structure[shortURL]
+			shortenedURL, ok := storage.structure[shortURL]
 
-			if shortenedURL.UserID != task.UserID {
+			if !ok || shortenedURL.UserID != task.UserID {
 				continue
 			}
 
@@ -121,11 +198,40 @@ func (storage *InMemoryStorage) DoDeleteURLTasks(ctx context.Context, tasks []do
 		}
 	}
 
-	return nil
+	storage.mx.Unlock()
+
+	return storage.completeDeleteTasks(tasks, time.Now().UTC())
+}
+
+// SetRedirectRules replace redirect rules of user url in the memory.
+func (storage *InMemoryStorage) SetRedirectRules(ctx context.Context, shortURL string, userID string, rules []domain.RedirectRule) error {
+	storage.mx.Lock()
+	defer storage.mx.Unlock()
+
+	return setRedirectRules(storage.structure, shortURL, userID, rules)
+}
+
+// IncrementClicks add count to clicks counter of short url and return new value.
+func (storage *InMemoryStorage) IncrementClicks(ctx context.Context, shortURL string, count int) (int, error) {
+	storage.mx.Lock()
+	defer storage.mx.Unlock()
+
+	shortenedURL, ok := storage.structure[shortURL]
+	if !ok {
+		return 0, domain.ErrURLNotFound
+	}
+
+	shortenedURL.Clicks += count
+	storage.structure[shortURL] = shortenedURL
+
+	return shortenedURL.Clicks, nil
 }
 
 // GetInternalStats get internal stats for metrics.
 func (storage *InMemoryStorage) GetInternalStats(ctx context.Context) (*domain.InternalStats, error) {
+	storage.mx.RLock()
+	defer storage.mx.RUnlock()
+
 	stats := domain.InternalStats{}
 	uniqueUsers := make(map[string]struct{})
 
@@ -143,3 +249,13 @@ func (storage *InMemoryStorage) GetInternalStats(ctx context.Context) (*domain.I
 func (storage *InMemoryStorage) Ping(ctx context.Context) error {
 	return nil
 }
+
+// CheckMigrations does nothing, in memory storage has no migrations.
+func (storage *InMemoryStorage) CheckMigrations(ctx context.Context) error {
+	return nil
+}
+
+// Close does nothing, in memory storage has no resources to release.
+func (storage *InMemoryStorage) Close() error {
+	return nil
+}
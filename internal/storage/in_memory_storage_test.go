@@ -2,7 +2,10 @@ package storage
 
 import (
 	"context"
+	"fmt"
+	"sync"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -51,6 +54,57 @@ func TestInMemoryStorage_SaveURL(t *testing.T) {
 		assert.ErrorIs(t, err, domain.ErrURLConflict)
 		assert.Equal(t, secondShortenedURL.ShortURL, shortenedURL.ShortURL)
 	})
+
+	t.Run("Save url with tags and expiration", func(t *testing.T) {
+		expiresAt := time.Now().Add(time.Hour)
+		storage, _ := NewInMemoryStorage()
+		_, err := storage.SaveURL(context.Background(), domain.SaveShortURLDto{
+			OriginalURL: "https://test.com",
+			ShortURL:    "short-url",
+			UserID:      "1",
+			Tags:        []string{"news"},
+			ExpiresAt:   &expiresAt,
+		})
+		require.NoError(t, err)
+
+		shortenedURL, err := storage.GetByShortURL(context.Background(), "short-url")
+		require.NoError(t, err)
+		assert.Equal(t, []string{"news"}, shortenedURL.Tags)
+		assert.Equal(t, &expiresAt, shortenedURL.ExpiresAt)
+	})
+
+	t.Run("Save url with utm params", func(t *testing.T) {
+		storage, _ := NewInMemoryStorage()
+		_, err := storage.SaveURL(context.Background(), domain.SaveShortURLDto{
+			OriginalURL:      "https://test.com",
+			ShortURL:         "short-url",
+			UserID:           "1",
+			UTM:              &domain.UTMParams{Source: "mail"},
+			QueryPassthrough: true,
+		})
+		require.NoError(t, err)
+
+		shortenedURL, err := storage.GetByShortURL(context.Background(), "short-url")
+		require.NoError(t, err)
+		assert.Equal(t, &domain.UTMParams{Source: "mail"}, shortenedURL.UTM)
+		assert.True(t, shortenedURL.QueryPassthrough)
+	})
+
+	t.Run("Save url with redirect type", func(t *testing.T) {
+		storage, _ := NewInMemoryStorage()
+		_, err := storage.SaveURL(context.Background(), domain.SaveShortURLDto{
+			OriginalURL:  "https://test.com",
+			ShortURL:     "short-url",
+			UserID:       "1",
+			RedirectType: domain.RedirectPreview,
+		})
+		require.NoError(t, err)
+
+		shortenedURL, err := storage.GetByShortURL(context.Background(), "short-url")
+		require.NoError(t, err)
+		assert.Equal(t, domain.RedirectPreview, shortenedURL.RedirectType)
+		assert.NotNil(t, shortenedURL.CreatedAt)
+	})
 }
 
 func TestInMemoryStorage_GetURLsByUserID(t *testing.T) {
@@ -252,3 +306,166 @@ func TestInMemoryStorage_Ping(t *testing.T) {
 		assert.NoError(t, err)
 	})
 }
+
+func TestInMemoryStorage_IncrementClicks(t *testing.T) {
+	storage, _ := NewInMemoryStorage()
+	storage.structure["1"] = domain.ShortenedURL{ShortURL: "1"}
+
+	t.Run("increment", func(t *testing.T) {
+		clicks, err := storage.IncrementClicks(context.Background(), "1", 1)
+		require.NoError(t, err)
+		assert.Equal(t, 1, clicks)
+
+		clicks, err = storage.IncrementClicks(context.Background(), "1", 3)
+		require.NoError(t, err)
+		assert.Equal(t, 4, clicks)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		_, err := storage.IncrementClicks(context.Background(), "2", 1)
+		assert.ErrorIs(t, err, domain.ErrURLNotFound)
+	})
+}
+
+func TestInMemoryStorage_SetRedirectRules(t *testing.T) {
+	storage, _ := NewInMemoryStorage()
+	storage.structure["1"] = domain.ShortenedURL{ShortURL: "1", UserID: "1"}
+	rules := []domain.RedirectRule{{Destination: "https://example.de", Countries: []string{"DE"}}}
+
+	t.Run("set", func(t *testing.T) {
+		require.NoError(t, storage.SetRedirectRules(context.Background(), "1", "1", rules))
+
+		url, err := storage.GetByShortURL(context.Background(), "1")
+		require.NoError(t, err)
+		assert.Equal(t, rules, url.RedirectRules)
+	})
+
+	t.Run("url of other user", func(t *testing.T) {
+		err := storage.SetRedirectRules(context.Background(), "1", "2", nil)
+		assert.ErrorIs(t, err, domain.ErrURLNotFound)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		err := storage.SetRedirectRules(context.Background(), "2", "1", nil)
+		assert.ErrorIs(t, err, domain.ErrURLNotFound)
+	})
+}
+
+func TestInMemoryStorage_GetURLsAfter(t *testing.T) {
+	storage, _ := NewInMemoryStorage()
+	storage.structure["c"] = domain.ShortenedURL{ShortURL: "c"}
+	storage.structure["a"] = domain.ShortenedURL{ShortURL: "a"}
+	storage.structure["b"] = domain.ShortenedURL{ShortURL: "b"}
+
+	urls, err := storage.GetURLsAfter(context.Background(), "", 2)
+	require.NoError(t, err)
+	require.Len(t, urls, 2)
+	assert.Equal(t, "a", urls[0].ShortURL)
+	assert.Equal(t, "b", urls[1].ShortURL)
+
+	urls, err = storage.GetURLsAfter(context.Background(), "b", 2)
+	require.NoError(t, err)
+	require.Len(t, urls, 1)
+	assert.Equal(t, "c", urls[0].ShortURL)
+}
+
+func TestInMemoryStorage_GetURLsByUserIDAfter(t *testing.T) {
+	storage, _ := NewInMemoryStorage()
+	storage.structure["c"] = domain.ShortenedURL{ShortURL: "c", UserID: "1"}
+	storage.structure["a"] = domain.ShortenedURL{ShortURL: "a", UserID: "1"}
+	storage.structure["b"] = domain.ShortenedURL{ShortURL: "b", UserID: "2"}
+	storage.structure["d"] = domain.ShortenedURL{ShortURL: "d", UserID: "1"}
+
+	urls, err := storage.GetURLsByUserIDAfter(context.Background(), "1", "", 2)
+	require.NoError(t, err)
+	require.Len(t, urls, 2)
+	assert.Equal(t, "a", urls[0].ShortURL)
+	assert.Equal(t, "c", urls[1].ShortURL)
+
+	urls, err = storage.GetURLsByUserIDAfter(context.Background(), "1", "c", 2)
+	require.NoError(t, err)
+	require.Len(t, urls, 1)
+	assert.Equal(t, "d", urls[0].ShortURL)
+}
+
+func TestInMemoryStorage_ImportURLs(t *testing.T) {
+	storage, _ := NewInMemoryStorage()
+	storage.structure["exists"] = domain.ShortenedURL{ShortURL: "exists", OriginalURL: "https://exists.com"}
+
+	skipped, err := storage.ImportURLs(context.Background(), []domain.ShortenedURL{
+		{ShortURL: "new", OriginalURL: "https://new.com", UserID: "1", IsDeleted: true, Clicks: 3},
+		{ShortURL: "exists", OriginalURL: "https://another.com"},
+		{ShortURL: "same-original", OriginalURL: "https://exists.com"},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"exists", "same-original"}, skipped)
+
+	url, err := storage.GetByShortURL(context.Background(), "new")
+	require.NoError(t, err)
+	assert.Equal(t, "1", url.UserID)
+	assert.True(t, url.IsDeleted)
+	assert.Equal(t, 3, url.Clicks)
+}
+
+type concurrentURLStorage interface {
+	SaveSeveralURL(ctx context.Context, dtos []domain.SaveShortURLDto) ([]domain.ShortenedURL, error)
+	GetByShortURL(ctx context.Context, shortURL string) (*domain.ShortenedURL, error)
+	GetURLsByUserID(ctx context.Context, userID string) ([]domain.ShortenedURL, error)
+	IncrementClicks(ctx context.Context, shortURL string, count int) (int, error)
+	DeleteByShortURLs(ctx context.Context, shortURLs []string, userID string) error
+}
+
+// testConcurrentAccess uses storage from several goroutines, like background import and requests do.
+func testConcurrentAccess(t *testing.T, storage concurrentURLStorage) {
+	t.Helper()
+
+	const workers = 8
+	const urlsPerWorker = 50
+
+	ctx := context.Background()
+
+	var wg sync.WaitGroup
+
+	for worker := 0; worker < workers; worker++ {
+		wg.Add(1)
+
+		go func(worker int) {
+			defer wg.Done()
+
+			for i := 0; i < urlsPerWorker; i++ {
+				shortURL := fmt.Sprintf("w%d-%d", worker, i)
+
+				_, err := storage.SaveSeveralURL(ctx, []domain.SaveShortURLDto{{
+					OriginalURL: "https://example.com/" + shortURL,
+					ShortURL:    shortURL,
+					UserID:      "1",
+				}})
+				assert.NoError(t, err)
+
+				_, err = storage.GetByShortURL(ctx, shortURL)
+				assert.NoError(t, err)
+
+				_, err = storage.IncrementClicks(ctx, shortURL, 1)
+				assert.NoError(t, err)
+
+				_, err = storage.GetURLsByUserID(ctx, "1")
+				assert.NoError(t, err)
+
+				assert.NoError(t, storage.DeleteByShortURLs(ctx, []string{shortURL}, "1"))
+			}
+		}(worker)
+	}
+
+	wg.Wait()
+
+	urls, err := storage.GetURLsByUserID(ctx, "1")
+	require.NoError(t, err)
+	assert.Len(t, urls, workers*urlsPerWorker)
+}
+
+func TestInMemoryStorage_ConcurrentAccess(t *testing.T) {
+	storage, err := NewInMemoryStorage()
+	require.NoError(t, err)
+
+	testConcurrentAccess(t, storage)
+}
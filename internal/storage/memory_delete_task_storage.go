@@ -0,0 +1,182 @@
+package storage
+
+import (
+	"context"
+	"encoding/json"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/MowlCoder/go-url-shortener/internal/domain"
+)
+
+// completedDeleteTaskRetention is how long completed delete tasks are kept, so clients can poll their status.
+const completedDeleteTaskRetention = time.Hour * 24
+
+// memoryDeleteTaskStorage store delete url tasks in memory.
+// It is embedded into InMemoryStorage and FileStorage. If onChange is set,
+// it is called with serialized tasks after every modification and its error is returned to caller.
+type memoryDeleteTaskStorage struct {
+	tasks    map[string]domain.DeleteURLsTask
+	onChange func(rawTasks []byte) error
+	mx       sync.RWMutex
+}
+
+func newMemoryDeleteTaskStorage() *memoryDeleteTaskStorage {
+	return &memoryDeleteTaskStorage{
+		tasks: make(map[string]domain.DeleteURLsTask),
+	}
+}
+
+// SaveDeleteTask save delete task. If task can not be persisted, it is not saved.
+func (storage *memoryDeleteTaskStorage) SaveDeleteTask(ctx context.Context, task domain.DeleteURLsTask) error {
+	storage.mx.Lock()
+	defer storage.mx.Unlock()
+
+	previous, existed := storage.tasks[task.ID]
+	storage.tasks[task.ID] = task
+
+	if err := storage.changed(); err != nil {
+		if existed {
+			storage.tasks[task.ID] = previous
+		} else {
+			delete(storage.tasks, task.ID)
+		}
+
+		return err
+	}
+
+	return nil
+}
+
+// GetDeleteTaskByID return delete task with given id.
+func (storage *memoryDeleteTaskStorage) GetDeleteTaskByID(ctx context.Context, id string) (*domain.DeleteURLsTask, error) {
+	storage.mx.RLock()
+	defer storage.mx.RUnlock()
+
+	task, ok := storage.tasks[id]
+	if !ok {
+		return nil, domain.ErrDeleteTaskNotFound
+	}
+
+	return &task, nil
+}
+
+// GetPendingDeleteTasks return oldest pending delete tasks.
+func (storage *memoryDeleteTaskStorage) GetPendingDeleteTasks(ctx context.Context, limit int) ([]domain.DeleteURLsTask, error) {
+	storage.mx.RLock()
+	defer storage.mx.RUnlock()
+
+	tasks := make([]domain.DeleteURLsTask, 0)
+
+	for _, task := range storage.tasks {
+		if task.Status == domain.DeleteTaskPending {
+			tasks = append(tasks, task)
+		}
+	}
+
+	sort.Slice(tasks, func(i, j int) bool {
+		return tasks[i].CreatedAt.Before(tasks[j].CreatedAt)
+	})
+
+	if len(tasks) > limit {
+		tasks = tasks[:limit]
+	}
+
+	return tasks, nil
+}
+
+// CountPendingDeleteTasks return count of pending delete tasks.
+func (storage *memoryDeleteTaskStorage) CountPendingDeleteTasks(ctx context.Context) (int, error) {
+	storage.mx.RLock()
+	defer storage.mx.RUnlock()
+
+	count := 0
+
+	for _, task := range storage.tasks {
+		if task.Status == domain.DeleteTaskPending {
+			count++
+		}
+	}
+
+	return count, nil
+}
+
+// FailDeleteTasks increment attempts of delete tasks and save reason of failure. Tasks stay pending
+// until they fail domain.DeleteTaskMaxAttempts times, after that they get failed status.
+func (storage *memoryDeleteTaskStorage) FailDeleteTasks(ctx context.Context, ids []string, reason string) error {
+	storage.mx.Lock()
+	defer storage.mx.Unlock()
+
+	for _, id := range ids {
+		task, ok := storage.tasks[id]
+		if !ok {
+			continue
+		}
+
+		task.Attempts++
+		task.LastError = reason
+
+		if task.Attempts >= domain.DeleteTaskMaxAttempts {
+			task.Status = domain.DeleteTaskFailed
+		}
+
+		storage.tasks[id] = task
+	}
+
+	return storage.changed()
+}
+
+// completeDeleteTasks mark given tasks as completed and remove outdated completed tasks.
+func (storage *memoryDeleteTaskStorage) completeDeleteTasks(tasks []domain.DeleteURLsTask, completedAt time.Time) error {
+	storage.mx.Lock()
+	defer storage.mx.Unlock()
+
+	for _, completedTask := range tasks {
+		task, ok := storage.tasks[completedTask.ID]
+		if !ok {
+			continue
+		}
+
+		task.Status = domain.DeleteTaskCompleted
+		task.Attempts++
+		task.LastError = ""
+		task.CompletedAt = &completedAt
+		storage.tasks[task.ID] = task
+	}
+
+	for id, task := range storage.tasks {
+		if task.CompletedAt != nil && completedAt.Sub(*task.CompletedAt) > completedDeleteTaskRetention {
+			delete(storage.tasks, id)
+		}
+	}
+
+	return storage.changed()
+}
+
+func (storage *memoryDeleteTaskStorage) changed() error {
+	if storage.onChange == nil {
+		return nil
+	}
+
+	rawTasks, err := json.Marshal(&storage.tasks)
+	if err != nil {
+		return err
+	}
+
+	return storage.onChange(rawTasks)
+}
+
+func (storage *memoryDeleteTaskStorage) load(rawTasks []byte) error {
+	storage.mx.Lock()
+	defer storage.mx.Unlock()
+
+	tasks := make(map[string]domain.DeleteURLsTask)
+	if err := json.Unmarshal(rawTasks, &tasks); err != nil {
+		return err
+	}
+
+	storage.tasks = tasks
+
+	return nil
+}
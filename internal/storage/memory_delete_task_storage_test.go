@@ -0,0 +1,139 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/MowlCoder/go-url-shortener/internal/domain"
+)
+
+func TestMemoryDeleteTaskStorage_PendingTasks(t *testing.T) {
+	storage := newMemoryDeleteTaskStorage()
+	ctx := context.Background()
+	now := time.Now()
+
+	require.NoError(t, storage.SaveDeleteTask(ctx, domain.DeleteURLsTask{ID: "2", Status: domain.DeleteTaskPending, CreatedAt: now}))
+	require.NoError(t, storage.SaveDeleteTask(ctx, domain.DeleteURLsTask{ID: "1", Status: domain.DeleteTaskPending, CreatedAt: now.Add(-time.Minute)}))
+	require.NoError(t, storage.SaveDeleteTask(ctx, domain.DeleteURLsTask{ID: "3", Status: domain.DeleteTaskCompleted, CreatedAt: now}))
+
+	tasks, err := storage.GetPendingDeleteTasks(ctx, 1)
+	require.NoError(t, err)
+	require.Len(t, tasks, 1)
+	assert.Equal(t, "1", tasks[0].ID)
+
+	count, err := storage.CountPendingDeleteTasks(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 2, count)
+
+	require.NoError(t, storage.FailDeleteTasks(ctx, []string{"1"}, "undefined behavior"))
+
+	task, err := storage.GetDeleteTaskByID(ctx, "1")
+	require.NoError(t, err)
+	assert.Equal(t, domain.DeleteTaskPending, task.Status)
+	assert.Equal(t, 1, task.Attempts)
+	assert.Equal(t, "undefined behavior", task.LastError)
+
+	require.NoError(t, storage.completeDeleteTasks(tasks, now))
+
+	task, err = storage.GetDeleteTaskByID(ctx, "1")
+	require.NoError(t, err)
+	assert.Equal(t, domain.DeleteTaskCompleted, task.Status)
+	assert.Empty(t, task.LastError)
+	require.NotNil(t, task.CompletedAt)
+
+	tasks, err = storage.GetPendingDeleteTasks(ctx, 10)
+	require.NoError(t, err)
+	require.Len(t, tasks, 1)
+	assert.Equal(t, "2", tasks[0].ID)
+
+	_, err = storage.GetDeleteTaskByID(ctx, "unknown")
+	assert.ErrorIs(t, err, domain.ErrDeleteTaskNotFound)
+}
+
+func TestMemoryDeleteTaskStorage_Retention(t *testing.T) {
+	storage := newMemoryDeleteTaskStorage()
+	ctx := context.Background()
+	now := time.Now()
+
+	require.NoError(t, storage.SaveDeleteTask(ctx, domain.DeleteURLsTask{ID: "old", Status: domain.DeleteTaskPending}))
+	require.NoError(t, storage.completeDeleteTasks([]domain.DeleteURLsTask{{ID: "old"}}, now.Add(-completedDeleteTaskRetention*2)))
+
+	require.NoError(t, storage.completeDeleteTasks(nil, now))
+
+	_, err := storage.GetDeleteTaskByID(ctx, "old")
+	assert.ErrorIs(t, err, domain.ErrDeleteTaskNotFound)
+}
+
+func TestMemoryDeleteTaskStorage_Persistence(t *testing.T) {
+	var rawTasks []byte
+
+	storage := newMemoryDeleteTaskStorage()
+	storage.onChange = func(tasks []byte) error {
+		rawTasks = tasks
+		return nil
+	}
+
+	require.NoError(t, storage.SaveDeleteTask(context.Background(), domain.DeleteURLsTask{
+		ID:        "task",
+		UserID:    "user",
+		Status:    domain.DeleteTaskPending,
+		ShortURLs: []string{"abc"},
+	}))
+	require.NotEmpty(t, rawTasks)
+
+	restoredStorage := newMemoryDeleteTaskStorage()
+	require.NoError(t, restoredStorage.load(rawTasks))
+
+	tasks, err := restoredStorage.GetPendingDeleteTasks(context.Background(), 10)
+	require.NoError(t, err)
+	require.Len(t, tasks, 1)
+	assert.Equal(t, []string{"abc"}, tasks[0].ShortURLs)
+}
+
+func TestMemoryDeleteTaskStorage_MaxAttempts(t *testing.T) {
+	storage := newMemoryDeleteTaskStorage()
+	ctx := context.Background()
+
+	require.NoError(t, storage.SaveDeleteTask(ctx, domain.DeleteURLsTask{ID: "task", Status: domain.DeleteTaskPending}))
+
+	for i := 1; i < domain.DeleteTaskMaxAttempts; i++ {
+		require.NoError(t, storage.FailDeleteTasks(ctx, []string{"task"}, "undefined behavior"))
+	}
+
+	task, err := storage.GetDeleteTaskByID(ctx, "task")
+	require.NoError(t, err)
+	assert.Equal(t, domain.DeleteTaskPending, task.Status)
+
+	require.NoError(t, storage.FailDeleteTasks(ctx, []string{"task"}, "undefined behavior"))
+
+	task, err = storage.GetDeleteTaskByID(ctx, "task")
+	require.NoError(t, err)
+	assert.Equal(t, domain.DeleteTaskFailed, task.Status)
+	assert.Equal(t, domain.DeleteTaskMaxAttempts, task.Attempts)
+
+	count, err := storage.CountPendingDeleteTasks(ctx)
+	require.NoError(t, err)
+	assert.Zero(t, count)
+}
+
+func TestMemoryDeleteTaskStorage_PersistenceError(t *testing.T) {
+	persistErr := errors.New("disk is full")
+
+	storage := newMemoryDeleteTaskStorage()
+	storage.onChange = func(tasks []byte) error {
+		return persistErr
+	}
+
+	err := storage.SaveDeleteTask(context.Background(), domain.DeleteURLsTask{ID: "task", Status: domain.DeleteTaskPending})
+	assert.ErrorIs(t, err, persistErr)
+
+	_, err = storage.GetDeleteTaskByID(context.Background(), "task")
+	assert.ErrorIs(t, err, domain.ErrDeleteTaskNotFound)
+
+	assert.ErrorIs(t, storage.FailDeleteTasks(context.Background(), []string{"task"}, "reason"), persistErr)
+}
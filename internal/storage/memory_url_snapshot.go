@@ -0,0 +1,78 @@
+package storage
+
+import (
+	"sort"
+
+	"github.com/MowlCoder/go-url-shortener/internal/domain"
+)
+
+// urlsAfter return up to limit urls from structure with short url greater than afterShortURL, ordered by short url.
+func urlsAfter(structure map[string]domain.ShortenedURL, afterShortURL string, limit int) []domain.ShortenedURL {
+	return userURLsAfter(structure, "", afterShortURL, limit)
+}
+
+// userURLsAfter return up to limit urls of user with short url greater than given one, ordered by short url.
+// Empty userID matches urls of all users.
+func userURLsAfter(structure map[string]domain.ShortenedURL, userID string, afterShortURL string, limit int) []domain.ShortenedURL {
+	urls := make([]domain.ShortenedURL, 0)
+
+	for shortURL, url := range structure {
+		if shortURL > afterShortURL && (userID == "" || url.UserID == userID) {
+			urls = append(urls, url)
+		}
+	}
+
+	sort.Slice(urls, func(i, j int) bool {
+		return urls[i].ShortURL < urls[j].ShortURL
+	})
+
+	if len(urls) > limit {
+		urls = urls[:limit]
+	}
+
+	return urls
+}
+
+// importURLs save urls to structure as is. Urls with already existing short url or original url are skipped.
+// Return count of saved urls.
+func importURLs(structure map[string]domain.ShortenedURL, urls []domain.ShortenedURL) []string {
+	originalURLs := make(map[string]struct{}, len(structure))
+
+	for _, url := range structure {
+		originalURLs[url.OriginalURL] = struct{}{}
+	}
+
+	skipped := make([]string, 0)
+
+	for _, url := range urls {
+		if _, ok := structure[url.ShortURL]; ok {
+			skipped = append(skipped, url.ShortURL)
+			continue
+		}
+
+		if _, ok := originalURLs[url.OriginalURL]; ok {
+			skipped = append(skipped, url.ShortURL)
+			continue
+		}
+
+		url.ID = len(structure) + 1
+		structure[url.ShortURL] = url
+		originalURLs[url.OriginalURL] = struct{}{}
+	}
+
+	return skipped
+}
+
+// setRedirectRules replace redirect rules of url in structure. Return domain.ErrURLNotFound,
+// when user has no url with given short url.
+func setRedirectRules(structure map[string]domain.ShortenedURL, shortURL string, userID string, rules []domain.RedirectRule) error {
+	url, ok := structure[shortURL]
+	if !ok || url.UserID != userID {
+		return domain.ErrURLNotFound
+	}
+
+	url.RedirectRules = rules
+	structure[shortURL] = url
+
+	return nil
+}
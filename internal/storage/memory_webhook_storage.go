@@ -0,0 +1,197 @@
+package storage
+
+import (
+	"context"
+	"encoding/json"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/MowlCoder/go-url-shortener/internal/domain"
+)
+
+// memoryWebhookStorage store webhooks, outbox events and delivery log in memory.
+// It is embedded into InMemoryStorage and FileStorage. If onChange is set,
+// it is called with serialized state after every modification and its error is returned to caller.
+type memoryWebhookStorage struct {
+	state    memoryWebhookState
+	onChange func(rawState []byte) error
+	mx       sync.RWMutex
+}
+
+type memoryWebhookState struct {
+	Webhooks   map[string]domain.Webhook      `json:"webhooks"`
+	Events     map[string]domain.WebhookEvent `json:"events"`
+	Deliveries []domain.WebhookDelivery       `json:"deliveries"`
+}
+
+func newMemoryWebhookStorage() *memoryWebhookStorage {
+	return &memoryWebhookStorage{
+		state: memoryWebhookState{
+			Webhooks:   make(map[string]domain.Webhook),
+			Events:     make(map[string]domain.WebhookEvent),
+			Deliveries: make([]domain.WebhookDelivery, 0),
+		},
+	}
+}
+
+// SaveWebhook save webhook subscription.
+func (storage *memoryWebhookStorage) SaveWebhook(ctx context.Context, webhook domain.Webhook) error {
+	storage.mx.Lock()
+	defer storage.mx.Unlock()
+
+	storage.state.Webhooks[webhook.ID] = webhook
+	return storage.changed()
+}
+
+// GetWebhookByID return webhook with given id.
+func (storage *memoryWebhookStorage) GetWebhookByID(ctx context.Context, id string) (*domain.Webhook, error) {
+	storage.mx.RLock()
+	defer storage.mx.RUnlock()
+
+	webhook, ok := storage.state.Webhooks[id]
+	if !ok {
+		return nil, domain.ErrWebhookNotFound
+	}
+
+	return &webhook, nil
+}
+
+// GetWebhooksByUserID return list of webhooks where user id equal given user id.
+func (storage *memoryWebhookStorage) GetWebhooksByUserID(ctx context.Context, userID string) ([]domain.Webhook, error) {
+	storage.mx.RLock()
+	defer storage.mx.RUnlock()
+
+	webhooks := make([]domain.Webhook, 0)
+
+	for _, webhook := range storage.state.Webhooks {
+		if webhook.UserID == userID {
+			webhooks = append(webhooks, webhook)
+		}
+	}
+
+	sort.Slice(webhooks, func(i, j int) bool {
+		return webhooks[i].CreatedAt.Before(webhooks[j].CreatedAt)
+	})
+
+	return webhooks, nil
+}
+
+// DeleteWebhook delete webhook of given user. Pending events of webhook become failed.
+func (storage *memoryWebhookStorage) DeleteWebhook(ctx context.Context, id string, userID string) error {
+	storage.mx.Lock()
+	defer storage.mx.Unlock()
+
+	webhook, ok := storage.state.Webhooks[id]
+	if !ok || webhook.UserID != userID {
+		return domain.ErrWebhookNotFound
+	}
+
+	delete(storage.state.Webhooks, id)
+
+	for eventID, event := range storage.state.Events {
+		if event.WebhookID == id && event.Status == domain.WebhookEventPending {
+			event.Status = domain.WebhookEventFailed
+			storage.state.Events[eventID] = event
+		}
+	}
+
+	return storage.changed()
+}
+
+// SaveWebhookEvents save events to outbox.
+func (storage *memoryWebhookStorage) SaveWebhookEvents(ctx context.Context, events []domain.WebhookEvent) error {
+	storage.mx.Lock()
+	defer storage.mx.Unlock()
+
+	for _, event := range events {
+		storage.state.Events[event.ID] = event
+	}
+
+	return storage.changed()
+}
+
+// GetPendingWebhookEvents return pending events which next attempt time is already come.
+func (storage *memoryWebhookStorage) GetPendingWebhookEvents(ctx context.Context, now time.Time, limit int) ([]domain.WebhookEvent, error) {
+	storage.mx.RLock()
+	defer storage.mx.RUnlock()
+
+	events := make([]domain.WebhookEvent, 0)
+
+	for _, event := range storage.state.Events {
+		if event.Status == domain.WebhookEventPending && !event.NextAttemptAt.After(now) {
+			events = append(events, event)
+		}
+	}
+
+	sort.Slice(events, func(i, j int) bool {
+		return events[i].NextAttemptAt.Before(events[j].NextAttemptAt)
+	})
+
+	if len(events) > limit {
+		events = events[:limit]
+	}
+
+	return events, nil
+}
+
+// UpdateWebhookEvent update status, attempts and next attempt time of event.
+func (storage *memoryWebhookStorage) UpdateWebhookEvent(ctx context.Context, event domain.WebhookEvent) error {
+	storage.mx.Lock()
+	defer storage.mx.Unlock()
+
+	storage.state.Events[event.ID] = event
+	return storage.changed()
+}
+
+// SaveWebhookDelivery save delivery attempt to log.
+func (storage *memoryWebhookStorage) SaveWebhookDelivery(ctx context.Context, delivery domain.WebhookDelivery) error {
+	storage.mx.Lock()
+	defer storage.mx.Unlock()
+
+	storage.state.Deliveries = append(storage.state.Deliveries, delivery)
+	return storage.changed()
+}
+
+// GetWebhookDeliveries return delivery log of given webhook.
+func (storage *memoryWebhookStorage) GetWebhookDeliveries(ctx context.Context, webhookID string) ([]domain.WebhookDelivery, error) {
+	storage.mx.RLock()
+	defer storage.mx.RUnlock()
+
+	deliveries := make([]domain.WebhookDelivery, 0)
+
+	for _, delivery := range storage.state.Deliveries {
+		if delivery.WebhookID == webhookID {
+			deliveries = append(deliveries, delivery)
+		}
+	}
+
+	return deliveries, nil
+}
+
+func (storage *memoryWebhookStorage) changed() error {
+	if storage.onChange == nil {
+		return nil
+	}
+
+	rawState, err := json.Marshal(&storage.state)
+	if err != nil {
+		return err
+	}
+
+	return storage.onChange(rawState)
+}
+
+func (storage *memoryWebhookStorage) load(rawState []byte) error {
+	storage.mx.Lock()
+	defer storage.mx.Unlock()
+
+	state := newMemoryWebhookStorage().state
+	if err := json.Unmarshal(rawState, &state); err != nil {
+		return err
+	}
+
+	storage.state = state
+
+	return nil
+}
@@ -0,0 +1,99 @@
+package storage
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/MowlCoder/go-url-shortener/internal/domain"
+)
+
+func TestMemoryWebhookStorage_Webhooks(t *testing.T) {
+	storage := newMemoryWebhookStorage()
+	ctx := context.Background()
+
+	require.NoError(t, storage.SaveWebhook(ctx, domain.Webhook{ID: "1", UserID: "user"}))
+	require.NoError(t, storage.SaveWebhook(ctx, domain.Webhook{ID: "2", UserID: "another"}))
+
+	webhooks, err := storage.GetWebhooksByUserID(ctx, "user")
+	require.NoError(t, err)
+	assert.Len(t, webhooks, 1)
+
+	webhook, err := storage.GetWebhookByID(ctx, "2")
+	require.NoError(t, err)
+	assert.Equal(t, "another", webhook.UserID)
+
+	assert.ErrorIs(t, storage.DeleteWebhook(ctx, "2", "user"), domain.ErrWebhookNotFound)
+	require.NoError(t, storage.DeleteWebhook(ctx, "2", "another"))
+
+	_, err = storage.GetWebhookByID(ctx, "2")
+	assert.ErrorIs(t, err, domain.ErrWebhookNotFound)
+}
+
+func TestMemoryWebhookStorage_Events(t *testing.T) {
+	storage := newMemoryWebhookStorage()
+	ctx := context.Background()
+	now := time.Now()
+
+	require.NoError(t, storage.SaveWebhook(ctx, domain.Webhook{ID: "hook", UserID: "user"}))
+	require.NoError(t, storage.SaveWebhookEvents(ctx, []domain.WebhookEvent{
+		{ID: "1", WebhookID: "hook", Status: domain.WebhookEventPending, NextAttemptAt: now.Add(-time.Minute)},
+		{ID: "2", WebhookID: "hook", Status: domain.WebhookEventPending, NextAttemptAt: now.Add(time.Minute)},
+		{ID: "3", WebhookID: "hook", Status: domain.WebhookEventDelivered, NextAttemptAt: now.Add(-time.Minute)},
+	}))
+
+	events, err := storage.GetPendingWebhookEvents(ctx, now, 10)
+	require.NoError(t, err)
+	require.Len(t, events, 1)
+	assert.Equal(t, "1", events[0].ID)
+
+	events[0].Status = domain.WebhookEventDelivered
+	require.NoError(t, storage.UpdateWebhookEvent(ctx, events[0]))
+
+	events, err = storage.GetPendingWebhookEvents(ctx, now.Add(time.Hour), 10)
+	require.NoError(t, err)
+	require.Len(t, events, 1)
+	assert.Equal(t, "2", events[0].ID)
+
+	require.NoError(t, storage.DeleteWebhook(ctx, "hook", "user"))
+
+	events, err = storage.GetPendingWebhookEvents(ctx, now.Add(time.Hour), 10)
+	require.NoError(t, err)
+	assert.Empty(t, events)
+}
+
+func TestMemoryWebhookStorage_Deliveries(t *testing.T) {
+	storage := newMemoryWebhookStorage()
+	ctx := context.Background()
+
+	require.NoError(t, storage.SaveWebhookDelivery(ctx, domain.WebhookDelivery{ID: "1", WebhookID: "hook"}))
+	require.NoError(t, storage.SaveWebhookDelivery(ctx, domain.WebhookDelivery{ID: "2", WebhookID: "another"}))
+
+	deliveries, err := storage.GetWebhookDeliveries(ctx, "hook")
+	require.NoError(t, err)
+	require.Len(t, deliveries, 1)
+	assert.Equal(t, "1", deliveries[0].ID)
+}
+
+func TestMemoryWebhookStorage_Persistence(t *testing.T) {
+	var rawState []byte
+
+	storage := newMemoryWebhookStorage()
+	storage.onChange = func(state []byte) error {
+		rawState = state
+		return nil
+	}
+
+	require.NoError(t, storage.SaveWebhook(context.Background(), domain.Webhook{ID: "hook", UserID: "user"}))
+	require.NotEmpty(t, rawState)
+
+	restoredStorage := newMemoryWebhookStorage()
+	require.NoError(t, restoredStorage.load(rawState))
+
+	webhook, err := restoredStorage.GetWebhookByID(context.Background(), "hook")
+	require.NoError(t, err)
+	assert.Equal(t, "user", webhook.UserID)
+}
@@ -0,0 +1,57 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE shorten_url ADD COLUMN IF NOT EXISTS clicks INTEGER NOT NULL DEFAULT 0;
+
+CREATE TABLE IF NOT EXISTS webhooks (
+   id VARCHAR( 100 ) PRIMARY KEY,
+   user_id VARCHAR( 100 ) NOT NULL,
+   url TEXT NOT NULL,
+   secret VARCHAR( 100 ) NOT NULL,
+   events TEXT[] NOT NULL,
+   created_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS webhooks_user_id_idx ON webhooks (user_id);
+
+CREATE TABLE IF NOT EXISTS webhook_events (
+   id VARCHAR( 100 ) PRIMARY KEY,
+   webhook_id VARCHAR( 100 ) NOT NULL,
+   event_type VARCHAR( 50 ) NOT NULL,
+   payload JSONB NOT NULL,
+   status VARCHAR( 20 ) NOT NULL,
+   attempts INTEGER NOT NULL DEFAULT 0,
+   next_attempt_at TIMESTAMP NOT NULL,
+   created_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS webhook_events_pending_idx ON webhook_events (status, next_attempt_at);
+
+CREATE TABLE IF NOT EXISTS webhook_deliveries (
+   id VARCHAR( 100 ) PRIMARY KEY,
+   webhook_id VARCHAR( 100 ) NOT NULL,
+   event_id VARCHAR( 100 ) NOT NULL,
+   event_type VARCHAR( 50 ) NOT NULL,
+   attempt INTEGER NOT NULL,
+   status_code INTEGER NOT NULL,
+   success BOOLEAN NOT NULL,
+   error TEXT NOT NULL DEFAULT '',
+   duration_ms BIGINT NOT NULL,
+   attempted_at TIMESTAMP NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS webhook_deliveries_webhook_id_idx ON webhook_deliveries (webhook_id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS webhook_deliveries_webhook_id_idx;
+DROP TABLE IF EXISTS webhook_deliveries;
+
+DROP INDEX IF EXISTS webhook_events_pending_idx;
+DROP TABLE IF EXISTS webhook_events;
+
+DROP INDEX IF EXISTS webhooks_user_id_idx;
+DROP TABLE IF EXISTS webhooks;
+
+ALTER TABLE shorten_url DROP COLUMN IF EXISTS clicks;
+-- +goose StatementEnd
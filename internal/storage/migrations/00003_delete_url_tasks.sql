@@ -0,0 +1,21 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS delete_url_tasks (
+   id VARCHAR( 100 ) PRIMARY KEY,
+   user_id VARCHAR( 100 ) NOT NULL,
+   short_urls TEXT[] NOT NULL,
+   status VARCHAR( 20 ) NOT NULL,
+   attempts INTEGER NOT NULL DEFAULT 0,
+   last_error TEXT NOT NULL DEFAULT '',
+   created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+   completed_at TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS delete_url_tasks_status_idx ON delete_url_tasks (status, created_at);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS delete_url_tasks_status_idx;
+DROP TABLE IF EXISTS delete_url_tasks;
+-- +goose StatementEnd
@@ -0,0 +1,11 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE shorten_url ADD COLUMN IF NOT EXISTS tags TEXT[] NOT NULL DEFAULT '{}';
+ALTER TABLE shorten_url ADD COLUMN IF NOT EXISTS expires_at TIMESTAMPTZ NULL;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE shorten_url DROP COLUMN IF EXISTS expires_at;
+ALTER TABLE shorten_url DROP COLUMN IF EXISTS tags;
+-- +goose StatementEnd
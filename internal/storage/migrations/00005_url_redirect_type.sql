@@ -0,0 +1,9 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE shorten_url ADD COLUMN IF NOT EXISTS redirect_type TEXT NOT NULL DEFAULT '';
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE shorten_url DROP COLUMN IF EXISTS redirect_type;
+-- +goose StatementEnd
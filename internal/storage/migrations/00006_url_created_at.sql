@@ -0,0 +1,11 @@
+-- +goose Up
+-- +goose StatementBegin
+-- existing urls keep NULL, their creation time is unknown
+ALTER TABLE shorten_url ADD COLUMN IF NOT EXISTS created_at TIMESTAMPTZ NULL;
+ALTER TABLE shorten_url ALTER COLUMN created_at SET DEFAULT now();
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE shorten_url DROP COLUMN IF EXISTS created_at;
+-- +goose StatementEnd
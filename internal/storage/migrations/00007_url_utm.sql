@@ -0,0 +1,11 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE shorten_url ADD COLUMN IF NOT EXISTS utm JSONB NULL;
+ALTER TABLE shorten_url ADD COLUMN IF NOT EXISTS query_passthrough BOOLEAN NOT NULL DEFAULT FALSE;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE shorten_url DROP COLUMN IF EXISTS query_passthrough;
+ALTER TABLE shorten_url DROP COLUMN IF EXISTS utm;
+-- +goose StatementEnd
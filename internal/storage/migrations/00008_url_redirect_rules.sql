@@ -0,0 +1,9 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE shorten_url ADD COLUMN IF NOT EXISTS redirect_rules JSONB NULL;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE shorten_url DROP COLUMN IF EXISTS redirect_rules;
+-- +goose StatementEnd
@@ -0,0 +1,12 @@
+-- +goose Up
+-- +goose StatementBegin
+-- created_at is written by server in UTC, completed_at is set by NOW() in time zone of session
+ALTER TABLE delete_url_tasks ALTER COLUMN created_at TYPE TIMESTAMPTZ USING created_at AT TIME ZONE 'UTC';
+ALTER TABLE delete_url_tasks ALTER COLUMN completed_at TYPE TIMESTAMPTZ;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE delete_url_tasks ALTER COLUMN created_at TYPE TIMESTAMP USING created_at AT TIME ZONE 'UTC';
+ALTER TABLE delete_url_tasks ALTER COLUMN completed_at TYPE TIMESTAMP;
+-- +goose StatementEnd
@@ -2,6 +2,7 @@ package storage
 
 import (
 	"context"
+	"time"
 
 	"github.com/MowlCoder/go-url-shortener/internal/config"
 	"github.com/MowlCoder/go-url-shortener/internal/domain"
@@ -9,14 +10,47 @@ import (
 
 // URLStorage is common interface for all storages.
 type URLStorage interface {
+	DeleteTaskStorage
+	WebhookStorage
+
 	SaveSeveralURL(ctx context.Context, dtos []domain.SaveShortURLDto) ([]domain.ShortenedURL, error)
 	SaveURL(ctx context.Context, dto domain.SaveShortURLDto) (*domain.ShortenedURL, error)
 	GetByShortURL(ctx context.Context, shortURL string) (*domain.ShortenedURL, error)
 	GetURLsByUserID(ctx context.Context, userID string) ([]domain.ShortenedURL, error)
+	GetURLsAfter(ctx context.Context, afterShortURL string, limit int) ([]domain.ShortenedURL, error)
+	GetURLsByUserIDAfter(ctx context.Context, userID string, afterShortURL string, limit int) ([]domain.ShortenedURL, error)
+	ImportURLs(ctx context.Context, urls []domain.ShortenedURL) ([]string, error)
 	DeleteByShortURLs(ctx context.Context, shortURLs []string, userID string) error
 	DoDeleteURLTasks(ctx context.Context, tasks []domain.DeleteURLsTask) error
+	SetRedirectRules(ctx context.Context, shortURL string, userID string, rules []domain.RedirectRule) error
+	IncrementClicks(ctx context.Context, shortURL string, count int) (int, error)
 	GetInternalStats(ctx context.Context) (*domain.InternalStats, error)
 	Ping(ctx context.Context) error
+	CheckMigrations(ctx context.Context) error
+	Close() error
+}
+
+// DeleteTaskStorage is common interface for storing journal of delete url tasks.
+// Tasks are marked as completed by URLStorage.DoDeleteURLTasks.
+type DeleteTaskStorage interface {
+	SaveDeleteTask(ctx context.Context, task domain.DeleteURLsTask) error
+	GetDeleteTaskByID(ctx context.Context, id string) (*domain.DeleteURLsTask, error)
+	GetPendingDeleteTasks(ctx context.Context, limit int) ([]domain.DeleteURLsTask, error)
+	FailDeleteTasks(ctx context.Context, ids []string, reason string) error
+	CountPendingDeleteTasks(ctx context.Context) (int, error)
+}
+
+// WebhookStorage is common interface for storing webhook subscriptions, outbox events and delivery log.
+type WebhookStorage interface {
+	SaveWebhook(ctx context.Context, webhook domain.Webhook) error
+	GetWebhookByID(ctx context.Context, id string) (*domain.Webhook, error)
+	GetWebhooksByUserID(ctx context.Context, userID string) ([]domain.Webhook, error)
+	DeleteWebhook(ctx context.Context, id string, userID string) error
+	SaveWebhookEvents(ctx context.Context, events []domain.WebhookEvent) error
+	GetPendingWebhookEvents(ctx context.Context, now time.Time, limit int) ([]domain.WebhookEvent, error)
+	UpdateWebhookEvent(ctx context.Context, event domain.WebhookEvent) error
+	SaveWebhookDelivery(ctx context.Context, delivery domain.WebhookDelivery) error
+	GetWebhookDeliveries(ctx context.Context, webhookID string) ([]domain.WebhookDelivery, error)
 }
 
 // New create URLStorage base on given config.
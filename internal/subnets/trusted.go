@@ -0,0 +1,95 @@
+// Package subnets checks, if ip address belongs to one of trusted subnets. Trusted subnets can be changed
+// while server is running, so they are reloaded without restart.
+package subnets
+
+import (
+	"fmt"
+	"net"
+	"strings"
+	"sync/atomic"
+)
+
+// Trusted is list of trusted IPv4 and IPv6 subnets, that is safe to use from many goroutines.
+// Empty list trusts nobody.
+type Trusted struct {
+	ipNets atomic.Pointer[[]*net.IPNet]
+}
+
+// NewTrusted is constructor function to create Trusted from comma separated subnets, see Parse.
+func NewTrusted(value string) (*Trusted, error) {
+	trusted := &Trusted{}
+
+	if err := trusted.Update(value); err != nil {
+		return nil, err
+	}
+
+	return trusted, nil
+}
+
+// Parse parses comma separated subnets in CIDR format, like "10.0.0.0/8, fd00::/8".
+// Single ip address is subnet of this address only.
+func Parse(value string) ([]*net.IPNet, error) {
+	ipNets := make([]*net.IPNet, 0)
+
+	for _, item := range strings.Split(value, ",") {
+		item = strings.TrimSpace(item)
+		if item == "" {
+			continue
+		}
+
+		if !strings.Contains(item, "/") {
+			ip := net.ParseIP(item)
+			if ip == nil {
+				return nil, fmt.Errorf("invalid ip address: %s", item)
+			}
+
+			if ip4 := ip.To4(); ip4 != nil {
+				ip = ip4
+			}
+
+			ipNets = append(ipNets, &net.IPNet{IP: ip, Mask: net.CIDRMask(len(ip)*8, len(ip)*8)})
+			continue
+		}
+
+		_, ipNet, err := net.ParseCIDR(item)
+		if err != nil {
+			return nil, err
+		}
+
+		ipNets = append(ipNets, ipNet)
+	}
+
+	return ipNets, nil
+}
+
+// Update replaces trusted subnets. Current subnets are kept, if value is invalid.
+func (t *Trusted) Update(value string) error {
+	ipNets, err := Parse(value)
+	if err != nil {
+		return err
+	}
+
+	t.Set(ipNets)
+
+	return nil
+}
+
+// Set replaces trusted subnets with already parsed ones, see Parse.
+func (t *Trusted) Set(ipNets []*net.IPNet) {
+	t.ipNets.Store(&ipNets)
+}
+
+// Contains reports whether ip belongs to one of trusted subnets.
+func (t *Trusted) Contains(ip net.IP) bool {
+	if ip == nil {
+		return false
+	}
+
+	for _, ipNet := range *t.ipNets.Load() {
+		if ipNet.Contains(ip) {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,65 @@
+package subnets
+
+import (
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTrusted(t *testing.T) {
+	t.Run("contains", func(t *testing.T) {
+		testCases := []struct {
+			name     string
+			cidr     string
+			ip       string
+			expected bool
+		}{
+			{name: "ip in subnet", cidr: "192.168.1.0/24", ip: "192.168.1.10", expected: true},
+			{name: "ip not in subnet", cidr: "192.168.1.0/24", ip: "10.0.0.1", expected: false},
+			{name: "invalid ip", cidr: "192.168.1.0/24", ip: "", expected: false},
+			{name: "empty subnet", cidr: "", ip: "192.168.1.10", expected: false},
+			{name: "ip in second subnet", cidr: "192.168.1.0/24, 10.0.0.0/8", ip: "10.1.2.3", expected: true},
+			{name: "ipv6 in subnet", cidr: "192.168.1.0/24,fd00::/8", ip: "fd00::1", expected: true},
+			{name: "ipv6 not in subnet", cidr: "fd00::/8", ip: "2001:db8::1", expected: false},
+			{name: "single ip", cidr: "10.0.0.1", ip: "10.0.0.1", expected: true},
+			{name: "other than single ip", cidr: "10.0.0.1", ip: "10.0.0.2", expected: false},
+			{name: "single ipv6", cidr: "::1", ip: "::1", expected: true},
+		}
+
+		for _, tc := range testCases {
+			t.Run(tc.name, func(t *testing.T) {
+				trusted, err := NewTrusted(tc.cidr)
+				require.NoError(t, err)
+
+				assert.Equal(t, tc.expected, trusted.Contains(net.ParseIP(tc.ip)))
+			})
+		}
+	})
+
+	t.Run("invalid subnet", func(t *testing.T) {
+		for _, value := range []string{"192.168.1.0/33", "10.0.0.0/8,host", "fd00::/129"} {
+			trusted, err := NewTrusted(value)
+
+			assert.Error(t, err, value)
+			assert.Nil(t, trusted)
+		}
+	})
+
+	t.Run("update", func(t *testing.T) {
+		trusted, err := NewTrusted("192.168.1.0/24")
+		require.NoError(t, err)
+
+		require.NoError(t, trusted.Update("10.0.0.0/8"))
+		assert.True(t, trusted.Contains(net.ParseIP("10.0.0.1")))
+		assert.False(t, trusted.Contains(net.ParseIP("192.168.1.10")))
+
+		// invalid subnet keeps current one
+		assert.Error(t, trusted.Update("bad"))
+		assert.True(t, trusted.Contains(net.ParseIP("10.0.0.1")))
+
+		require.NoError(t, trusted.Update(""))
+		assert.False(t, trusted.Contains(net.ParseIP("10.0.0.1")))
+	})
+}
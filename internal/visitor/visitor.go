@@ -0,0 +1,65 @@
+// Package visitor detects properties of visitor of short url, that redirect rules match on,
+// from headers of http request.
+package visitor
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/MowlCoder/go-url-shortener/internal/domain"
+)
+
+// Device returns device of visitor with given User-Agent, one of domain.Device* constants.
+// User agents, that are not recognized as mobile, are desktop.
+func Device(userAgent string) string {
+	switch {
+	case strings.Contains(userAgent, "iPhone"), strings.Contains(userAgent, "iPad"), strings.Contains(userAgent, "iPod"):
+		return domain.DeviceIOS
+	case strings.Contains(userAgent, "Android"):
+		return domain.DeviceAndroid
+	case strings.Contains(userAgent, "Mobi"):
+		return domain.DeviceMobile
+	default:
+		return domain.DeviceDesktop
+	}
+}
+
+// Language returns the most preferred language of Accept-Language header. Languages with zero quality
+// and wildcard are skipped, of languages with equal quality the first one wins. Empty string is returned,
+// when header has no language.
+func Language(acceptLanguage string) string {
+	language := ""
+	bestQuality := 0.0
+
+	for _, item := range strings.Split(acceptLanguage, ",") {
+		tag, params, _ := strings.Cut(item, ";")
+		tag = strings.TrimSpace(tag)
+
+		if tag == "" || tag == "*" {
+			continue
+		}
+
+		quality := 1.0
+
+		for _, param := range strings.Split(params, ";") {
+			name, value, _ := strings.Cut(strings.TrimSpace(param), "=")
+			if name != "q" {
+				continue
+			}
+
+			parsed, err := strconv.ParseFloat(value, 64)
+			if err != nil {
+				parsed = 0
+			}
+
+			quality = parsed
+		}
+
+		if quality > bestQuality {
+			language = tag
+			bestQuality = quality
+		}
+	}
+
+	return language
+}
@@ -0,0 +1,52 @@
+package visitor
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/MowlCoder/go-url-shortener/internal/domain"
+)
+
+func TestDevice(t *testing.T) {
+	testCases := []struct {
+		name      string
+		userAgent string
+		expected  string
+	}{
+		{name: "iphone", userAgent: "Mozilla/5.0 (iPhone; CPU iPhone OS 17_0 like Mac OS X) AppleWebKit/605.1.15 Mobile/15E148", expected: domain.DeviceIOS},
+		{name: "ipad", userAgent: "Mozilla/5.0 (iPad; CPU OS 16_6 like Mac OS X) AppleWebKit/605.1.15", expected: domain.DeviceIOS},
+		{name: "android", userAgent: "Mozilla/5.0 (Linux; Android 14; Pixel 8) AppleWebKit/537.36 Chrome/120.0 Mobile Safari/537.36", expected: domain.DeviceAndroid},
+		{name: "other mobile", userAgent: "Mozilla/5.0 (Mobile; rv:48.0) Gecko/48.0 Firefox/48.0 KAIOS/2.5", expected: domain.DeviceMobile},
+		{name: "desktop", userAgent: "Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 Chrome/120.0 Safari/537.36", expected: domain.DeviceDesktop},
+		{name: "empty", userAgent: "", expected: domain.DeviceDesktop},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			assert.Equal(t, testCase.expected, Device(testCase.userAgent))
+		})
+	}
+}
+
+func TestLanguage(t *testing.T) {
+	testCases := []struct {
+		name           string
+		acceptLanguage string
+		expected       string
+	}{
+		{name: "single", acceptLanguage: "de-DE", expected: "de-DE"},
+		{name: "first of equal quality", acceptLanguage: "fr-CH, fr;q=0.9, en;q=0.8", expected: "fr-CH"},
+		{name: "highest quality", acceptLanguage: "en;q=0.5, pt-BR;q=0.8", expected: "pt-BR"},
+		{name: "zero quality is skipped", acceptLanguage: "en;q=0", expected: ""},
+		{name: "wildcard is skipped", acceptLanguage: "*, es;q=0.1", expected: "es"},
+		{name: "invalid quality is skipped", acceptLanguage: "en;q=high, it;q=0.2", expected: "it"},
+		{name: "empty", acceptLanguage: "", expected: ""},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			assert.Equal(t, testCase.expected, Language(testCase.acceptLanguage))
+		})
+	}
+}
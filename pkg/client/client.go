@@ -0,0 +1,220 @@
+// Package client is Go client of URL shortener.
+//
+// Client talks to the server either over REST api (NewHTTPClient) or over gRPC (NewGRPCClient),
+// both implement Client interface. Server issues token on the first request, client remembers it
+// and sends it with next requests, so urls created by the client belong to the same user.
+// Use WithTokenStore to keep token between runs of the program.
+//
+// Requests failed with 5xx status code or codes.Unavailable are retried with exponential backoff.
+package client
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc"
+)
+
+// Client is client of URL shortener.
+type Client interface {
+	// ShortURL shorts url and returns short url. If url is already shortened, existing short url is returned.
+	ShortURL(ctx context.Context, originalURL string) (string, error)
+	// ShortBatch shorts several urls in one request.
+	ShortBatch(ctx context.Context, originalURLs []string) ([]ShortenedURL, error)
+	// ListMine returns urls of current user.
+	ListMine(ctx context.Context) ([]ShortenedURL, error)
+	// Delete schedules deletion of urls of current user by their ids and returns id of deletion task.
+	Delete(ctx context.Context, shortURLs []string) (string, error)
+	// Stats returns internal stats of the server. Client must be in trusted subnet.
+	Stats(ctx context.Context) (*Stats, error)
+	// Token returns current token of user, empty if server has not issued it yet.
+	Token() string
+	// Close releases resources of client.
+	Close() error
+}
+
+// ShortenedURL is url of user.
+type ShortenedURL struct {
+	ShortURL    string `json:"short_url"`
+	OriginalURL string `json:"original_url"`
+}
+
+// Stats is internal stats of the server.
+type Stats struct {
+	URLs  int64 `json:"urls"`
+	Users int64 `json:"users"`
+}
+
+// StatusError is returned by HTTP client when server responds with unexpected status code.
+type StatusError struct {
+	Message    string
+	StatusCode int
+}
+
+func (e *StatusError) Error() string {
+	return fmt.Sprintf("server responded with %d: %s", e.StatusCode, e.Message)
+}
+
+// Option configures client.
+type Option func(o *options)
+
+type options struct {
+	tokenStore  TokenStore
+	httpClient  *http.Client
+	headers     map[string]string
+	token       string
+	dialOptions []grpc.DialOption
+	retry       RetryPolicy
+}
+
+func newOptions(opts []Option) *options {
+	o := &options{
+		headers: make(map[string]string),
+		retry:   DefaultRetryPolicy,
+	}
+
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	return o
+}
+
+// WithToken sets token of user. It takes precedence over token from token store.
+func WithToken(token string) Option {
+	return func(o *options) {
+		o.token = token
+	}
+}
+
+// WithTokenStore sets store, where token is loaded from on creation of client and saved to when server issues new one.
+func WithTokenStore(store TokenStore) Option {
+	return func(o *options) {
+		o.tokenStore = store
+	}
+}
+
+// WithRetryPolicy sets retry policy. Use RetryPolicy{MaxAttempts: 1} to disable retries.
+func WithRetryPolicy(policy RetryPolicy) Option {
+	return func(o *options) {
+		o.retry = policy
+	}
+}
+
+// WithHeader sets header sent with every request, as HTTP header or as gRPC metadata.
+func WithHeader(key string, value string) Option {
+	return func(o *options) {
+		o.headers[key] = value
+	}
+}
+
+// WithHTTPClient sets http.Client used by HTTP client.
+func WithHTTPClient(httpClient *http.Client) Option {
+	return func(o *options) {
+		o.httpClient = httpClient
+	}
+}
+
+// WithDialOptions sets additional options of gRPC connection.
+func WithDialOptions(dialOptions ...grpc.DialOption) Option {
+	return func(o *options) {
+		o.dialOptions = append(o.dialOptions, dialOptions...)
+	}
+}
+
+// tokenHolder keeps token of user and saves new token to store.
+type tokenHolder struct {
+	store TokenStore
+	token string
+	mx    sync.RWMutex
+}
+
+func newTokenHolder(o *options) (*tokenHolder, error) {
+	holder := &tokenHolder{
+		store: o.tokenStore,
+		token: o.token,
+	}
+
+	if holder.token == "" && holder.store != nil {
+		token, err := holder.store.LoadToken()
+		if err != nil {
+			return nil, fmt.Errorf("load token: %w", err)
+		}
+
+		holder.token = token
+	}
+
+	return holder, nil
+}
+
+func (h *tokenHolder) get() string {
+	h.mx.RLock()
+	defer h.mx.RUnlock()
+
+	return h.token
+}
+
+func (h *tokenHolder) set(token string) error {
+	h.mx.Lock()
+	defer h.mx.Unlock()
+
+	if token == "" || token == h.token {
+		return nil
+	}
+
+	h.token = token
+
+	if h.store == nil {
+		return nil
+	}
+
+	if err := h.store.SaveToken(token); err != nil {
+		return fmt.Errorf("save token: %w", err)
+	}
+
+	return nil
+}
+
+// RetryPolicy describes how failed requests are retried.
+type RetryPolicy struct {
+	// MaxAttempts is max count of attempts including the first one.
+	MaxAttempts    int
+	InitialBackoff time.Duration
+	MaxBackoff     time.Duration
+}
+
+// DefaultRetryPolicy is retry policy used by default.
+var DefaultRetryPolicy = RetryPolicy{
+	MaxAttempts:    3,
+	InitialBackoff: time.Millisecond * 100,
+	MaxBackoff:     time.Second * 2,
+}
+
+// do calls fn until it succeeds, returns not retryable error or attempts are over.
+func (p RetryPolicy) do(ctx context.Context, isRetryable func(err error) bool, fn func() error) error {
+	backoff := p.InitialBackoff
+
+	for attempt := 1; ; attempt++ {
+		err := fn()
+		if err == nil || attempt >= p.MaxAttempts || !isRetryable(err) {
+			return err
+		}
+
+		timer := time.NewTimer(backoff)
+
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return err
+		case <-timer.C:
+		}
+
+		backoff *= 2
+		if p.MaxBackoff > 0 && backoff > p.MaxBackoff {
+			backoff = p.MaxBackoff
+		}
+	}
+}
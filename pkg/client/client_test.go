@@ -0,0 +1,194 @@
+package client_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/MowlCoder/go-url-shortener/pkg/client"
+	"github.com/MowlCoder/go-url-shortener/pkg/client/clienttest"
+)
+
+func TestHTTPClient(t *testing.T) {
+	server := clienttest.NewServer(t)
+	ctx := context.Background()
+	tokenPath := filepath.Join(t.TempDir(), "token")
+	c := server.NewHTTPClient(client.WithTokenStore(client.NewFileTokenStore(tokenPath)))
+
+	shortURL, err := c.ShortURL(ctx, "https://example.com")
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(shortURL, server.HTTPURL))
+	require.NotEmpty(t, c.Token())
+
+	sameShortURL, err := c.ShortURL(ctx, "https://example.com")
+	require.NoError(t, err)
+	assert.Equal(t, shortURL, sameShortURL)
+
+	batch, err := c.ShortBatch(ctx, []string{"https://a.example.com", "https://b.example.com"})
+	require.NoError(t, err)
+	require.Len(t, batch, 2)
+	assert.Equal(t, "https://a.example.com", batch[0].OriginalURL)
+
+	// new client restores token from the store, so it sees urls of the same user
+	restoredClient := server.NewHTTPClient(client.WithTokenStore(client.NewFileTokenStore(tokenPath)))
+	assert.Equal(t, c.Token(), restoredClient.Token())
+
+	urls, err := restoredClient.ListMine(ctx)
+	require.NoError(t, err)
+	assert.Len(t, urls, 3)
+
+	taskID, err := restoredClient.Delete(ctx, []string{strings.TrimPrefix(shortURL, server.HTTPURL+"/")})
+	require.NoError(t, err)
+	assert.NotEmpty(t, taskID)
+
+	stats, err := c.Stats(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, &client.Stats{URLs: 3, Users: 1}, stats)
+}
+
+func TestGRPCClient(t *testing.T) {
+	server := clienttest.NewServer(t)
+	ctx := context.Background()
+	tokenPath := filepath.Join(t.TempDir(), "token")
+	c := server.NewGRPCClient(client.WithTokenStore(client.NewFileTokenStore(tokenPath)))
+
+	batch, err := c.ShortBatch(ctx, []string{"https://a.example.com", "https://b.example.com"})
+	require.NoError(t, err)
+	require.Len(t, batch, 2)
+	assert.Equal(t, "https://b.example.com", batch[1].OriginalURL)
+	require.NotEmpty(t, c.Token())
+
+	// token issued over gRPC is accepted by REST api
+	httpClient := server.NewHTTPClient(client.WithTokenStore(client.NewFileTokenStore(tokenPath)))
+	httpShortURL, err := httpClient.ShortURL(ctx, "https://example.com")
+	require.NoError(t, err)
+
+	// conflict is signalled by server, client returns existing short url
+	shortURL, err := c.ShortURL(ctx, "https://example.com")
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(shortURL, server.HTTPURL))
+	assert.Equal(t, httpShortURL, shortURL)
+
+	urls, err := c.ListMine(ctx)
+	require.NoError(t, err)
+	assert.Len(t, urls, 3)
+
+	taskID, err := c.Delete(ctx, []string{urls[0].ShortURL})
+	require.NoError(t, err)
+	assert.NotEmpty(t, taskID)
+
+	stats, err := c.Stats(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, &client.Stats{URLs: 3, Users: 1}, stats)
+
+	invalidClient := server.NewGRPCClient(client.WithToken("invalid"))
+	_, err = invalidClient.ListMine(ctx)
+	assert.Equal(t, codes.Unauthenticated, status.Code(err))
+}
+
+func TestHTTPClient_Retry(t *testing.T) {
+	attempts := 0
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+
+		if attempts < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+
+		w.WriteHeader(http.StatusCreated)
+		w.Write([]byte(`{"result":"http://short/abc"}`))
+	}))
+	defer server.Close()
+
+	retryPolicy := client.RetryPolicy{MaxAttempts: 3, InitialBackoff: time.Millisecond}
+
+	c, err := client.NewHTTPClient(server.URL, client.WithRetryPolicy(retryPolicy))
+	require.NoError(t, err)
+
+	shortURL, err := c.ShortURL(context.Background(), "https://example.com")
+	require.NoError(t, err)
+	assert.Equal(t, "http://short/abc", shortURL)
+	assert.Equal(t, 3, attempts)
+
+	attempts = -10
+
+	_, err = c.ShortURL(context.Background(), "https://example.com")
+
+	var statusErr *client.StatusError
+	require.True(t, errors.As(err, &statusErr))
+	assert.Equal(t, http.StatusServiceUnavailable, statusErr.StatusCode)
+	assert.Equal(t, -7, attempts)
+}
+
+func TestHTTPClient_NoRetryOnClientError(t *testing.T) {
+	attempts := 0
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		w.WriteHeader(http.StatusForbidden)
+	}))
+	defer server.Close()
+
+	c, err := client.NewHTTPClient(server.URL)
+	require.NoError(t, err)
+
+	_, err = c.Stats(context.Background())
+
+	var statusErr *client.StatusError
+	require.True(t, errors.As(err, &statusErr))
+	assert.Equal(t, http.StatusForbidden, statusErr.StatusCode)
+	assert.Equal(t, 1, attempts)
+}
+
+func TestHTTPClient_NoRetryOfNotIdempotentRequest(t *testing.T) {
+	attempts := 0
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	retryPolicy := client.RetryPolicy{MaxAttempts: 3, InitialBackoff: time.Millisecond}
+
+	c, err := client.NewHTTPClient(server.URL, client.WithRetryPolicy(retryPolicy))
+	require.NoError(t, err)
+
+	_, err = c.ShortBatch(context.Background(), []string{"https://example.com"})
+	require.Error(t, err)
+	assert.Equal(t, 1, attempts)
+
+	_, err = c.Delete(context.Background(), []string{"abc"})
+	require.Error(t, err)
+	assert.Equal(t, 2, attempts)
+
+	_, err = c.ListMine(context.Background())
+	require.Error(t, err)
+	assert.Equal(t, 5, attempts)
+}
+
+func TestFileTokenStore(t *testing.T) {
+	store := client.NewFileTokenStore(filepath.Join(t.TempDir(), "dir", "token"))
+
+	token, err := store.LoadToken()
+	require.NoError(t, err)
+	assert.Empty(t, token)
+
+	require.NoError(t, store.SaveToken("token"))
+
+	token, err = store.LoadToken()
+	require.NoError(t, err)
+	assert.Equal(t, "token", token)
+}
@@ -0,0 +1,160 @@
+// Package clienttest provides in-process URL shortener for tests of code that uses client package.
+//
+// Server is built by the same server.NewApp as production server, but with in-memory storage,
+// so it behaves exactly like production server.
+package clienttest
+
+import (
+	"context"
+	"net"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/MowlCoder/go-url-shortener/internal/config"
+	"github.com/MowlCoder/go-url-shortener/internal/geoip"
+	"github.com/MowlCoder/go-url-shortener/internal/lifecycle"
+	"github.com/MowlCoder/go-url-shortener/internal/logger"
+	"github.com/MowlCoder/go-url-shortener/internal/server"
+	"github.com/MowlCoder/go-url-shortener/internal/storage"
+	"github.com/MowlCoder/go-url-shortener/pkg/client"
+)
+
+const (
+	// TrustedIP is ip address, that is in trusted subnet of the server. Clients created by Server
+	// send it in X-Real-IP header or metadata, which is honoured because local address is trusted proxy.
+	TrustedIP = "10.1.0.1"
+
+	// AllowedOrigin is origin, from which browsers are allowed to call the server.
+	AllowedOrigin = "http://dashboard.example.com"
+
+	trustedSubnet  = "10.1.0.0/16"
+	trustedProxies = "127.0.0.1,::1"
+)
+
+// Server is in-process URL shortener serving REST api and gRPC api on local addresses.
+type Server struct {
+	tb testing.TB
+	// HTTPURL is base url of REST api and of short urls.
+	HTTPURL string
+	// GRPCAddr is address of gRPC api.
+	GRPCAddr string
+}
+
+// NewServer starts server. Server is stopped when test finishes.
+func NewServer(tb testing.TB) *Server {
+	tb.Helper()
+
+	customLogger, err := logger.NewLogger(logger.Options{Level: "error"})
+	if err != nil {
+		tb.Fatalf("create logger: %s", err)
+	}
+
+	urlStorage, err := storage.NewInMemoryStorage()
+	if err != nil {
+		tb.Fatalf("create storage: %s", err)
+	}
+
+	grpcListener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		tb.Fatalf("listen grpc: %s", err)
+	}
+
+	httpServer := httptest.NewUnstartedServer(nil)
+	appConfig := &config.AppConfig{
+		BaseHTTPAddr:         httpServer.Listener.Addr().String(),
+		BaseGRPCAddr:         grpcListener.Addr().String(),
+		BaseShortURLAddr:     "http://" + httpServer.Listener.Addr().String(),
+		TrustedSubnet:        trustedSubnet,
+		TrustedProxies:       trustedProxies,
+		CORSAllowedOrigins:   AllowedOrigin,
+		CORSAllowedMethods:   "GET,POST,PUT,DELETE",
+		CORSAllowCredentials: true,
+		PreviewOpenGraph:     true,
+		CORSMaxAge:           time.Minute * 10,
+		CookieSameSite:       "lax",
+		TokenRefreshBefore:   time.Hour,
+		DeleteFlushInterval:  time.Millisecond * 100,
+		DeleteBatchSize:      500,
+		ShutdownTimeout:      time.Second * 5,
+		MaxDeleteBacklog:     10000,
+	}
+
+	lifecycleManager := lifecycle.NewManager(customLogger)
+	app, err := server.NewApp(appConfig, server.AppOptions{
+		Storage:   urlStorage,
+		Countries: &geoip.Reader{},
+		Logger:    customLogger,
+		Lifecycle: lifecycleManager,
+		LoadConfig: func() (*config.AppConfig, error) {
+			reloaded := *appConfig
+			return &reloaded, nil
+		},
+	})
+	if err != nil {
+		tb.Fatalf("create app: %s", err)
+	}
+
+	httpServer.Config.Handler = app.HTTPHandler
+	httpServer.Start()
+	go app.GRPCServer.Serve(grpcListener)
+
+	app.Start(nil, func(ctx context.Context) error {
+		httpServer.Close()
+		return nil
+	}, func(ctx context.Context) error {
+		app.GRPCServer.Stop()
+		return nil
+	})
+	lifecycleManager.AppendAfterStopped("storage", func(ctx context.Context) error {
+		return urlStorage.Close()
+	})
+	lifecycleManager.SetReady()
+
+	tb.Cleanup(func() {
+		ctx, cancel := context.WithTimeout(context.Background(), appConfig.ShutdownTimeout)
+		defer cancel()
+
+		if err := lifecycleManager.Shutdown(ctx); err != nil {
+			tb.Errorf("stop server: %s", err)
+		}
+	})
+
+	return &Server{
+		tb:       tb,
+		HTTPURL:  appConfig.BaseShortURLAddr,
+		GRPCAddr: appConfig.BaseGRPCAddr,
+	}
+}
+
+// NewHTTPClient creates client of REST api of the server. Client is closed when test finishes.
+func (s *Server) NewHTTPClient(opts ...client.Option) *client.HTTPClient {
+	s.tb.Helper()
+
+	httpClient, err := client.NewHTTPClient(s.HTTPURL, append([]client.Option{client.WithHeader("X-Real-IP", TrustedIP)}, opts...)...)
+	if err != nil {
+		s.tb.Fatalf("create http client: %s", err)
+	}
+
+	s.tb.Cleanup(func() {
+		httpClient.Close()
+	})
+
+	return httpClient
+}
+
+// NewGRPCClient creates client of gRPC api of the server. Client is closed when test finishes.
+func (s *Server) NewGRPCClient(opts ...client.Option) *client.GRPCClient {
+	s.tb.Helper()
+
+	grpcClient, err := client.NewGRPCClient(s.GRPCAddr, append([]client.Option{client.WithHeader("x-real-ip", TrustedIP)}, opts...)...)
+	if err != nil {
+		s.tb.Fatalf("create grpc client: %s", err)
+	}
+
+	s.tb.Cleanup(func() {
+		grpcClient.Close()
+	})
+
+	return grpcClient
+}
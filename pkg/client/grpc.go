@@ -0,0 +1,230 @@
+package client
+
+import (
+	"context"
+	"strconv"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	"github.com/MowlCoder/go-url-shortener/proto"
+)
+
+// TokenMetadataKey is metadata key, where server expects token of user and sends issued token.
+const TokenMetadataKey = "token"
+
+// GRPCClient is client of URL shortener gRPC api.
+type GRPCClient struct {
+	conn    *grpc.ClientConn
+	client  proto.ShortenerClient
+	token   *tokenHolder
+	headers map[string]string
+	retry   RetryPolicy
+}
+
+var _ Client = (*GRPCClient)(nil)
+
+// NewGRPCClient is constructor function to create GRPCClient.
+// Connection is insecure unless transport credentials are passed by WithDialOptions.
+func NewGRPCClient(addr string, opts ...Option) (*GRPCClient, error) {
+	o := newOptions(opts)
+
+	token, err := newTokenHolder(o)
+	if err != nil {
+		return nil, err
+	}
+
+	dialOptions := append([]grpc.DialOption{grpc.WithTransportCredentials(insecure.NewCredentials())}, o.dialOptions...)
+
+	conn, err := grpc.Dial(addr, dialOptions...)
+	if err != nil {
+		return nil, err
+	}
+
+	return &GRPCClient{
+		conn:    conn,
+		client:  proto.NewShortenerClient(conn),
+		token:   token,
+		headers: o.headers,
+		retry:   o.retry,
+	}, nil
+}
+
+// ShortURL shorts url and returns short url. If url is already shortened, existing short url is returned.
+func (c *GRPCClient) ShortURL(ctx context.Context, originalURL string) (string, error) {
+	var response *proto.ShortURLResponse
+
+	err := c.invoke(ctx, true, func(ctx context.Context, opts ...grpc.CallOption) (err error) {
+		response, err = c.client.ShortURL(ctx, &proto.ShortURLRequest{Url: originalURL}, opts...)
+		return err
+	})
+	if status.Code(err) == codes.AlreadyExists {
+		for _, detail := range status.Convert(err).Details() {
+			if existing, ok := detail.(*errdetails.ResourceInfo); ok && existing.ResourceType == "short_url" {
+				return existing.ResourceName, nil
+			}
+		}
+	}
+
+	if err != nil {
+		return "", err
+	}
+
+	return response.Result, nil
+}
+
+// ShortBatch shorts several urls in one request.
+func (c *GRPCClient) ShortBatch(ctx context.Context, originalURLs []string) ([]ShortenedURL, error) {
+	request := &proto.ShortBatchURLRequest{
+		Dtos: make([]*proto.RequestBatchURLDto, 0, len(originalURLs)),
+	}
+
+	for i, originalURL := range originalURLs {
+		request.Dtos = append(request.Dtos, &proto.RequestBatchURLDto{
+			OriginalUrl:   originalURL,
+			CorrelationId: strconv.Itoa(i),
+		})
+	}
+
+	var response *proto.ShortBatchURLResponse
+
+	err := c.invoke(ctx, false, func(ctx context.Context, opts ...grpc.CallOption) (err error) {
+		response, err = c.client.ShortBatchURL(ctx, request, opts...)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	urls := make([]ShortenedURL, 0, len(response.Dtos))
+	for _, url := range response.Dtos {
+		if originalURL, ok := correlatedURL(originalURLs, url.CorrelationId); ok {
+			urls = append(urls, ShortenedURL{
+				ShortURL:    url.ShortUrl,
+				OriginalURL: originalURL,
+			})
+		}
+	}
+
+	return urls, nil
+}
+
+// ListMine returns urls of current user.
+func (c *GRPCClient) ListMine(ctx context.Context) ([]ShortenedURL, error) {
+	var response *proto.GetMyURLsResponse
+
+	err := c.invoke(ctx, true, func(ctx context.Context, opts ...grpc.CallOption) (err error) {
+		response, err = c.client.GetMyURLs(ctx, &proto.GetMyURLsRequest{}, opts...)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	urls := make([]ShortenedURL, 0, len(response.Result))
+	for _, url := range response.Result {
+		urls = append(urls, ShortenedURL{
+			ShortURL:    url.ShortUrl,
+			OriginalURL: url.OriginalUrl,
+		})
+	}
+
+	return urls, nil
+}
+
+// Delete schedules deletion of urls of current user by their ids and returns id of deletion task.
+func (c *GRPCClient) Delete(ctx context.Context, shortURLs []string) (string, error) {
+	var response *proto.DeleteURLsResponse
+
+	err := c.invoke(ctx, false, func(ctx context.Context, opts ...grpc.CallOption) (err error) {
+		response, err = c.client.DeleteURLs(ctx, &proto.DeleteURLsRequest{Urls: shortURLs}, opts...)
+		return err
+	})
+	if err != nil {
+		return "", err
+	}
+
+	return response.TaskId, nil
+}
+
+// Stats returns internal stats of the server. Client must be in trusted subnet.
+func (c *GRPCClient) Stats(ctx context.Context) (*Stats, error) {
+	var response *proto.GetStatsResponse
+
+	err := c.invoke(ctx, true, func(ctx context.Context, opts ...grpc.CallOption) (err error) {
+		response, err = c.client.GetStats(ctx, &proto.GetStatsRequest{}, opts...)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &Stats{
+		URLs:  response.Urls,
+		Users: response.Users,
+	}, nil
+}
+
+// Token returns current token of user.
+func (c *GRPCClient) Token() string {
+	return c.token.get()
+}
+
+// Close closes connection to the server.
+func (c *GRPCClient) Close() error {
+	return c.conn.Close()
+}
+
+// invoke calls rpc with token and headers in metadata and remembers token issued by the server.
+// Idempotent rpc is retried on codes.Unavailable. Not idempotent rpc is not retried, because it
+// may be already handled by the server; rpc, that was not sent, is retried transparently by grpc.
+func (c *GRPCClient) invoke(
+	ctx context.Context,
+	idempotent bool,
+	call func(ctx context.Context, opts ...grpc.CallOption) error,
+) error {
+	isRetryable := isRetryableGRPCError
+	if !idempotent {
+		isRetryable = func(err error) bool { return false }
+	}
+
+	return c.retry.do(ctx, isRetryable, func() error {
+		var header metadata.MD
+
+		callErr := call(c.outgoingContext(ctx), grpc.Header(&header))
+
+		if tokens := header.Get(TokenMetadataKey); len(tokens) > 0 {
+			if err := c.token.set(tokens[0]); err != nil {
+				return err
+			}
+		}
+
+		return callErr
+	})
+}
+
+func (c *GRPCClient) outgoingContext(ctx context.Context) context.Context {
+	pairs := make([]string, 0, len(c.headers)*2+2)
+
+	for key, value := range c.headers {
+		pairs = append(pairs, key, value)
+	}
+
+	if token := c.token.get(); token != "" {
+		pairs = append(pairs, TokenMetadataKey, token)
+	}
+
+	if len(pairs) == 0 {
+		return ctx
+	}
+
+	return metadata.AppendToOutgoingContext(ctx, pairs...)
+}
+
+func isRetryableGRPCError(err error) bool {
+	return status.Code(err) == codes.Unavailable
+}
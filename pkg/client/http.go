@@ -0,0 +1,307 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// TokenCookieName is cookie, where server expects token of user.
+const TokenCookieName = "token"
+
+const defaultHTTPTimeout = time.Second * 30
+
+// HTTPClient is client of URL shortener REST api.
+type HTTPClient struct {
+	httpClient *http.Client
+	token      *tokenHolder
+	headers    map[string]string
+	baseURL    string
+	retry      RetryPolicy
+}
+
+var _ Client = (*HTTPClient)(nil)
+
+// NewHTTPClient is constructor function to create HTTPClient. Address without scheme is treated as http address.
+func NewHTTPClient(baseURL string, opts ...Option) (*HTTPClient, error) {
+	o := newOptions(opts)
+
+	token, err := newTokenHolder(o)
+	if err != nil {
+		return nil, err
+	}
+
+	httpClient := o.httpClient
+	if httpClient == nil {
+		httpClient = &http.Client{Timeout: defaultHTTPTimeout}
+	}
+
+	return &HTTPClient{
+		httpClient: httpClient,
+		token:      token,
+		headers:    o.headers,
+		baseURL:    NormalizeBaseURL(baseURL),
+		retry:      o.retry,
+	}, nil
+}
+
+// NormalizeBaseURL adds http scheme to address without scheme and removes trailing slash.
+func NormalizeBaseURL(baseURL string) string {
+	if !strings.HasPrefix(baseURL, "http://") && !strings.HasPrefix(baseURL, "https://") {
+		baseURL = "http://" + baseURL
+	}
+
+	return strings.TrimRight(baseURL, "/")
+}
+
+// ShortURL shorts url and returns short url. If url is already shortened, existing short url is returned,
+// so request is safely retried.
+func (c *HTTPClient) ShortURL(ctx context.Context, originalURL string) (string, error) {
+	var response struct {
+		Result string `json:"result"`
+	}
+
+	err := c.do(ctx, http.MethodPost, "/api/shorten", true, map[string]string{"url": originalURL}, &response, http.StatusCreated, http.StatusConflict)
+	if err != nil {
+		return "", err
+	}
+
+	return response.Result, nil
+}
+
+// ShortBatch shorts several urls in one request.
+func (c *HTTPClient) ShortBatch(ctx context.Context, originalURLs []string) ([]ShortenedURL, error) {
+	type batchRequest struct {
+		OriginalURL   string `json:"original_url"`
+		CorrelationID string `json:"correlation_id"`
+	}
+
+	type batchResponse struct {
+		ShortURL      string `json:"short_url"`
+		CorrelationID string `json:"correlation_id"`
+	}
+
+	request := make([]batchRequest, 0, len(originalURLs))
+	for i, originalURL := range originalURLs {
+		request = append(request, batchRequest{
+			OriginalURL:   originalURL,
+			CorrelationID: strconv.Itoa(i),
+		})
+	}
+
+	var response []batchResponse
+	if err := c.do(ctx, http.MethodPost, "/api/shorten/batch", false, request, &response, http.StatusCreated); err != nil {
+		return nil, err
+	}
+
+	urls := make([]ShortenedURL, 0, len(response))
+	for _, url := range response {
+		if originalURL, ok := correlatedURL(originalURLs, url.CorrelationID); ok {
+			urls = append(urls, ShortenedURL{
+				ShortURL:    url.ShortURL,
+				OriginalURL: originalURL,
+			})
+		}
+	}
+
+	return urls, nil
+}
+
+// ListMine returns urls of current user.
+func (c *HTTPClient) ListMine(ctx context.Context) ([]ShortenedURL, error) {
+	urls := make([]ShortenedURL, 0)
+
+	if err := c.do(ctx, http.MethodGet, "/api/user/urls", true, nil, &urls, http.StatusOK, http.StatusNoContent); err != nil {
+		return nil, err
+	}
+
+	return urls, nil
+}
+
+// Delete schedules deletion of urls of current user by their ids and returns id of deletion task.
+func (c *HTTPClient) Delete(ctx context.Context, shortURLs []string) (string, error) {
+	var response struct {
+		ID string `json:"id"`
+	}
+
+	if err := c.do(ctx, http.MethodDelete, "/api/user/urls", false, shortURLs, &response, http.StatusAccepted); err != nil {
+		return "", err
+	}
+
+	return response.ID, nil
+}
+
+// Stats returns internal stats of the server. Client must be in trusted subnet.
+func (c *HTTPClient) Stats(ctx context.Context) (*Stats, error) {
+	response := &Stats{}
+
+	if err := c.do(ctx, http.MethodGet, "/api/internal/stats", true, nil, response, http.StatusOK); err != nil {
+		return nil, err
+	}
+
+	return response, nil
+}
+
+// Token returns current token of user.
+func (c *HTTPClient) Token() string {
+	return c.token.get()
+}
+
+// Close closes idle connections.
+func (c *HTTPClient) Close() error {
+	c.httpClient.CloseIdleConnections()
+	return nil
+}
+
+// do sends request with JSON body and decodes JSON response to result.
+// Response with status code not in expectedCodes is returned as StatusError.
+// Request, that is not idempotent, is retried only if it was not sent to the server.
+func (c *HTTPClient) do(
+	ctx context.Context,
+	method string,
+	path string,
+	idempotent bool,
+	body interface{},
+	result interface{},
+	expectedCodes ...int,
+) error {
+	var rawBody []byte
+
+	if body != nil {
+		var err error
+
+		rawBody, err = json.Marshal(body)
+		if err != nil {
+			return err
+		}
+	}
+
+	isRetryable := isRetryableHTTPError
+	if !idempotent {
+		isRetryable = isNotSentHTTPError
+	}
+
+	var rawResponse []byte
+
+	err := c.retry.do(ctx, isRetryable, func() error {
+		var err error
+
+		rawResponse, err = c.send(ctx, method, path, rawBody, expectedCodes)
+		return err
+	})
+	if err != nil {
+		return err
+	}
+
+	if result == nil || len(rawResponse) == 0 {
+		return nil
+	}
+
+	return json.Unmarshal(rawResponse, result)
+}
+
+func (c *HTTPClient) send(ctx context.Context, method string, path string, rawBody []byte, expectedCodes []int) ([]byte, error) {
+	var requestBody io.Reader
+
+	if rawBody != nil {
+		requestBody = bytes.NewReader(rawBody)
+	}
+
+	request, err := http.NewRequestWithContext(ctx, method, c.baseURL+path, requestBody)
+	if err != nil {
+		return nil, err
+	}
+
+	if rawBody != nil {
+		request.Header.Set("Content-Type", "application/json")
+	}
+
+	for key, value := range c.headers {
+		request.Header.Set(key, value)
+	}
+
+	if token := c.token.get(); token != "" {
+		request.AddCookie(&http.Cookie{Name: TokenCookieName, Value: token})
+	}
+
+	response, err := c.httpClient.Do(request)
+	if err != nil {
+		return nil, err
+	}
+	defer response.Body.Close()
+
+	for _, cookie := range response.Cookies() {
+		if cookie.Name == TokenCookieName {
+			if err := c.token.set(cookie.Value); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	rawResponse, err := io.ReadAll(response.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	if !containsCode(expectedCodes, response.StatusCode) {
+		message := strings.TrimSpace(string(rawResponse))
+		if message == "" {
+			message = http.StatusText(response.StatusCode)
+		}
+
+		return nil, &StatusError{
+			StatusCode: response.StatusCode,
+			Message:    message,
+		}
+	}
+
+	return rawResponse, nil
+}
+
+// isRetryableHTTPError reports if request failed because of server error or network error.
+func isRetryableHTTPError(err error) bool {
+	var statusErr *StatusError
+	if errors.As(err, &statusErr) {
+		return statusErr.StatusCode >= http.StatusInternalServerError
+	}
+
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return false
+	}
+
+	var netErr net.Error
+	return errors.As(err, &netErr)
+}
+
+// isNotSentHTTPError reports if request failed before it was sent, because connection to the server
+// was not established.
+func isNotSentHTTPError(err error) bool {
+	var opErr *net.OpError
+	return errors.As(err, &opErr) && opErr.Op == "dial"
+}
+
+func containsCode(codes []int, code int) bool {
+	for _, c := range codes {
+		if c == code {
+			return true
+		}
+	}
+
+	return false
+}
+
+func correlatedURL(originalURLs []string, correlationID string) (string, bool) {
+	i, err := strconv.Atoi(correlationID)
+	if err != nil || i < 0 || i >= len(originalURLs) {
+		return "", false
+	}
+
+	return originalURLs[i], true
+}
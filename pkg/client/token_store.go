@@ -0,0 +1,50 @@
+package client
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// TokenStore keeps token of user between runs of the program.
+type TokenStore interface {
+	// LoadToken returns saved token, empty if there is no token.
+	LoadToken() (string, error)
+	SaveToken(token string) error
+}
+
+// FileTokenStore keeps token in file. File is readable only by owner.
+type FileTokenStore struct {
+	path string
+}
+
+// NewFileTokenStore is constructor function to create FileTokenStore.
+func NewFileTokenStore(path string) *FileTokenStore {
+	return &FileTokenStore{
+		path: path,
+	}
+}
+
+// LoadToken reads token from file. Not existing file means there is no token.
+func (s *FileTokenStore) LoadToken() (string, error) {
+	rawToken, err := os.ReadFile(s.path)
+	if errors.Is(err, os.ErrNotExist) {
+		return "", nil
+	}
+
+	if err != nil {
+		return "", err
+	}
+
+	return strings.TrimSpace(string(rawToken)), nil
+}
+
+// SaveToken writes token to file, creating parent directories.
+func (s *FileTokenStore) SaveToken(token string) error {
+	if err := os.MkdirAll(filepath.Dir(s.path), 0700); err != nil {
+		return err
+	}
+
+	return os.WriteFile(s.path, []byte(token+"\n"), 0600)
+}
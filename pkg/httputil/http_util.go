@@ -55,8 +55,27 @@ func SendJSONErrorResponse(w http.ResponseWriter, code int, error string) error
 // SendRedirectResponse send redirect response with status code 307
 // and given location in header Location.
 func SendRedirectResponse(w http.ResponseWriter, location string) {
+	SendRedirectResponseWithCode(w, http.StatusTemporaryRedirect, location)
+}
+
+// SendRedirectResponseWithCode send redirect response with given status code
+// and given location in header Location.
+func SendRedirectResponseWithCode(w http.ResponseWriter, code int, location string) {
 	w.Header().Set("Location", location)
-	w.WriteHeader(http.StatusTemporaryRedirect)
+	w.WriteHeader(code)
+}
+
+// SendHTMLResponse send response with content-type text/html to the client
+// and given status code.
+func SendHTMLResponse(w http.ResponseWriter, code int, html []byte) error {
+	w.Header().Set("content-type", "text/html; charset=utf-8")
+	w.WriteHeader(code)
+
+	if _, err := w.Write(html); err != nil {
+		return err
+	}
+
+	return nil
 }
 
 // SendStatusCode send given status code to client.
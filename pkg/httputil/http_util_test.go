@@ -121,3 +121,35 @@ func TestSendRedirectResponse(t *testing.T) {
 		assert.Equal(t, url, res.Header.Get("Location"))
 	})
 }
+
+func TestSendRedirectResponseWithCode(t *testing.T) {
+	t.Run("Send redirect response with code", func(t *testing.T) {
+		url := "https://practicum.yandex.ru"
+		w := httptest.NewRecorder()
+		SendRedirectResponseWithCode(w, http.StatusPermanentRedirect, url)
+
+		res := w.Result()
+		require.Equal(t, http.StatusPermanentRedirect, res.StatusCode)
+		defer res.Body.Close()
+
+		assert.Equal(t, url, res.Header.Get("Location"))
+	})
+}
+
+func TestSendHTMLResponse(t *testing.T) {
+	t.Run("Send html response", func(t *testing.T) {
+		html := "<p>test</p>"
+		w := httptest.NewRecorder()
+		require.NoError(t, SendHTMLResponse(w, http.StatusOK, []byte(html)))
+
+		res := w.Result()
+		require.Equal(t, http.StatusOK, res.StatusCode)
+		defer res.Body.Close()
+
+		body, err := io.ReadAll(res.Body)
+		require.NoError(t, err)
+
+		assert.Equal(t, "text/html; charset=utf-8", res.Header.Get("content-type"))
+		assert.Equal(t, html, string(body))
+	})
+}
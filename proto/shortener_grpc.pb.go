@@ -19,12 +19,16 @@ import (
 const _ = grpc.SupportPackageIsVersion7
 
 const (
-	Shortener_ShortURL_FullMethodName      = "/shortener.Shortener/ShortURL"
-	Shortener_ShortBatchURL_FullMethodName = "/shortener.Shortener/ShortBatchURL"
-	Shortener_GetMyURLs_FullMethodName     = "/shortener.Shortener/GetMyURLs"
-	Shortener_DeleteURLs_FullMethodName    = "/shortener.Shortener/DeleteURLs"
-	Shortener_GetStats_FullMethodName      = "/shortener.Shortener/GetStats"
-	Shortener_Ping_FullMethodName          = "/shortener.Shortener/Ping"
+	Shortener_ShortURL_FullMethodName         = "/shortener.Shortener/ShortURL"
+	Shortener_ShortBatchURL_FullMethodName    = "/shortener.Shortener/ShortBatchURL"
+	Shortener_GetMyURLs_FullMethodName        = "/shortener.Shortener/GetMyURLs"
+	Shortener_StreamMyURLs_FullMethodName     = "/shortener.Shortener/StreamMyURLs"
+	Shortener_ShortURLStream_FullMethodName   = "/shortener.Shortener/ShortURLStream"
+	Shortener_DeleteURLs_FullMethodName       = "/shortener.Shortener/DeleteURLs"
+	Shortener_GetRedirectRules_FullMethodName = "/shortener.Shortener/GetRedirectRules"
+	Shortener_SetRedirectRules_FullMethodName = "/shortener.Shortener/SetRedirectRules"
+	Shortener_GetStats_FullMethodName         = "/shortener.Shortener/GetStats"
+	Shortener_Ping_FullMethodName             = "/shortener.Shortener/Ping"
 )
 
 // ShortenerClient is the client API for Shortener service.
@@ -34,7 +38,13 @@ type ShortenerClient interface {
 	ShortURL(ctx context.Context, in *ShortURLRequest, opts ...grpc.CallOption) (*ShortURLResponse, error)
 	ShortBatchURL(ctx context.Context, in *ShortBatchURLRequest, opts ...grpc.CallOption) (*ShortBatchURLResponse, error)
 	GetMyURLs(ctx context.Context, in *GetMyURLsRequest, opts ...grpc.CallOption) (*GetMyURLsResponse, error)
+	// StreamMyURLs sends urls of user one by one
+	StreamMyURLs(ctx context.Context, in *StreamMyURLsRequest, opts ...grpc.CallOption) (Shortener_StreamMyURLsClient, error)
+	// ShortURLStream shorts every received url and sends result as soon as it is ready
+	ShortURLStream(ctx context.Context, opts ...grpc.CallOption) (Shortener_ShortURLStreamClient, error)
 	DeleteURLs(ctx context.Context, in *DeleteURLsRequest, opts ...grpc.CallOption) (*DeleteURLsResponse, error)
+	GetRedirectRules(ctx context.Context, in *GetRedirectRulesRequest, opts ...grpc.CallOption) (*GetRedirectRulesResponse, error)
+	SetRedirectRules(ctx context.Context, in *SetRedirectRulesRequest, opts ...grpc.CallOption) (*SetRedirectRulesResponse, error)
 	GetStats(ctx context.Context, in *GetStatsRequest, opts ...grpc.CallOption) (*GetStatsResponse, error)
 	Ping(ctx context.Context, in *PingRequest, opts ...grpc.CallOption) (*PingResponse, error)
 }
@@ -74,6 +84,69 @@ func (c *shortenerClient) GetMyURLs(ctx context.Context, in *GetMyURLsRequest, o
 	return out, nil
 }
 
+func (c *shortenerClient) StreamMyURLs(ctx context.Context, in *StreamMyURLsRequest, opts ...grpc.CallOption) (Shortener_StreamMyURLsClient, error) {
+	stream, err := c.cc.NewStream(ctx, &Shortener_ServiceDesc.Streams[0], Shortener_StreamMyURLs_FullMethodName, opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &shortenerStreamMyURLsClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Shortener_StreamMyURLsClient interface {
+	Recv() (*UserShortenedURL, error)
+	grpc.ClientStream
+}
+
+type shortenerStreamMyURLsClient struct {
+	grpc.ClientStream
+}
+
+func (x *shortenerStreamMyURLsClient) Recv() (*UserShortenedURL, error) {
+	m := new(UserShortenedURL)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *shortenerClient) ShortURLStream(ctx context.Context, opts ...grpc.CallOption) (Shortener_ShortURLStreamClient, error) {
+	stream, err := c.cc.NewStream(ctx, &Shortener_ServiceDesc.Streams[1], Shortener_ShortURLStream_FullMethodName, opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &shortenerShortURLStreamClient{stream}
+	return x, nil
+}
+
+type Shortener_ShortURLStreamClient interface {
+	Send(*RequestBatchURLDto) error
+	Recv() (*ResponseBatchURLDto, error)
+	grpc.ClientStream
+}
+
+type shortenerShortURLStreamClient struct {
+	grpc.ClientStream
+}
+
+func (x *shortenerShortURLStreamClient) Send(m *RequestBatchURLDto) error {
+	return x.ClientStream.SendMsg(m)
+}
+
+func (x *shortenerShortURLStreamClient) Recv() (*ResponseBatchURLDto, error) {
+	m := new(ResponseBatchURLDto)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
 func (c *shortenerClient) DeleteURLs(ctx context.Context, in *DeleteURLsRequest, opts ...grpc.CallOption) (*DeleteURLsResponse, error) {
 	out := new(DeleteURLsResponse)
 	err := c.cc.Invoke(ctx, Shortener_DeleteURLs_FullMethodName, in, out, opts...)
@@ -83,6 +156,24 @@ func (c *shortenerClient) DeleteURLs(ctx context.Context, in *DeleteURLsRequest,
 	return out, nil
 }
 
+func (c *shortenerClient) GetRedirectRules(ctx context.Context, in *GetRedirectRulesRequest, opts ...grpc.CallOption) (*GetRedirectRulesResponse, error) {
+	out := new(GetRedirectRulesResponse)
+	err := c.cc.Invoke(ctx, Shortener_GetRedirectRules_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *shortenerClient) SetRedirectRules(ctx context.Context, in *SetRedirectRulesRequest, opts ...grpc.CallOption) (*SetRedirectRulesResponse, error) {
+	out := new(SetRedirectRulesResponse)
+	err := c.cc.Invoke(ctx, Shortener_SetRedirectRules_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *shortenerClient) GetStats(ctx context.Context, in *GetStatsRequest, opts ...grpc.CallOption) (*GetStatsResponse, error) {
 	out := new(GetStatsResponse)
 	err := c.cc.Invoke(ctx, Shortener_GetStats_FullMethodName, in, out, opts...)
@@ -108,7 +199,13 @@ type ShortenerServer interface {
 	ShortURL(context.Context, *ShortURLRequest) (*ShortURLResponse, error)
 	ShortBatchURL(context.Context, *ShortBatchURLRequest) (*ShortBatchURLResponse, error)
 	GetMyURLs(context.Context, *GetMyURLsRequest) (*GetMyURLsResponse, error)
+	// StreamMyURLs sends urls of user one by one
+	StreamMyURLs(*StreamMyURLsRequest, Shortener_StreamMyURLsServer) error
+	// ShortURLStream shorts every received url and sends result as soon as it is ready
+	ShortURLStream(Shortener_ShortURLStreamServer) error
 	DeleteURLs(context.Context, *DeleteURLsRequest) (*DeleteURLsResponse, error)
+	GetRedirectRules(context.Context, *GetRedirectRulesRequest) (*GetRedirectRulesResponse, error)
+	SetRedirectRules(context.Context, *SetRedirectRulesRequest) (*SetRedirectRulesResponse, error)
 	GetStats(context.Context, *GetStatsRequest) (*GetStatsResponse, error)
 	Ping(context.Context, *PingRequest) (*PingResponse, error)
 	mustEmbedUnimplementedShortenerServer()
@@ -127,9 +224,21 @@ func (UnimplementedShortenerServer) ShortBatchURL(context.Context, *ShortBatchUR
 func (UnimplementedShortenerServer) GetMyURLs(context.Context, *GetMyURLsRequest) (*GetMyURLsResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetMyURLs not implemented")
 }
+func (UnimplementedShortenerServer) StreamMyURLs(*StreamMyURLsRequest, Shortener_StreamMyURLsServer) error {
+	return status.Errorf(codes.Unimplemented, "method StreamMyURLs not implemented")
+}
+func (UnimplementedShortenerServer) ShortURLStream(Shortener_ShortURLStreamServer) error {
+	return status.Errorf(codes.Unimplemented, "method ShortURLStream not implemented")
+}
 func (UnimplementedShortenerServer) DeleteURLs(context.Context, *DeleteURLsRequest) (*DeleteURLsResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method DeleteURLs not implemented")
 }
+func (UnimplementedShortenerServer) GetRedirectRules(context.Context, *GetRedirectRulesRequest) (*GetRedirectRulesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetRedirectRules not implemented")
+}
+func (UnimplementedShortenerServer) SetRedirectRules(context.Context, *SetRedirectRulesRequest) (*SetRedirectRulesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetRedirectRules not implemented")
+}
 func (UnimplementedShortenerServer) GetStats(context.Context, *GetStatsRequest) (*GetStatsResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetStats not implemented")
 }
@@ -203,6 +312,53 @@ func _Shortener_GetMyURLs_Handler(srv interface{}, ctx context.Context, dec func
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Shortener_StreamMyURLs_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(StreamMyURLsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(ShortenerServer).StreamMyURLs(m, &shortenerStreamMyURLsServer{stream})
+}
+
+type Shortener_StreamMyURLsServer interface {
+	Send(*UserShortenedURL) error
+	grpc.ServerStream
+}
+
+type shortenerStreamMyURLsServer struct {
+	grpc.ServerStream
+}
+
+func (x *shortenerStreamMyURLsServer) Send(m *UserShortenedURL) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _Shortener_ShortURLStream_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(ShortenerServer).ShortURLStream(&shortenerShortURLStreamServer{stream})
+}
+
+type Shortener_ShortURLStreamServer interface {
+	Send(*ResponseBatchURLDto) error
+	Recv() (*RequestBatchURLDto, error)
+	grpc.ServerStream
+}
+
+type shortenerShortURLStreamServer struct {
+	grpc.ServerStream
+}
+
+func (x *shortenerShortURLStreamServer) Send(m *ResponseBatchURLDto) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func (x *shortenerShortURLStreamServer) Recv() (*RequestBatchURLDto, error) {
+	m := new(RequestBatchURLDto)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
 func _Shortener_DeleteURLs_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(DeleteURLsRequest)
 	if err := dec(in); err != nil {
@@ -221,6 +377,42 @@ func _Shortener_DeleteURLs_Handler(srv interface{}, ctx context.Context, dec fun
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Shortener_GetRedirectRules_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetRedirectRulesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ShortenerServer).GetRedirectRules(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Shortener_GetRedirectRules_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ShortenerServer).GetRedirectRules(ctx, req.(*GetRedirectRulesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Shortener_SetRedirectRules_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetRedirectRulesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ShortenerServer).SetRedirectRules(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Shortener_SetRedirectRules_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ShortenerServer).SetRedirectRules(ctx, req.(*SetRedirectRulesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _Shortener_GetStats_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(GetStatsRequest)
 	if err := dec(in); err != nil {
@@ -280,6 +472,14 @@ var Shortener_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "DeleteURLs",
 			Handler:    _Shortener_DeleteURLs_Handler,
 		},
+		{
+			MethodName: "GetRedirectRules",
+			Handler:    _Shortener_GetRedirectRules_Handler,
+		},
+		{
+			MethodName: "SetRedirectRules",
+			Handler:    _Shortener_SetRedirectRules_Handler,
+		},
 		{
 			MethodName: "GetStats",
 			Handler:    _Shortener_GetStats_Handler,
@@ -289,6 +489,18 @@ var Shortener_ServiceDesc = grpc.ServiceDesc{
 			Handler:    _Shortener_Ping_Handler,
 		},
 	},
-	Streams:  []grpc.StreamDesc{},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "StreamMyURLs",
+			Handler:       _Shortener_StreamMyURLs_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "ShortURLStream",
+			Handler:       _Shortener_ShortURLStream_Handler,
+			ServerStreams: true,
+			ClientStreams: true,
+		},
+	},
 	Metadata: "proto/shortener.proto",
 }